@@ -8,11 +8,20 @@
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
+// @description API-ключ: "ApiKey <ключ>" или просто ключ
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Токен доступа из /api/v1/auth/token: "Bearer <токен>"
 
 package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -20,12 +29,15 @@ import (
 	"syscall"
 	"time"
 
+	"RestApi/internal/blob"
 	"RestApi/internal/config"
 	"RestApi/internal/database"
+	"RestApi/internal/domain"
 	myhttp "RestApi/internal/http"
 	"RestApi/internal/http/handlers"
 	_ "RestApi/internal/http/handlers"
 	"RestApi/internal/http/middleware"
+	"RestApi/internal/jwt"
 	"RestApi/internal/service"
 	"RestApi/internal/storage/postgres"
 )
@@ -34,12 +46,13 @@ func main() {
 	// Загружаем конфигурацию
 	cfg := config.Load()
 
-	// Создаем контекст для работы
-	ctx := context.Background()
+	// Создаем контекст для работы; отменяется при остановке сервера
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
 
 	// Подключаемся к PostgreSQL
 	log.Println("Connecting to database...")
-	pool, err := database.NewPool(ctx, cfg.DatabaseURL())
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL(), cfg.DBRowLevelSecurity)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -49,19 +62,91 @@ func main() {
 	// Создаем репозиторий PostgreSQL
 	listRepo := postgres.NewListRepo(pool)
 	taskRepo := postgres.NewTaskRepo(pool)
+	tagRepo := postgres.NewTagRepo(pool)
+	trashRepo := postgres.NewTrashRepo(pool)
+	historyRepo := postgres.NewHistoryRepo(pool)
+	commentRepo := postgres.NewCommentRepo(pool)
+	attachmentRepo := postgres.NewAttachmentRepo(pool)
+	userRepo := postgres.NewUserRepo(pool)
+	apiKeyRepo := postgres.NewAPIKeyRepo(pool)
+	refreshTokenRepo := postgres.NewRefreshTokenRepo(pool)
+	workspaceRepo := postgres.NewWorkspaceRepo(pool)
+	searchRepo := postgres.NewSearchRepo(pool)
+
+	// Создаем хранилище содержимого вложений
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	// Создаем подпись токенов доступа
+	tokenSigner, err := newTokenSigner(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create token signer: %v", err)
+	}
 
 	// Создаем сервис
 	listService := service.NewListService(listRepo)
-	taskService := service.NewTaskService(taskRepo, listRepo)
+	taskService := service.NewTaskService(taskRepo, listRepo, userRepo)
+	if cfg.TaskWorkflow != "" {
+		workflow, err := domain.ParseTaskWorkflow(cfg.TaskWorkflow)
+		if err != nil {
+			log.Fatalf("Invalid TASK_WORKFLOW: %v", err)
+		}
+		taskService.WithWorkflow(workflow)
+	}
+	tagService := service.NewTagService(tagRepo, taskRepo, listRepo)
+	trashService := service.NewTrashService(trashRepo, cfg.TrashRetention)
+	historyService := service.NewHistoryService(historyRepo, taskRepo, listRepo)
+	commentService := service.NewCommentService(commentRepo, taskRepo, listRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, listRepo, blobStore, cfg.AttachmentMaxSize, cfg.AttachmentContentTypes)
+	userService := service.NewUserService(userRepo, refreshTokenRepo)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo).WithBootstrapKey(cfg.AdminAPIKey)
+	workspaceService := service.NewWorkspaceService(workspaceRepo)
+	searchService := service.NewSearchService(searchRepo)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, workspaceRepo, tokenSigner, service.TokenConfig{
+		Issuer:     cfg.JWTIssuer,
+		AccessTTL:  cfg.AccessTokenTTL,
+		RefreshTTL: cfg.RefreshTokenTTL,
+	})
+
+	// Фоновая очистка корзины
+	go trashService.RunPurge(ctx, cfg.TrashPurgeInterval)
+	// Фоновое удаление содержимого удаленных вложений
+	go attachmentService.RunBlobCleanup(ctx, cfg.TrashPurgeInterval)
 
 	// Создаем HTTP-роутер
 	listHandler := handlers.NewListHandler(listService)
 	taskHandler := handlers.NewTaskHandler(taskService)
-
-	httpServer := myhttp.NewHTTPServer(listHandler, taskHandler)
+	tagHandler := handlers.NewTagHandler(tagService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	historyHandler := handlers.NewHistoryHandler(historyService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	userHandler := handlers.NewUserHandler(userService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	authHandler := handlers.NewAuthHandler(authService)
+	workspaceHandler := handlers.NewWorkspaceHandler(workspaceService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	httpServer := myhttp.NewHTTPServer(listHandler, taskHandler, tagHandler, trashHandler, historyHandler, commentHandler, attachmentHandler, userHandler, apiKeyHandler, authHandler, workspaceHandler, searchHandler)
 
 	// Создаем обработчик с middleware
-	httpHandler := middleware.RequestID(httpServer)
+	httpHandler := middleware.Actor(httpServer)
+	// Рабочее пространство определяется после аутентификации: учетные данные могут быть к нему привязаны
+	httpHandler = middleware.Workspace(workspaceService)(httpHandler)
+	if cfg.AuthEnabled {
+		// X-User-Id не учитывается: пользователь берется только из ключа или токена
+		httpHandler = middleware.Auth(apiKeyService, authService)(httpHandler)
+		if cfg.AdminAPIKey == "" {
+			log.Println("ADMIN_API_KEY is not set, only API keys and users stored in the database can access the API")
+		}
+	} else {
+		// Режим разработки: пользователь для доступа к спискам берется из X-User-Id без проверки
+		httpHandler = middleware.User(httpHandler)
+		log.Println("WARNING: authentication is disabled (AUTH_ENABLED=false), any client can act as any user via X-User-Id; use only for development")
+	}
+	httpHandler = middleware.RequestID(httpHandler)
 	httpHandler = middleware.Logging(httpHandler)
 
 	// Создаем HTTP-сервер
@@ -87,6 +172,7 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stop()
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -96,3 +182,61 @@ func main() {
 
 	log.Println("Server stopped")
 }
+
+// newBlobStore создает хранилище вложений, выбранное в BLOB_STORE
+func newBlobStore(cfg config.Config) (blob.Store, error) {
+	switch cfg.BlobStore {
+	case "local":
+		return blob.NewLocalStore(cfg.BlobLocalDir)
+	case "s3":
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}, &http.Client{Timeout: 5 * time.Minute})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q: must be local or s3", cfg.BlobStore)
+	}
+}
+
+// newTokenSigner создает подпись токенов доступа по JWT_ALGORITHM
+func newTokenSigner(cfg config.Config) (jwt.Signer, error) {
+	switch cfg.JWTAlgorithm {
+	case jwt.HS256:
+		secret := []byte(cfg.JWTSecret)
+		if len(secret) == 0 {
+			log.Println("JWT_SECRET is not set, using a random secret: tokens are invalidated on restart")
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return nil, err
+			}
+		}
+		return jwt.NewHS256(secret)
+	case jwt.RS256:
+		var privateKey *rsa.PrivateKey
+		var publicKey *rsa.PublicKey
+		if cfg.JWTPrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			if privateKey, err = jwt.ParseRSAPrivateKey(data); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.JWTPublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.JWTPublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			if publicKey, err = jwt.ParseRSAPublicKey(data); err != nil {
+				return nil, err
+			}
+		}
+		return jwt.NewRS256(privateKey, publicKey)
+	default:
+		return nil, fmt.Errorf("unknown JWT_ALGORITHM %q: must be HS256 or RS256", cfg.JWTAlgorithm)
+	}
+}
@@ -11,7 +11,9 @@ import (
 
 // CreateListRequest defines model for CreateListRequest.
 type CreateListRequest struct {
-	Title string `json:"title"`
+	// Description Описание списка (до 1000 символов)
+	Description *string `json:"description,omitempty"`
+	Title       string  `json:"title"`
 }
 
 // CreateTaskRequest defines model for CreateTaskRequest.
@@ -42,6 +44,9 @@ type List struct {
 	// CreatedAt Время создания (RFC3339)
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 
+	// Description Описание списка
+	Description string `json:"description"`
+
 	// Id Идентификатор списка
 	Id openapi_types.UUID `json:"id"`
 
@@ -72,7 +77,9 @@ type Task struct {
 
 // UpdateListRequest defines model for UpdateListRequest.
 type UpdateListRequest struct {
-	Title string `json:"title"`
+	// Description Описание списка (до 1000 символов, пустая строка удаляет описание)
+	Description *string `json:"description,omitempty"`
+	Title       *string `json:"title,omitempty"`
 }
 
 // UpdateTaskRequest defines model for UpdateTaskRequest.
@@ -0,0 +1,43 @@
+// Package blob хранит содержимое файлов вложений отдельно от их метаданных.
+//
+// Store реализуется локальной файловой системой (LocalStore) и
+// S3-совместимым хранилищем (S3Store).
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob: not found")
+	ErrInvalidKey = errors.New("blob: invalid key")
+)
+
+// Store — хранилище содержимого файлов по ключу.
+// Ключ состоит из сегментов через "/", например tasks/<task_id>/<attachment_id>.
+type Store interface {
+	// Put сохраняет содержимое r под ключом key. size — размер в байтах
+	// или -1, если он заранее неизвестен.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get открывает содержимое на чтение; вызывающий закрывает его
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет содержимое; отсутствие ключа не считается ошибкой
+	Delete(ctx context.Context, key string) error
+}
+
+// validateKey не допускает пустые сегменты и выход за пределы хранилища
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: empty key", ErrInvalidKey)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, "\\\x00") {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
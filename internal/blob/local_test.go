@@ -0,0 +1,44 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "tasks/task-1/file-1", strings.NewReader("hello"), -1, "text/plain"))
+
+	reader, err := store.Get(ctx, "tasks/task-1/file-1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "hello", string(content))
+
+	require.NoError(t, store.Delete(ctx, "tasks/task-1/file-1"))
+	_, err = store.Get(ctx, "tasks/task-1/file-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Повторное удаление не считается ошибкой
+	assert.NoError(t, store.Delete(ctx, "tasks/task-1/file-1"))
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "tasks//file", "tasks/./file", "/absolute", `tasks\file`} {
+		err := store.Put(ctx, key, strings.NewReader("x"), 1, "")
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,264 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateLayout   = "20060102T150405Z"
+	amzDayLayout    = "20060102"
+)
+
+// S3Config — параметры подключения к S3-совместимому хранилищу
+type S3Config struct {
+	// Endpoint — адрес хранилища, например https://s3.eu-central-1.amazonaws.com или http://localhost:9000
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store хранит содержимое в бакете S3-совместимого хранилища.
+// Объекты адресуются в path-style (<endpoint>/<bucket>/<key>), запросы
+// подписываются AWS Signature Version 4 без подписи тела.
+type S3Store struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Store(cfg S3Config, client *http.Client) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.Region == "" {
+		return nil, fmt.Errorf("s3: endpoint, bucket and region are required")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &S3Store{cfg: cfg, client: client, now: time.Now}, nil
+}
+
+// Put загружает объект. Если размер неизвестен, содержимое сначала
+// записывается во временный файл: S3 требует Content-Length.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		spooled, spooledSize, err := spool(r)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			spooled.Close()
+			os.Remove(spooled.Name())
+		}()
+		r, size = spooled, spooledSize
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error("put", resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s3Error("get", resp)
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return s3Error("delete", resp)
+	}
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	target := s.cfg.Endpoint + "/" + escapePath(s.cfg.Bucket+"/"+key)
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("s3: build request: %w", err)
+	}
+	return req, nil
+}
+
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, s.now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3: %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// sign добавляет к запросу заголовки AWS Signature Version 4
+func (s *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(amzDateLayout)
+	day := now.Format(amzDayLayout)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": unsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := day + "/" + s.cfg.Region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hashHex(canonicalRequest),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.cfg.SecretKey, day, s.cfg.Region, s3Service), stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+// signingKey выводит ключ подписи из секретного ключа для дня, региона и сервиса
+func signingKey(secret, day, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// escapePath кодирует путь по правилам SigV4: все, кроме A-Z, a-z, 0-9, "-", "_", ".", "~"
+// и разделителей "/", записывается как %XX
+func escapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			escaped.WriteByte(c)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+// spool записывает содержимое во временный файл и возвращает его открытым на чтение с начала
+func spool(r io.Reader) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "blob-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("s3: create temp file: %w", err)
+	}
+
+	size, err := io.Copy(file, r)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, fmt.Errorf("s3: spool upload: %w", err)
+	}
+	return file, size, nil
+}
+
+func s3Error(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3: %s: unexpected status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,164 @@
+package blob
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 — минимальная замена S3 в памяти: хранит объекты по пути
+// и отклоняет запросы с неверной подписью
+type fakeS3 struct {
+	t       *testing.T
+	secret  string
+	region  string
+	mtx     sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.validSignature(r) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	path := r.URL.EscapedPath()
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "MissingContentLength", http.StatusLengthRequired)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		f.objects[path] = body
+		f.types[path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validSignature заново вычисляет подпись по полученному запросу
+func (f *fakeS3) validSignature(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(auth, s3Algorithm+" ") || len(amzDate) != len(amzDateLayout) {
+		return false
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		"host:" + r.Host + "\nx-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256") + "\nx-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		unsignedPayload,
+	}, "\n")
+	day := amzDate[:8]
+	scope := day + "/" + f.region + "/s3/aws4_request"
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + hashHex(canonicalRequest)
+	signature := hex.EncodeToString(hmacSHA256(signingKey(f.secret, day, f.region, "s3"), stringToSign))
+
+	return strings.HasSuffix(auth, "Signature="+signature) && strings.Contains(auth, "Credential=test-key/"+scope)
+}
+
+func newTestS3(t *testing.T, secret string) (*S3Store, *fakeS3) {
+	fake := &fakeS3{
+		t:       t,
+		secret:  "test-secret",
+		region:  "eu-central-1",
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  server.URL + "/",
+		Bucket:    "attachments",
+		Region:    "eu-central-1",
+		AccessKey: "test-key",
+		SecretKey: secret,
+	}, server.Client())
+	require.NoError(t, err)
+	return store, fake
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	store, fake := newTestS3(t, "test-secret")
+
+	// Размер неизвестен — содержимое проходит через временный файл
+	require.NoError(t, store.Put(ctx, "tasks/task-1/file 1", strings.NewReader("screenshot"), -1, "image/png"))
+	assert.Equal(t, []byte("screenshot"), fake.objects["/attachments/tasks/task-1/file%201"])
+	assert.Equal(t, "image/png", fake.types["/attachments/tasks/task-1/file%201"])
+
+	reader, err := store.Get(ctx, "tasks/task-1/file 1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "screenshot", string(content))
+
+	require.NoError(t, store.Delete(ctx, "tasks/task-1/file 1"))
+	_, err = store.Get(ctx, "tasks/task-1/file 1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3Store_WrongSecret(t *testing.T) {
+	store, _ := newTestS3(t, "wrong-secret")
+
+	err := store.Put(context.Background(), "tasks/task-1/file", strings.NewReader("x"), 1, "")
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestS3Store_Sign(t *testing.T) {
+	store, err := NewS3Store(S3Config{
+		Endpoint:  "https://s3.amazonaws.com",
+		Bucket:    "bucket",
+		Region:    "us-east-1",
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "secret",
+	}, nil)
+	require.NoError(t, err)
+
+	req, err := store.newRequest(context.Background(), http.MethodGet, "a/b", nil)
+	require.NoError(t, err)
+	store.sign(req, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, "20250102T030405Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, unsignedPayload, req.Header.Get("X-Amz-Content-Sha256"))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250102/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+}
+
+// Пример вывода ключа подписи из документации AWS Signature Version 4
+func TestSigningKey(t *testing.T) {
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+func TestEscapePath(t *testing.T) {
+	assert.Equal(t, "bucket/tasks/a%20b/%D1%84%2B~_.-", escapePath("bucket/tasks/a b/ф+~_.-"))
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -12,8 +15,54 @@ type Config struct {
 	DBUser     string
 	DBPassword string
 	DBName     string
+	// DBRowLevelSecurity — передавать рабочее пространство запроса в сессию БД
+	// (app.workspace_id) для политик из migrations/optional/row_level_security.sql
+	DBRowLevelSecurity bool
+	// TrashRetention — срок хранения удаленных списков и задач в корзине
+	TrashRetention time.Duration
+	// TrashPurgeInterval — период фоновой очистки корзины
+	TrashPurgeInterval time.Duration
+	// BlobStore — хранилище содержимого вложений: local или s3
+	BlobStore string
+	// BlobLocalDir — каталог локального хранилища вложений
+	BlobLocalDir string
+	S3Endpoint   string
+	S3Bucket     string
+	S3Region     string
+	S3AccessKey  string
+	S3SecretKey  string
+	// AttachmentMaxSize — максимальный размер вложения в байтах
+	AttachmentMaxSize int64
+	// AttachmentContentTypes — допустимые типы содержимого вложений
+	AttachmentContentTypes []string
+	// TaskWorkflow — статусы задач и переходы в формате domain.ParseTaskWorkflow;
+	// пустое значение — рабочий процесс по умолчанию
+	TaskWorkflow string
+	// AuthEnabled — требовать API-ключ или токен доступа для запросов к API (по умолчанию включено).
+	// Выключать только для разработки: без аутентификации пользователь берется из заголовка X-User-Id
+	AuthEnabled bool
+	// AdminAPIKey — ключ администратора, не хранящийся в БД; нужен для выпуска первых ключей
+	AdminAPIKey string
+	// JWTAlgorithm — алгоритм подписи токенов доступа: HS256 или RS256
+	JWTAlgorithm string
+	// JWTSecret — секрет HS256 (не короче 32 байт); пустой — случайный секрет на время работы сервера
+	JWTSecret string
+	// JWTPrivateKeyFile — закрытый ключ RS256 в PEM
+	JWTPrivateKeyFile string
+	// JWTPublicKeyFile — открытый ключ RS256 в PEM; по умолчанию выводится из закрытого
+	JWTPublicKeyFile string
+	// JWTIssuer — издатель токенов (iss)
+	JWTIssuer string
+	// AccessTokenTTL — срок действия токена доступа
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL — срок действия токена обновления
+	RefreshTokenTTL time.Duration
 }
 
+// defaultAttachmentContentTypes — типы вложений, разрешенные по умолчанию.
+// HTML и SVG не разрешены: браузер может исполнить их содержимое.
+const defaultAttachmentContentTypes = "image/png,image/jpeg,image/gif,image/webp,text/plain,application/pdf,application/json,application/zip"
+
 func Load() Config {
 	return Config{
 		Port:       getEnv("PORT", "8080"),
@@ -22,6 +71,35 @@ func Load() Config {
 		DBUser:     getEnv("DB_USER", "todo_user"),
 		DBPassword: getEnv("DB_PASSWORD", "todo_password"),
 		DBName:     getEnv("DB_NAME", "todo_db"),
+
+		DBRowLevelSecurity: getBool("DB_ROW_LEVEL_SECURITY", false),
+
+		TrashRetention:     getDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getDuration("TRASH_PURGE_INTERVAL", time.Hour),
+
+		BlobStore:    getEnv("BLOB_STORE", "local"),
+		BlobLocalDir: getEnv("BLOB_LOCAL_DIR", "./data/attachments"),
+		S3Endpoint:   getEnv("S3_ENDPOINT", ""),
+		S3Bucket:     getEnv("S3_BUCKET", ""),
+		S3Region:     getEnv("S3_REGION", "us-east-1"),
+		S3AccessKey:  getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:  getEnv("S3_SECRET_KEY", ""),
+
+		AttachmentMaxSize:      getInt64("ATTACHMENT_MAX_SIZE", 10<<20),
+		AttachmentContentTypes: getList("ATTACHMENT_CONTENT_TYPES", defaultAttachmentContentTypes),
+
+		TaskWorkflow: getEnv("TASK_WORKFLOW", ""),
+
+		AuthEnabled: getBool("AUTH_ENABLED", true),
+		AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
+
+		JWTAlgorithm:      getEnv("JWT_ALGORITHM", "HS256"),
+		JWTSecret:         getEnv("JWT_SECRET", ""),
+		JWTPrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+		JWTPublicKeyFile:  getEnv("JWT_PUBLIC_KEY_FILE", ""),
+		JWTIssuer:         getEnv("JWT_ISSUER", "todo-api"),
+		AccessTokenTTL:    getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:   getDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
 	}
 }
 
@@ -38,3 +116,44 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getDuration читает длительность в формате time.ParseDuration (например, 720h).
+// Некорректное или неположительное значение заменяется значением по умолчанию.
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getInt64 читает положительное целое число.
+// Некорректное или неположительное значение заменяется значением по умолчанию.
+func getInt64(key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getBool читает логическое значение в формате strconv.ParseBool (true, 1, false, 0).
+// Некорректное значение заменяется значением по умолчанию.
+func getBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getList читает список значений через запятую, пропуская пустые элементы
+func getList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
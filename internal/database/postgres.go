@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"time"
 
+	"RestApi/internal/requestctx"
+
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+// NewPool создает пул соединений. С rowLevelSecurity каждое соединение перед выдачей
+// получает рабочее пространство запроса в параметре app.workspace_id, по которому
+// фильтруют строки политики из migrations/optional/row_level_security.sql.
+// Фоновые задачи без рабочего пространства получают пустое значение и видят все строки
+func NewPool(ctx context.Context, databaseURL string, rowLevelSecurity bool) (*pgxpool.Pool, error) {
 	// Конфигурация пула
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
@@ -22,6 +29,16 @@ func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 	config.MaxConnIdleTime = 30 * time.Minute // Время простоя
 	config.HealthCheckPeriod = time.Minute    // Проверка здоровья
 
+	if rowLevelSecurity {
+		config.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+			workspaceID, _ := requestctx.LookupWorkspace(ctx)
+			if _, err := conn.Exec(ctx, `SELECT set_config('app.workspace_id', $1, false)`, workspaceID); err != nil {
+				return false, fmt.Errorf("set workspace: %w", err)
+			}
+			return true, nil
+		}
+	}
+
 	// Создаем пул
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
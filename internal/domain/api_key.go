@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// APIKeyScope — право доступа API-ключа
+type APIKeyScope string
+
+const (
+	// ScopeRead — чтение данных (GET, HEAD)
+	ScopeRead APIKeyScope = "read"
+	// ScopeWrite — изменение данных; включает чтение
+	ScopeWrite APIKeyScope = "write"
+	// ScopeAdmin — управление API-ключами; включает чтение и изменение
+	ScopeAdmin APIKeyScope = "admin"
+)
+
+// Valid проверяет, что право известно
+func (s APIKeyScope) Valid() bool {
+	switch s {
+	case ScopeRead, ScopeWrite, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// covers сообщает, включает ли право s право required
+func (s APIKeyScope) covers(required APIKeyScope) bool {
+	switch s {
+	case ScopeAdmin:
+		return true
+	case ScopeWrite:
+		return required == ScopeWrite || required == ScopeRead
+	}
+	return s == required
+}
+
+// APIKey — API-ключ. Сам ключ не хранится и выдается только при выпуске
+type APIKey struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	CreatedBy  string        `json:"created_by,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	// WorkspaceID — рабочее пространство, в котором работает ключ; пустое — любое
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// UserID — пользователь, от имени которого действует ключ; пустой только у ключа admin
+	UserID string `json:"user_id,omitempty"`
+}
+
+// Allows проверяет, дает ли ключ право required
+func (k APIKey) Allows(required APIKeyScope) bool {
+	return scopesAllow(k.Scopes, required)
+}
+
+// Principal возвращает субъекта запроса, выполненного с ключом
+func (k APIKey) Principal() Principal {
+	return Principal{
+		Type:        PrincipalAPIKey,
+		ID:          k.ID,
+		Name:        k.Name,
+		Scopes:      k.Scopes,
+		WorkspaceID: k.WorkspaceID,
+		UserID:      k.UserID,
+	}
+}
+
+// scopesAllow проверяет, включает ли хотя бы одно из прав право required
+func scopesAllow(scopes []APIKeyScope, required APIKeyScope) bool {
+	for _, scope := range scopes {
+		if scope.covers(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// Active проверяет, что ключ не отозван и не истек к моменту now
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+	// WorkspaceID ограничивает ключ одним рабочим пространством
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// UserID — пользователь, от имени которого действует ключ: его роли в списках
+	// ограничивают запросы с ключом. Обязателен для ключей без права admin
+	UserID string `json:"user_id,omitempty"`
+}
+
+// CreatedAPIKey — выпущенный ключ вместе с его значением.
+// Значение возвращается один раз, повторно получить его нельзя.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
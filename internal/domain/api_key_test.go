@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey_Allows(t *testing.T) {
+	read := APIKey{Scopes: []APIKeyScope{ScopeRead}}
+	assert.True(t, read.Allows(ScopeRead))
+	assert.False(t, read.Allows(ScopeWrite))
+	assert.False(t, read.Allows(ScopeAdmin))
+
+	write := APIKey{Scopes: []APIKeyScope{ScopeWrite}}
+	assert.True(t, write.Allows(ScopeRead))
+	assert.True(t, write.Allows(ScopeWrite))
+	assert.False(t, write.Allows(ScopeAdmin))
+
+	admin := APIKey{Scopes: []APIKeyScope{ScopeAdmin}}
+	assert.True(t, admin.Allows(ScopeRead))
+	assert.True(t, admin.Allows(ScopeWrite))
+	assert.True(t, admin.Allows(ScopeAdmin))
+
+	assert.False(t, APIKey{}.Allows(ScopeRead))
+}
+
+func TestAPIKey_Active(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, APIKey{}.Active(now))
+	assert.True(t, APIKey{ExpiresAt: &future}.Active(now))
+	assert.False(t, APIKey{ExpiresAt: &past}.Active(now))
+	assert.False(t, APIKey{ExpiresAt: &now}.Active(now))
+	assert.False(t, APIKey{RevokedAt: &past}.Active(now))
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Attachment — метаданные файла, прикрепленного к задаче.
+// Содержимое хранится в blob-хранилище под ключом StorageKey.
+type Attachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	UploadedBy  string    `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
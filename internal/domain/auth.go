@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// PrincipalType — способ, которым субъект запроса подтвердил свою личность
+type PrincipalType string
+
+const (
+	PrincipalUser   PrincipalType = "user"
+	PrincipalAPIKey PrincipalType = "api_key"
+)
+
+// Principal — аутентифицированный субъект запроса: пользователь или API-ключ
+type Principal struct {
+	Type   PrincipalType `json:"type"`
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Scopes []APIKeyScope `json:"scopes"`
+	// WorkspaceID — рабочее пространство, к которому привязаны учетные данные; пустое — без привязки
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// UserID — пользователь, от имени которого действует API-ключ
+	UserID string `json:"user_id,omitempty"`
+}
+
+// Allows проверяет, дает ли субъект право required
+func (p Principal) Allows(required APIKeyScope) bool {
+	return scopesAllow(p.Scopes, required)
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	// WorkspaceID привязывает выданные токены к рабочему пространству
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenPair — короткоживущий токен доступа и токен для его обновления
+type TokenPair struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn — срок действия токена доступа в секундах
+	ExpiresIn        int       `json:"expires_in"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// RefreshToken — выданный токен обновления. Сам токен не хранится, только его хэш
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	// WorkspaceID — рабочее пространство сессии; пустое — без привязки
+	WorkspaceID string
+}
+
+// Active проверяет, что токен не отозван и не истек к моменту now
+func (t RefreshToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Comment — комментарий к задаче.
+// Author берется из заголовка X-Actor и может быть пустым.
+type Comment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateCommentRequest struct {
+	Text string `json:"text"`
+}
+
+type UpdateCommentRequest struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor — курсор поврежден или выдан не этим API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor — позиция в выдаче, упорядоченной по (created_at, id). Клиент получает
+// курсор строкой и передает обратно без изменений, не разбирая ее
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	// Before — выбирать страницу перед позицией; иначе — после нее
+	Before bool
+}
+
+// cursorPayload — содержимое курсора в закодированном виде
+type cursorPayload struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Before    bool      `json:"b,omitempty"`
+}
+
+// Encode кодирует курсор в строку для query-параметра cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(cursorPayload{CreatedAt: c.CreatedAt, ID: c.ID, Before: c.Before})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor разбирает курсор, полученный от клиента
+func ParseCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if payload.CreatedAt.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(payload.ID); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{CreatedAt: payload.CreatedAt, ID: payload.ID, Before: payload.Before}, nil
+}
+
+// CursorPage — курсоры соседних страниц; пустой курсор — страницы нет
+type CursorPage struct {
+	Next string
+	Prev string
+}
+
+// NewCursorPage строит курсоры соседних страниц для страницы, выбранной по cursor
+// (nil — первая страница). first и last — позиции первой и последней записи страницы,
+// nil для пустой страницы; more — за страницей в направлении выборки есть еще записи
+func NewCursorPage(cursor *Cursor, first, last *Cursor, more bool) CursorPage {
+	before := cursor != nil && cursor.Before
+
+	// Пустая страница после удаления записей: назад можно вернуться от самого курсора
+	if first == nil || last == nil {
+		if cursor == nil {
+			return CursorPage{}
+		}
+		first, last = cursor, cursor
+	}
+
+	// Страница перед курсором получена со стороны следующей страницы, и наоборот
+	hasNext, hasPrev := more, cursor != nil
+	if before {
+		hasNext, hasPrev = true, more
+	}
+
+	var page CursorPage
+	if hasNext {
+		page.Next = Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if hasPrev {
+		page.Prev = Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Before: true}.Encode()
+	}
+	return page
+}
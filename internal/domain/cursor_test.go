@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 123456000, time.UTC)
+
+	t.Run("round trip", func(t *testing.T) {
+		cursor := Cursor{CreatedAt: createdAt, ID: "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b", Before: true}
+
+		parsed, err := ParseCursor(cursor.Encode())
+		require.NoError(t, err)
+		assert.True(t, parsed.CreatedAt.Equal(createdAt))
+		assert.Equal(t, cursor.ID, parsed.ID)
+		assert.True(t, parsed.Before)
+	})
+
+	for name, value := range map[string]string{
+		"not base64": "курсор",
+		"not json":   "bm90IGpzb24",
+		"empty json": Cursor{}.Encode(),
+		"bad id":     Cursor{CreatedAt: createdAt, ID: "list-1"}.Encode(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCursor(value)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	first := &Cursor{CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), ID: "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"}
+	last := &Cursor{CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), ID: "0a9b8c7d-6e5f-4a3b-8c1d-2e3f4a5b6c7d"}
+	after := Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	before := Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Before: true}.Encode()
+
+	t.Run("first page", func(t *testing.T) {
+		assert.Equal(t, CursorPage{Next: after}, NewCursorPage(nil, first, last, true))
+		assert.Equal(t, CursorPage{}, NewCursorPage(nil, first, last, false))
+		assert.Equal(t, CursorPage{}, NewCursorPage(nil, nil, nil, false))
+	})
+
+	t.Run("page after cursor", func(t *testing.T) {
+		cursor := &Cursor{CreatedAt: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), ID: first.ID}
+		assert.Equal(t, CursorPage{Next: after, Prev: before}, NewCursorPage(cursor, first, last, true))
+		assert.Equal(t, CursorPage{Prev: before}, NewCursorPage(cursor, first, last, false))
+	})
+
+	t.Run("page before cursor", func(t *testing.T) {
+		cursor := &Cursor{CreatedAt: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), ID: last.ID, Before: true}
+		assert.Equal(t, CursorPage{Next: after, Prev: before}, NewCursorPage(cursor, first, last, true))
+		assert.Equal(t, CursorPage{Next: after}, NewCursorPage(cursor, first, last, false))
+	})
+
+	t.Run("empty page after cursor", func(t *testing.T) {
+		cursor := &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		page := NewCursorPage(cursor, nil, nil, false)
+		assert.Empty(t, page.Next)
+		assert.Equal(t, Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Before: true}.Encode(), page.Prev)
+	})
+}
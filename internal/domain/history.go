@@ -0,0 +1,139 @@
+package domain
+
+import "time"
+
+// HistoryEntityType — вид объекта, изменения которого записываются в историю
+type HistoryEntityType string
+
+const (
+	HistoryEntityList HistoryEntityType = "list"
+	HistoryEntityTask HistoryEntityType = "task"
+)
+
+// HistoryAction — вид изменения объекта
+type HistoryAction string
+
+const (
+	HistoryCreated    HistoryAction = "created"
+	HistoryUpdated    HistoryAction = "updated"
+	HistoryMoved      HistoryAction = "moved"
+	HistoryCopied     HistoryAction = "copied"
+	HistoryDeleted    HistoryAction = "deleted"
+	HistoryRestored   HistoryAction = "restored"
+	HistoryArchived   HistoryAction = "archived"
+	HistoryUnarchived HistoryAction = "unarchived"
+)
+
+// FieldChange — значение поля до и после изменения.
+// From равно null для только что созданного объекта.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Changes — изменения полей объекта по имени поля в JSON
+type Changes map[string]FieldChange
+
+// HistoryEntry — запись истории изменений списка или задачи
+type HistoryEntry struct {
+	ID         string            `json:"id"`
+	EntityType HistoryEntityType `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Action     HistoryAction     `json:"action"`
+	Changes    Changes           `json:"changes"`
+	Actor      string            `json:"actor,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// TaskChanges сравнивает поля задачи до и после изменения.
+// before == nil означает, что задача создана: в изменения попадают все заполненные поля.
+func TaskChanges(before *Task, after Task) Changes {
+	var prev Task
+	if before != nil {
+		prev = *before
+	}
+
+	changes := make(Changes)
+	changes.add(before == nil, "list_id", prev.ListID, after.ListID)
+	changes.add(before == nil, "parent_task_id", prev.ParentTaskID, after.ParentTaskID)
+	changes.add(before == nil, "text", prev.Text, after.Text)
+	changes.add(before == nil, "status", string(prev.Status), string(after.Status))
+	changes.add(before == nil, "completed", prev.Completed, after.Completed)
+	changes.add(before == nil, "priority", string(prev.Priority), string(after.Priority))
+	changes.add(before == nil, "assignee_id", prev.AssigneeID, after.AssigneeID)
+	changes.add(before == nil, "due_at", prev.DueAt, after.DueAt)
+	changes.add(before == nil, "position", prev.Position, after.Position)
+	changes.add(before == nil, "recurrence_rule", prev.RecurrenceRule, after.RecurrenceRule)
+	return changes
+}
+
+// ListChanges сравнивает поля списка до и после изменения.
+// before == nil означает, что список создан.
+func ListChanges(before *List, after List) Changes {
+	var prev List
+	if before != nil {
+		prev = *before
+	}
+
+	changes := make(Changes)
+	changes.add(before == nil, "title", prev.Title, after.Title)
+	changes.add(before == nil, "description", prev.Description, after.Description)
+	changes.add(before == nil, "archived_at", prev.ArchivedAt, after.ArchivedAt)
+	return changes
+}
+
+// add записывает изменение поля, если значение поменялось.
+// Для созданного объекта пустые значения пропускаются.
+func (c Changes) add(created bool, field string, from, to any) {
+	from, to = historyValue(from), historyValue(to)
+	if created {
+		if isZeroHistoryValue(to) {
+			return
+		}
+		from = nil
+	}
+	if equalHistoryValues(from, to) {
+		return
+	}
+	c[field] = FieldChange{From: from, To: to}
+}
+
+// historyValue разыменовывает необязательные поля; отсутствующее значение — nil
+func historyValue(value any) any {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.UTC()
+	case time.Time:
+		return v.UTC()
+	}
+	return value
+}
+
+func isZeroHistoryValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	}
+	return false
+}
+
+func equalHistoryValues(a, b any) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return a == b
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskChanges(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("created task lists filled fields", func(t *testing.T) {
+		task := Task{ID: "task-1", ListID: "list-1", Text: "Купить молоко", Priority: PriorityNone, Position: "n"}
+
+		changes := TaskChanges(nil, task)
+		assert.Equal(t, Changes{
+			"list_id":  {From: nil, To: "list-1"},
+			"text":     {From: nil, To: "Купить молоко"},
+			"priority": {From: nil, To: "none"},
+			"position": {From: nil, To: "n"},
+		}, changes)
+	})
+
+	t.Run("updated task lists only changed fields", func(t *testing.T) {
+		before := Task{ID: "task-1", ListID: "list-1", Text: "Купить молоко", Priority: PriorityNone, DueAt: &due}
+		after := before
+		after.Completed = true
+		after.Priority = PriorityHigh
+		after.DueAt = nil
+		after.UpdatedAt = time.Now()
+
+		changes := TaskChanges(&before, after)
+		assert.Equal(t, Changes{
+			"completed": {From: false, To: true},
+			"priority":  {From: "none", To: "high"},
+			"due_at":    {From: due, To: nil},
+		}, changes)
+	})
+
+	t.Run("same instant in another zone is not a change", func(t *testing.T) {
+		local := due.In(time.FixedZone("MSK", 3*60*60))
+		before := Task{ID: "task-1", DueAt: &due}
+		after := Task{ID: "task-1", DueAt: &local}
+
+		assert.Empty(t, TaskChanges(&before, after))
+	})
+}
+
+func TestListChanges(t *testing.T) {
+	archivedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := List{ID: "list-1", Title: "Покупки", Description: "На неделю"}
+	after := List{ID: "list-1", Title: "Продукты", Description: "На неделю", ArchivedAt: &archivedAt}
+
+	assert.Equal(t, Changes{
+		"title":       {From: "Покупки", To: "Продукты"},
+		"archived_at": {From: nil, To: archivedAt},
+	}, ListChanges(&before, after))
+}
@@ -3,15 +3,73 @@ package domain
 import "time"
 
 type List struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	CreatedBy   string     `json:"created_by,omitempty"` // ID пользователя, создавшего список
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 type CreateListRequest struct {
-	Title string `json:"title"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
 }
 
+// UpdateListRequest — частичное обновление списка.
+// Пустая строка в Description удаляет описание.
 type UpdateListRequest struct {
-	Title string `json:"title"`
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ListStats — статистика выполнения задач списка (без задач в корзине)
+type ListStats struct {
+	ListID    string `json:"list_id"`
+	Total     int    `json:"total"`
+	Open      int    `json:"open"`
+	Completed int    `json:"completed"`
+	// CompletionRate — доля выполненных задач от 0 до 1
+	CompletionRate float64 `json:"completion_rate"`
+	// MedianTimeToCompleteSeconds — медиана времени от создания задачи до ее выполнения.
+	// Отсутствует, если в списке нет выполненных задач.
+	MedianTimeToCompleteSeconds *float64 `json:"median_time_to_complete_seconds,omitempty"`
+}
+
+// ListFilter — какие списки включать в выдачу по признаку архивации.
+// По умолчанию архивные списки скрыты.
+type ListFilter struct {
+	IncludeArchived bool
+	ArchivedOnly    bool
+	// MemberID — только списки, в которых состоит пользователь.
+	// Заполняется сервисом по субъекту запроса.
+	MemberID string
+}
+
+// TitleSearchMode — способ поиска списков по названию
+type TitleSearchMode string
+
+const (
+	// TitleSearchSubstring — название содержит строку запроса без учета регистра
+	TitleSearchSubstring TitleSearchMode = "substring"
+	// TitleSearchFuzzy — название похоже на запрос по сходству триграмм (pg_trgm);
+	// находит названия с опечатками
+	TitleSearchFuzzy TitleSearchMode = "fuzzy"
+)
+
+// Valid сообщает, является ли значение известным способом поиска
+func (m TitleSearchMode) Valid() bool {
+	return m == TitleSearchSubstring || m == TitleSearchFuzzy
+}
+
+// DefaultSimilarityThreshold — порог сходства по умолчанию, как у pg_trgm
+const DefaultSimilarityThreshold = 0.3
+
+// TitleSearch — параметры поиска списков по названию
+type TitleSearch struct {
+	Query string
+	Mode  TitleSearchMode
+	// Threshold — минимальное сходство названия с запросом от 0 до 1 в режиме fuzzy.
+	// Сходство — доля общих триграмм среди всех триграмм названия и запроса.
+	Threshold float64
 }
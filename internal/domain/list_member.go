@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ListRole — роль участника списка
+type ListRole string
+
+const (
+	// RoleOwner — управляет списком и его участниками
+	RoleOwner ListRole = "owner"
+	// RoleEditor — меняет список и его задачи
+	RoleEditor ListRole = "editor"
+	// RoleViewer — только читает список и его задачи
+	RoleViewer ListRole = "viewer"
+)
+
+// listRoleRanks — старшинство ролей: старшая роль включает права младших
+var listRoleRanks = map[ListRole]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Valid проверяет, что роль известна
+func (r ListRole) Valid() bool {
+	_, ok := listRoleRanks[r]
+	return ok
+}
+
+// Covers сообщает, включает ли роль r права роли required
+func (r ListRole) Covers(required ListRole) bool {
+	return r.Valid() && listRoleRanks[r] >= listRoleRanks[required]
+}
+
+// ListMember — участник списка
+type ListMember struct {
+	ListID    string    `json:"list_id"`
+	UserID    string    `json:"user_id"`
+	Role      ListRole  `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SetListMemberRequest — приглашение участника или смена его роли
+type SetListMemberRequest struct {
+	Role ListRole `json:"role"`
+}
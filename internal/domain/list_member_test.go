@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRole_Covers(t *testing.T) {
+	assert.True(t, RoleOwner.Covers(RoleOwner))
+	assert.True(t, RoleOwner.Covers(RoleEditor))
+	assert.True(t, RoleOwner.Covers(RoleViewer))
+
+	assert.False(t, RoleEditor.Covers(RoleOwner))
+	assert.True(t, RoleEditor.Covers(RoleEditor))
+	assert.True(t, RoleEditor.Covers(RoleViewer))
+
+	assert.False(t, RoleViewer.Covers(RoleEditor))
+	assert.True(t, RoleViewer.Covers(RoleViewer))
+
+	assert.False(t, ListRole("admin").Covers(RoleViewer))
+	assert.False(t, ListRole("").Valid())
+}
@@ -0,0 +1,43 @@
+package domain
+
+// SearchItemType — вид найденного объекта
+type SearchItemType string
+
+const (
+	SearchItemList SearchItemType = "list"
+	SearchItemTask SearchItemType = "task"
+)
+
+// Valid сообщает, является ли значение известным видом объекта
+func (t SearchItemType) Valid() bool {
+	return t == SearchItemList || t == SearchItemTask
+}
+
+// SearchFilter — параметры полнотекстового поиска
+type SearchFilter struct {
+	// Query — поисковый запрос в формате websearch_to_tsquery:
+	// слова, "точная фраза", or, -исключение
+	Query string
+	// Type — только списки или только задачи; пустое значение — все
+	Type SearchItemType
+	// ListID — только указанный список и его задачи
+	ListID string
+	// Completed — только выполненные или невыполненные задачи; списки при этом не ищутся
+	Completed *bool
+	// MemberID — только списки, в которых состоит пользователь, и их задачи.
+	// Заполняется сервисом по субъекту запроса.
+	MemberID string
+}
+
+// SearchResult — найденный список или задача.
+// Title содержит название списка или текст задачи, Snippet — фрагмент
+// с совпадениями, выделенными тегом <mark>; остальной текст экранирован для HTML.
+type SearchResult struct {
+	Type      SearchItemType `json:"type"`
+	ID        string         `json:"id"`
+	ListID    *string        `json:"list_id,omitempty"`
+	Title     string         `json:"title"`
+	Snippet   string         `json:"snippet"`
+	Rank      float64        `json:"rank"`
+	Completed *bool          `json:"completed,omitempty"`
+}
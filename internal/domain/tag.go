@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateTagRequest struct {
+	Name string `json:"name"`
+}
+
+// TagMatchMode — как сопоставлять задачи с несколькими метками
+type TagMatchMode string
+
+const (
+	// TagMatchAny — задача помечена хотя бы одной из меток
+	TagMatchAny TagMatchMode = "any"
+	// TagMatchAll — задача помечена всеми метками
+	TagMatchAll TagMatchMode = "all"
+)
+
+// Valid сообщает, является ли значение известным режимом
+func (m TagMatchMode) Valid() bool {
+	return m == TagMatchAny || m == TagMatchAll
+}
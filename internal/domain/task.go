@@ -1,21 +1,290 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
 
 type Task struct {
-	ID        string    `json:"id"`
-	ListID    string    `json:"list_id"`
-	Text      string    `json:"text"`
-	Completed bool      `json:"completed"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string       `json:"id"`
+	ListID         string       `json:"list_id"`
+	ParentTaskID   *string      `json:"parent_task_id,omitempty"`
+	Text           string       `json:"text"`
+	Status         TaskStatus   `json:"status"`
+	Completed      bool         `json:"completed"` // производно от Status, оставлено для совместимости
+	CompletedAt    *time.Time   `json:"completed_at,omitempty"`
+	Priority       TaskPriority `json:"priority"`
+	DueAt          *time.Time   `json:"due_at,omitempty"`
+	Position       string       `json:"position"`
+	RecurrenceRule *string      `json:"recurrence_rule,omitempty"`
+	AssigneeID     *string      `json:"assignee_id,omitempty"`
+	CreatedBy      string       `json:"created_by,omitempty"` // ID пользователя, создавшего задачу
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// SetStatus меняет статус задачи вместе с производным признаком Completed
+func (t *Task) SetStatus(status TaskStatus) {
+	t.Status = status
+	t.Completed = status == StatusDone
+}
+
+// TaskNode — задача вместе с вложенными подзадачами
+type TaskNode struct {
+	Task
+	Subtasks []TaskNode `json:"subtasks"`
 }
 
 type CreateTaskRequest struct {
-	Text string `json:"text"`
+	Text           string       `json:"text"`
+	Status         TaskStatus   `json:"status,omitempty"`
+	ParentTaskID   *string      `json:"parent_task_id,omitempty"`
+	Priority       TaskPriority `json:"priority,omitempty"`
+	DueAt          *time.Time   `json:"due_at,omitempty"`
+	RecurrenceRule *string      `json:"recurrence_rule,omitempty"`
+	AssigneeID     *string      `json:"assignee_id,omitempty"`
 }
 
 type UpdateTaskRequest struct {
-	Text      *string `json:"text,omitempty"`
-	Completed *bool   `json:"completed,omitempty"`
+	Text            *string       `json:"text,omitempty"`
+	Status          *TaskStatus   `json:"status,omitempty"`
+	Completed       *bool         `json:"completed,omitempty"`
+	Priority        *TaskPriority `json:"priority,omitempty"`
+	DueAt           *time.Time    `json:"due_at,omitempty"`
+	ClearDueAt      bool          `json:"clear_due_at,omitempty"`
+	ParentTaskID    *string       `json:"parent_task_id,omitempty"`
+	ClearParent     bool          `json:"clear_parent,omitempty"`
+	RecurrenceRule  *string       `json:"recurrence_rule,omitempty"`
+	ClearRecurrence bool          `json:"clear_recurrence,omitempty"`
+	AssigneeID      *string       `json:"assignee_id,omitempty"`
+	ClearAssignee   bool          `json:"clear_assignee,omitempty"`
+	// Cascade при завершении задачи завершает и все ее подзадачи
+	Cascade bool `json:"cascade,omitempty"`
+	// Force завершает задачу, даже если блокирующие ее задачи еще не выполнены
+	Force bool `json:"force,omitempty"`
+}
+
+// MoveTaskRequest — новое место задачи.
+// Задача ставится сразу после AfterTaskID и/или сразу перед BeforeTaskID.
+// Если указан ListID другого списка, задача переносится туда вместе с подзадачами.
+type MoveTaskRequest struct {
+	ListID         *string `json:"list_id,omitempty"`
+	AfterTaskID    *string `json:"after_task_id,omitempty"`
+	BeforeTaskID   *string `json:"before_task_id,omitempty"`
+	ResetCompleted bool    `json:"reset_completed,omitempty"`
+}
+
+// CopyTaskRequest — копирование задачи. Без ListID копия создается в том же списке.
+type CopyTaskRequest struct {
+	ListID         *string `json:"list_id,omitempty"`
+	ResetCompleted bool    `json:"reset_completed,omitempty"`
+}
+
+// TransferTasksRequest — пакетный перенос или копирование задач в список
+type TransferTasksRequest struct {
+	TaskIDs        []string `json:"task_ids"`
+	ListID         string   `json:"list_id"`
+	ResetCompleted bool     `json:"reset_completed,omitempty"`
+}
+
+// TaskMove — новое расположение задачи при переносе между списками
+type TaskMove struct {
+	ID             string
+	ListID         string
+	ParentTaskID   *string
+	Position       string
+	ResetCompleted bool
+	// ResetStatus — статус, который получает выполненная задача при ResetCompleted
+	ResetStatus TaskStatus
+}
+
+// TaskCopy — копия задачи SourceID; метки копируются вместе с задачей
+type TaskCopy struct {
+	SourceID string
+	Task     Task
+}
+
+// TaskPriority — уровень приоритета задачи
+type TaskPriority string
+
+const (
+	PriorityNone   TaskPriority = "none"
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+// Valid сообщает, является ли значение известным приоритетом
+func (p TaskPriority) Valid() bool {
+	switch p {
+	case PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// TaskStatus — этап задачи в рабочем процессе
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	// StatusDone — единственный статус, при котором задача считается выполненной
+	StatusDone TaskStatus = "done"
+)
+
+var taskStatusPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+// TaskWorkflow — набор статусов задач и допустимых переходов между ними
+type TaskWorkflow struct {
+	// Statuses — статусы в порядке отображения; первый присваивается новым задачам
+	Statuses []TaskStatus `json:"statuses"`
+	// Transitions — в какие статусы можно перейти из каждого статуса
+	Transitions map[TaskStatus][]TaskStatus `json:"transitions"`
+}
+
+// DefaultTaskWorkflow — рабочий процесс по умолчанию: todo → in_progress → done,
+// blocked для приостановленных задач; выполненную задачу можно вернуть в работу
+func DefaultTaskWorkflow() TaskWorkflow {
+	return TaskWorkflow{
+		Statuses: []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusDone},
+		Transitions: map[TaskStatus][]TaskStatus{
+			StatusTodo:       {StatusInProgress, StatusBlocked, StatusDone},
+			StatusInProgress: {StatusTodo, StatusBlocked, StatusDone},
+			StatusBlocked:    {StatusTodo, StatusInProgress},
+			StatusDone:       {StatusTodo, StatusInProgress},
+		},
+	}
+}
+
+// ParseTaskWorkflow разбирает рабочий процесс вида
+// "todo>in_progress,done;in_progress>todo,done;done>todo".
+// Статусы перечисляются через ";", после ">" — статусы, в которые из него можно перейти.
+// Первый статус присваивается новым задачам; статус done обязателен.
+func ParseTaskWorkflow(spec string) (TaskWorkflow, error) {
+	workflow := TaskWorkflow{Transitions: make(map[TaskStatus][]TaskStatus)}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, targets, _ := strings.Cut(entry, ">")
+		status := TaskStatus(strings.TrimSpace(from))
+		if !taskStatusPattern.MatchString(string(status)) {
+			return TaskWorkflow{}, fmt.Errorf("invalid status %q", status)
+		}
+		if _, ok := workflow.Transitions[status]; ok {
+			return TaskWorkflow{}, fmt.Errorf("status %q is listed twice", status)
+		}
+
+		workflow.Statuses = append(workflow.Statuses, status)
+		workflow.Transitions[status] = []TaskStatus{}
+		for _, target := range strings.Split(targets, ",") {
+			if target = strings.TrimSpace(target); target != "" {
+				workflow.Transitions[status] = append(workflow.Transitions[status], TaskStatus(target))
+			}
+		}
+	}
+
+	if !workflow.Has(StatusDone) {
+		return TaskWorkflow{}, fmt.Errorf("workflow must contain status %q", StatusDone)
+	}
+	for from, targets := range workflow.Transitions {
+		for _, to := range targets {
+			if !workflow.Has(to) {
+				return TaskWorkflow{}, fmt.Errorf("transition %s>%s: unknown status %q", from, to, to)
+			}
+		}
+	}
+
+	return workflow, nil
+}
+
+// Initial возвращает статус новых задач
+func (w TaskWorkflow) Initial() TaskStatus {
+	return w.Statuses[0]
+}
+
+// Has сообщает, есть ли статус в рабочем процессе
+func (w TaskWorkflow) Has(status TaskStatus) bool {
+	_, ok := w.Transitions[status]
+	return ok
+}
+
+// CanTransition сообщает, допустим ли переход между статусами.
+// Сохранение текущего статуса допустимо всегда; из статуса, которого нет
+// в рабочем процессе (например, после его изменения), можно перейти в любой.
+func (w TaskWorkflow) CanTransition(from, to TaskStatus) bool {
+	if from == to || !w.Has(from) {
+		return true
+	}
+	for _, target := range w.Transitions[from] {
+		if target == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskGroup — задачи одного статуса при группировке
+type TaskGroup struct {
+	Status TaskStatus `json:"status"`
+	Tasks  []Task     `json:"tasks"`
+	// Total — общее количество задач статуса без учета пагинации
+	Total int `json:"total"`
+}
+
+// TaskSortField — поле сортировки задач
+type TaskSortField string
+
+const (
+	TaskSortCreatedAt TaskSortField = "created_at"
+	TaskSortUpdatedAt TaskSortField = "updated_at"
+	TaskSortPriority  TaskSortField = "priority"
+	TaskSortDue       TaskSortField = "due"
+	TaskSortPosition  TaskSortField = "position"
+	TaskSortText      TaskSortField = "text"
+)
+
+// Valid сообщает, поддерживается ли сортировка по полю
+func (f TaskSortField) Valid() bool {
+	switch f {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortPriority, TaskSortDue, TaskSortPosition, TaskSortText:
+		return true
+	}
+	return false
+}
+
+// TaskSort — ключ сортировки задач
+type TaskSort struct {
+	Field TaskSortField
+	Desc  bool
+}
+
+// TaskFilter — параметры фильтрации и сортировки задач списка
+type TaskFilter struct {
+	DueBefore     *time.Time
+	DueAfter      *time.Time
+	CreatedBefore *time.Time
+	CreatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	Overdue       bool
+	Completed     *bool
+	// TextContains — подстрока текста задачи, без учета регистра
+	TextContains string
+	Statuses     []TaskStatus
+	Blocked      *bool
+	TagIDs       []string
+	TagMode      TagMatchMode
+	// Sort — ключи сортировки по убыванию значимости; пустой — от новых к старым
+	Sort []TaskSort
+	// MemberID — только задачи списков, в которых состоит пользователь.
+	// Заполняется сервисом по субъекту запроса.
+	MemberID string
 }
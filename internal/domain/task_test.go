@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskWorkflow(t *testing.T) {
+	t.Run("custom workflow", func(t *testing.T) {
+		workflow, err := ParseTaskWorkflow("backlog>todo; todo>backlog,review ;review>done,todo;done>")
+		require.NoError(t, err)
+
+		assert.Equal(t, []TaskStatus{"backlog", StatusTodo, "review", StatusDone}, workflow.Statuses)
+		assert.Equal(t, TaskStatus("backlog"), workflow.Initial())
+		assert.True(t, workflow.CanTransition("review", StatusDone))
+		assert.False(t, workflow.CanTransition("backlog", StatusDone))
+		assert.False(t, workflow.CanTransition(StatusDone, StatusTodo))
+		assert.True(t, workflow.CanTransition(StatusDone, StatusDone))
+	})
+
+	t.Run("invalid workflows", func(t *testing.T) {
+		for _, spec := range []string{
+			"",
+			"todo>in_progress;in_progress>todo",
+			"todo>done;done>todo;todo>done",
+			"todo>review;done>todo",
+			"To Do>done;done>",
+		} {
+			_, err := ParseTaskWorkflow(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
+
+func TestTaskWorkflow_CanTransition(t *testing.T) {
+	workflow := DefaultTaskWorkflow()
+
+	assert.True(t, workflow.CanTransition(StatusTodo, StatusInProgress))
+	assert.True(t, workflow.CanTransition(StatusDone, StatusTodo))
+	assert.False(t, workflow.CanTransition(StatusBlocked, StatusDone))
+	// Из статуса, убранного из рабочего процесса, задачу можно перевести куда угодно
+	assert.True(t, workflow.CanTransition("review", StatusBlocked))
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// TrashItemType — вид объекта в корзине
+type TrashItemType string
+
+const (
+	TrashItemList TrashItemType = "list"
+	TrashItemTask TrashItemType = "task"
+)
+
+// Valid сообщает, является ли значение известным видом объекта
+func (t TrashItemType) Valid() bool {
+	return t == TrashItemList || t == TrashItemTask
+}
+
+// TrashFilter — параметры выборки содержимого корзины
+type TrashFilter struct {
+	// Type — вид объектов; пустой — списки и задачи вместе
+	Type TrashItemType
+	// MemberID — только объекты списков, в которых состоит пользователь.
+	// Заполняется сервисом по субъекту запроса.
+	MemberID string
+}
+
+// TrashItem — удаленный список или задача.
+// Title содержит название списка или текст задачи.
+type TrashItem struct {
+	Type      TrashItemType `json:"type"`
+	ID        string        `json:"id"`
+	Title     string        `json:"title"`
+	ListID    *string       `json:"list_id,omitempty"`
+	DeletedAt time.Time     `json:"deleted_at"`
+}
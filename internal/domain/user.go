@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// User — пользователь, которому можно назначать задачи
+type User struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// PasswordHash — хэш пароля (bcrypt); пустой, если пароль не задан
+	PasswordHash string `json:"-"`
+}
+
+type CreateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Password — пароль для входа; без него пользователь не может получить токен
+	Password string `json:"password,omitempty"`
+}
+
+// UpdateUserRequest — частичное обновление пользователя
+type UpdateUserRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Password *string `json:"password,omitempty"`
+	// CurrentPassword — текущий пароль; нужен, когда пользователь меняет свой пароль
+	CurrentPassword string `json:"current_password,omitempty"`
+}
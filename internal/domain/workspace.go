@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// DefaultWorkspaceID — рабочее пространство по умолчанию. В нем выполняются запросы,
+// для которых рабочее пространство не указано
+const DefaultWorkspaceID = "00000000-0000-0000-0000-000000000000"
+
+// Workspace — рабочее пространство. Списки и задачи видны только внутри своего рабочего пространства
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
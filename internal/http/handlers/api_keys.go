@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandler struct {
+	service *service.APIKeyService
+}
+
+func NewAPIKeyHandler(service *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// Create выпускает API-ключ
+// @Summary Выпустить API-ключ
+// @Description Выпускает ключ с правами read, write и/или admin. Значение ключа возвращается только в этом ответе, сервер хранит лишь его хэш. Ключ или сессия, привязанные к рабочему пространству, выпускают ключи только в нем. Ключ действует от имени пользователя user_id, состоящего в рабочем пространстве ключа; без user_id выпускается только ключ admin
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateAPIKeyRequest true "Данные для выпуска ключа"
+// @Success 201 {object} domain.CreatedAPIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/api-keys [post]
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request domain.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	key, err := h.service.Create(r.Context(), request)
+	if err != nil {
+		writeAPIKeyError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, key)
+}
+
+// List получает API-ключи с пагинацией
+// @Summary Получить API-ключи
+// @Description Возвращает ключи рабочего пространства запроса (ключи без привязки — в рабочем пространстве по умолчанию), в том числе отозванные, новые первыми. Значения ключей не возвращаются
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.APIKey
+// @Header 200 {integer} X-Total-Count "Общее количество ключей"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/api-keys [get]
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	keys, total, err := h.service.List(r.Context(), limit, offset)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get API keys",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, keys)
+}
+
+// Revoke отзывает API-ключ
+// @Summary Отозвать API-ключ
+// @Description Отзывает ключ: запросы с ним получают 401. Ключ остается в списке с заполненным revoked_at
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "ID ключа"
+// @Success 204 "Отозван"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.service.Revoke(r.Context(), id); err != nil {
+		writeAPIKeyError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAPIKeyError переводит ошибки сервиса API-ключей в HTTP-ответ
+func writeAPIKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid API key data",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not allowed to issue keys for this workspace",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "API key not found",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
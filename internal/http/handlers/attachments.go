@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead — запас на заголовки и границы multipart сверх размера файла
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	service *service.AttachmentService
+}
+
+func NewAttachmentHandler(service *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+	}
+}
+
+// Upload загружает файл и прикрепляет его к задаче
+// @Summary Загрузить вложение
+// @Description Принимает файл в поле file формы multipart/form-data. Тип содержимого определяется по заголовку части или по первым байтам файла. Загрузивший берется из заголовка X-Actor
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param X-Actor header string false "Кто загрузил файл"
+// @Param file formData file true "Файл"
+// @Success 201 {object} domain.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/attachments [post]
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.service.MaxSize()+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Request must be multipart/form-data",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	// Пропускаем поля формы до первого поля file
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Form field file is required",
+			})
+			return
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				writeAttachmentError(w, err)
+				return
+			}
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid multipart body",
+				Details: err.Error(),
+			})
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.service.Upload(r.Context(), taskID, part.FileName(), part.Header.Get("Content-Type"), part)
+		part.Close()
+		if err != nil {
+			writeAttachmentError(w, err)
+			return
+		}
+
+		WriteJSON(w, http.StatusCreated, attachment)
+		return
+	}
+}
+
+// List получает вложения задачи с пагинацией
+// @Summary Получить вложения задачи
+// @Description Возвращает метаданные вложений задачи в порядке загрузки с пагинацией
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.Attachment
+// @Header 200 {integer} X-Total-Count "Общее количество вложений"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/attachments [get]
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+	limit, offset := parsePagination(r)
+
+	attachments, total, err := h.service.ListByTask(r.Context(), taskID, limit, offset)
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, attachments)
+}
+
+// Get получает метаданные вложения
+// @Summary Получить вложение
+// @Description Возвращает метаданные вложения задачи
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param attachmentID path string true "ID вложения"
+// @Success 200 {object} domain.Attachment
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/attachments/{attachmentID} [get]
+func (h *AttachmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	attachment, err := h.service.Get(r.Context(), params["taskID"], params["attachmentID"])
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, attachment)
+}
+
+// Download отдает содержимое вложения потоком
+// @Summary Скачать вложение
+// @Description Отдает содержимое вложения с исходным типом и именем файла в Content-Disposition
+// @Tags attachments
+// @Produce octet-stream
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param attachmentID path string true "ID вложения"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/attachments/{attachmentID}/content [get]
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	attachment, content, err := h.service.Download(r.Context(), params["taskID"], params["attachmentID"])
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// Заголовки уже отправлены: ошибку передачи можно только залогировать
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("Attachment %s download interrupted: %v", attachment.ID, err)
+	}
+}
+
+// Delete удаляет вложение
+// @Summary Удалить вложение
+// @Description Удаляет вложение задачи вместе с его содержимым
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param attachmentID path string true "ID вложения"
+// @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/attachments/{attachmentID} [delete]
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.Delete(r.Context(), params["taskID"], params["attachmentID"]); err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAttachmentError переводит ошибки сервиса вложений в HTTP-ответ
+func writeAttachmentError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, service.ErrPayloadTooLarge), errors.As(err, &maxBytesErr):
+		WriteJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{
+			Code:    "PAYLOAD_TOO_LARGE",
+			Message: "File is too large",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrUnsupportedMediaType):
+		WriteJSON(w, http.StatusUnsupportedMediaType, ErrorResponse{
+			Code:    "UNSUPPORTED_MEDIA_TYPE",
+			Message: "File type is not allowed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid attachment",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not enough permissions in the list",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Task or attachment not found",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
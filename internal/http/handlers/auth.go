@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/service"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{
+		service: service,
+	}
+}
+
+// Token выдает токены по email и паролю
+// @Summary Получить токен доступа
+// @Description Проверяет email и пароль пользователя и выдает короткоживущий JWT (заголовок Authorization: Bearer <токен>) и токен обновления. Токены работают в рабочем пространстве workspace_id (без него — по умолчанию), в котором пользователь должен состоять
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.LoginRequest true "Email и пароль"
+// @Success 200 {object} domain.TokenPair
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/token [post]
+func (h *AuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	var request domain.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	pair, err := h.service.Login(r.Context(), request)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusOK, pair)
+}
+
+// Refresh обменивает токен обновления на новую пару токенов
+// @Summary Обновить токен доступа
+// @Description Выдает новую пару токенов; предъявленный токен обновления отзывается. Повторное использование отозванного токена отзывает все токены пользователя
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.RefreshTokenRequest true "Токен обновления"
+// @Success 200 {object} domain.TokenPair
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var request domain.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	pair, err := h.service.Refresh(r.Context(), request.RefreshToken)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusOK, pair)
+}
+
+// Logout отзывает токен обновления
+// @Summary Выйти
+// @Description Отзывает токен обновления. Выданный токен доступа действует до истечения срока
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.RefreshTokenRequest true "Токен обновления"
+// @Success 204 "Токен отозван"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var request domain.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), request.RefreshToken); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Me возвращает субъекта текущего запроса
+// @Summary Текущий субъект
+// @Description Возвращает пользователя или API-ключ, которым аутентифицирован запрос
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} domain.Principal
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/auth/me [get]
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	principal, ok := requestctx.Principal(r.Context())
+	if !ok {
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Request is not authenticated",
+			Details: "authentication is disabled or credentials are missing",
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, principal)
+}
+
+// writeAuthError переводит ошибки сервиса аутентификации в HTTP-ответ
+func writeAuthError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid authentication data",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Code:    "UNAUTHORIZED",
+			Message: "Authentication failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not allowed to sign in to this workspace",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+type CommentHandler struct {
+	service *service.CommentService
+}
+
+func NewCommentHandler(service *service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		service: service,
+	}
+}
+
+// Create добавляет комментарий к задаче
+// @Summary Добавить комментарий
+// @Description Добавляет комментарий к задаче. Автор берется из заголовка X-Actor
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param X-Actor header string false "Автор комментария"
+// @Param input body domain.CreateCommentRequest true "Текст комментария"
+// @Success 201 {object} domain.Comment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/comments [post]
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	var request domain.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	comment, err := h.service.Create(r.Context(), taskID, request)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, comment)
+}
+
+// List получает комментарии задачи с пагинацией
+// @Summary Получить комментарии задачи
+// @Description Возвращает комментарии задачи в порядке добавления с пагинацией
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.Comment
+// @Header 200 {integer} X-Total-Count "Общее количество комментариев"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/comments [get]
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+	limit, offset := parsePagination(r)
+
+	comments, total, err := h.service.ListByTask(r.Context(), taskID, limit, offset)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, comments)
+}
+
+// Update меняет текст комментария
+// @Summary Обновить комментарий
+// @Description Меняет текст комментария задачи
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param commentID path string true "ID комментария"
+// @Param input body domain.UpdateCommentRequest true "Новый текст комментария"
+// @Success 200 {object} domain.Comment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/comments/{commentID} [patch]
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	var request domain.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	comment, err := h.service.Update(r.Context(), params["taskID"], params["commentID"], request)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, comment)
+}
+
+// Delete удаляет комментарий
+// @Summary Удалить комментарий
+// @Description Удаляет комментарий задачи
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param commentID path string true "ID комментария"
+// @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/comments/{commentID} [delete]
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.Delete(r.Context(), params["taskID"], params["commentID"]); err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCommentError переводит ошибки сервиса комментариев в HTTP-ответ
+func writeCommentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "text must be 1..2000 chars",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not enough permissions in the list",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Task or comment not found",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
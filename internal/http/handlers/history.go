@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+)
+
+type HistoryHandler struct {
+	service *service.HistoryService
+}
+
+func NewHistoryHandler(service *service.HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		service: service,
+	}
+}
+
+// TaskHistory получает историю изменений задачи
+// @Summary Получить историю задачи
+// @Description Возвращает изменения задачи, начиная с последних: действие, изменившиеся поля
+// @Description (значения до и после), автора из заголовка X-Actor и идентификатор запроса.
+// @Description История сохраняется и после удаления задачи
+// @Tags history
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.HistoryEntry
+// @Header 200 {integer} X-Total-Count "Общее количество записей истории"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/history [get]
+func (h *HistoryHandler) TaskHistory(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+	limit, offset := parsePagination(r)
+
+	entries, total, err := h.service.TaskHistory(r.Context(), taskID, limit, offset)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get task history",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	writeHistory(w, entries, total)
+}
+
+// ListHistory получает историю изменений списка
+// @Summary Получить историю списка
+// @Description Возвращает изменения списка, начиная с последних: действие, изменившиеся поля
+// @Description (значения до и после), автора из заголовка X-Actor и идентификатор запроса.
+// @Description История сохраняется и после удаления списка
+// @Tags history
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.HistoryEntry
+// @Header 200 {integer} X-Total-Count "Общее количество записей истории"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/history [get]
+func (h *HistoryHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	listID := mux.Vars(r)["id"]
+	limit, offset := parsePagination(r)
+
+	entries, total, err := h.service.ListHistory(r.Context(), listID, limit, offset)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get list history",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	writeHistory(w, entries, total)
+}
+
+func writeHistory(w http.ResponseWriter, entries []domain.HistoryEntry, total int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, entries)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,13 +26,16 @@ func NewListHandler(service *service.ListService) *ListHandler {
 
 // Create создает новый список
 // @Summary Создать список
-// @Description Создает новый список задач
+// @Description Создает новый список задач с необязательным описанием (до 1000 символов)
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param input body domain.CreateListRequest true "Данные для создания списка"
 // @Success 201 {object} domain.List
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists [post]
 func (h *ListHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -45,12 +49,20 @@ func (h *ListHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	list, err := h.service.Create(request.Title)
+	list, err := h.service.Create(r.Context(), request)
 	if err != nil {
-		if err == service.ErrValidation {
+		if errors.Is(err, service.ErrValidation) {
 			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
 				Code:    "VALIDATION_FAILED",
-				Message: "title must be 1..100 chars",
+				Message: "Invalid list data",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
 				Details: err.Error(),
 			})
 			return
@@ -75,8 +87,11 @@ func (h *ListHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path string true "ID списка"
 // @Success 200 {object} domain.List
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/{id} [get]
@@ -85,9 +100,17 @@ func (h *ListHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	list, err := h.service.GetByID(id)
+	list, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		if err == postgres.ErrNotFound {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
 			WriteJSON(w, http.StatusNotFound, ErrorResponse{
 				Code:    "NOT_FOUND",
 				Message: "List not found",
@@ -109,25 +132,69 @@ func (h *ListHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 // SearchByTitle ищет списки по названию
 // @Summary Поиск списков по названию
-// @Description Возвращает списки, содержащие в названии заданную строку
+// @Description Возвращает списки, содержащие в названии заданную строку, начиная с новых.
+// @Description В режиме fuzzy возвращает списки с похожим названием (в том числе с опечатками),
+// @Description начиная с самых похожих; threshold — минимальное сходство от 0 до 1.
+// @Description Архивные списки по умолчанию не возвращаются
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param q query string true "Поисковый запрос"
+// @Param mode query string false "Способ поиска" Enums(substring, fuzzy) default(substring)
+// @Param threshold query number false "Минимальное сходство для режима fuzzy" default(0.3)
+// @Param include_archived query bool false "Включить архивные списки"
+// @Param archived_only query bool false "Только архивные списки"
 // @Success 200 {array} domain.List
 // @Failure 400 {string} string "Неверный запрос"
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/search [get]
 func (h *ListHandler) SearchByTitle(w http.ResponseWriter, r *http.Request) {
 
-	query := r.URL.Query().Get("q")
-	if query == "" {
+	search, err := parseTitleSearch(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+	if search.Query == "" {
 		http.Error(w, "Query parameter 'q' is required", http.StatusBadRequest)
 		return
 	}
 
-	lists, err := h.service.SearchByTitle(query)
+	filter, err := parseListFilter(r)
 	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	lists, err := h.service.SearchByTitle(r.Context(), search, filter)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid query parameters",
+				Details: err.Error(),
+			})
+			return
+		}
 		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Internal server error",
@@ -141,14 +208,18 @@ func (h *ListHandler) SearchByTitle(w http.ResponseWriter, r *http.Request) {
 
 // Update обновляет список
 // @Summary Обновить список
-// @Description Обновляет название списка
+// @Description Обновляет название и/или описание списка. Не переданные поля не меняются,
+// @Description пустое описание удаляет его
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path string true "ID списка"
-// @Param input body domain.UpdateListRequest true "Новое название списка"
+// @Param input body domain.UpdateListRequest true "Данные для обновления списка"
 // @Success 200 {object} domain.List
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/{id} [patch]
@@ -167,17 +238,34 @@ func (h *ListHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedList, err := h.service.Update(id, request.Title)
+	if request.Title == nil && request.Description == nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "At least one field (title or description) must be provided",
+			Details: "No fields to update",
+		})
+		return
+	}
+
+	updatedList, err := h.service.Update(r.Context(), id, request)
 	if err != nil {
-		if err == service.ErrValidation {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
 			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
 				Code:    "VALIDATION_FAILED",
-				Message: "title must be 1..100 chars",
+				Message: "Invalid list data",
 				Details: err.Error(),
 			})
 			return
 		}
-		if err == postgres.ErrNotFound {
+		if errors.Is(err, postgres.ErrNotFound) {
 			WriteJSON(w, http.StatusNotFound, ErrorResponse{
 				Code:    "NOT_FOUND",
 				Message: "List not found",
@@ -195,14 +283,18 @@ func (h *ListHandler) Update(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, updatedList)
 }
 
-// Delete удаляет список
+// Delete перемещает список в корзину
 // @Summary Удалить список
-// @Description Удаляет список по его идентификатору
+// @Description Перемещает список вместе с задачами в корзину. Восстановить его можно через
+// @Description POST /api/v1/lists/{id}/restore до окончательной очистки корзины
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param id path string true "ID списка"
 // @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/{id} [delete]
@@ -211,9 +303,17 @@ func (h *ListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
-	err := h.service.Delete(id)
+	err := h.service.Delete(r.Context(), id)
 	if err != nil {
-		if err == postgres.ErrNotFound {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
 			WriteJSON(w, http.StatusNotFound, ErrorResponse{
 				Code:    "NOT_FOUND",
 				Message: "List not found",
@@ -237,44 +337,281 @@ func (h *ListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Archive переносит список в архив
+// @Summary Архивировать список
+// @Description Скрывает список из выдачи по умолчанию; в архивный список нельзя добавлять задачи. Повторная архивация ничего не меняет
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Success 200 {object} domain.List
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/archive [post]
+func (h *ListHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	list, err := h.service.Archive(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, list)
+}
+
+// Unarchive возвращает список из архива
+// @Summary Вернуть список из архива
+// @Description Возвращает список из архива
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Success 200 {object} domain.List
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/unarchive [post]
+func (h *ListHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	list, err := h.service.Unarchive(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, list)
+}
+
+// Stats получает статистику выполнения задач списка
+// @Summary Статистика списка
+// @Description Возвращает количество задач списка (всего, открытых, выполненных), долю выполненных и медиану времени до выполнения в секундах. Задачи в корзине не учитываются
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Success 200 {object} domain.ListStats
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/stats [get]
+func (h *ListHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	stats, err := h.service.Stats(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, stats)
+}
+
+// Restore возвращает список из корзины
+// @Summary Восстановить список
+// @Description Возвращает список из корзины вместе с задачами, удаленными вместе с ним
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Success 200 {object} domain.List
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/restore [post]
+func (h *ListHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	list, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found in trash",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, list)
+}
+
 // List получает списки с пагинацией
 // @Summary Получить списки
-// @Description Возвращает список списков с пагинацией
+// @Description Возвращает список списков с пагинацией, от новых к старым. Архивные списки по умолчанию не возвращаются.
+// @Description С параметром cursor (пустое значение — первая страница) выдача идет по курсору без подсчета
+// @Description общего количества: курсоры соседних страниц передаются в X-Next-Cursor, X-Prev-Cursor и Link
 // @Tags lists
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param limit query int false "Лимит" default(20)
 // @Param offset query int false "Смещение" default(0)
+// @Param cursor query string false "Курсор страницы из X-Next-Cursor или X-Prev-Cursor"
+// @Param include_archived query bool false "Включить архивные списки"
+// @Param archived_only query bool false "Только архивные списки"
 // @Success 200 {array} domain.List
-// @Header 200 {integer} X-Total-Count "Общее количество списков"
+// @Header 200 {integer} X-Total-Count "Общее количество списков (без cursor)"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы"
+// @Header 200 {string} X-Prev-Cursor "Курсор предыдущей страницы"
+// @Header 200 {string} Link "Ссылки на соседние страницы (RFC 8288)"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists [get]
 func (h *ListHandler) List(w http.ResponseWriter, r *http.Request) {
 
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	limit, offset := parsePagination(r)
 
-	limit := 20
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l >= 0 {
-			limit = l
-		}
+	filter, err := parseListFilter(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
 	}
 
-	if limit > 100 {
-		limit = 100
+	cursor, byCursor, err := parseCursor(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
 	}
-	offset := 0
 
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
+	if byCursor {
+		lists, page, err := h.service.ListByCursor(r.Context(), filter, cursor, limit)
+		if err != nil {
+			if errors.Is(err, service.ErrForbidden) {
+				WriteJSON(w, http.StatusForbidden, ErrorResponse{
+					Code:    "FORBIDDEN",
+					Message: "Request must be made on behalf of a user",
+					Details: err.Error(),
+				})
+				return
+			}
+			if errors.Is(err, service.ErrValidation) {
+				WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+					Code:    "VALIDATION_FAILED",
+					Message: "Invalid query parameters",
+					Details: err.Error(),
+				})
+				return
+			}
+			WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: "Failed to paginate lists",
+				Details: err.Error(),
+			})
+			return
 		}
+		setCursorHeaders(w, r, page)
+
+		WriteJSON(w, http.StatusOK, lists)
+		return
 	}
 
-	paginatedLists, total, err := h.service.List(limit, offset)
+	paginatedLists, total, err := h.service.List(r.Context(), filter, limit, offset)
 
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
 		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Failed to paginate lists",
@@ -287,6 +624,157 @@ func (h *ListHandler) List(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, paginatedLists)
 }
 
+// ListMembers получает участников списка
+// @Summary Получить участников списка
+// @Description Возвращает пользователей, имеющих доступ к списку, и их роли
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Success 200 {array} domain.ListMember
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/members [get]
+func (h *ListHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	members, err := h.service.ListMembers(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, members)
+}
+
+// SetMember приглашает пользователя в список или меняет его роль
+// @Summary Пригласить участника списка
+// @Description Добавляет пользователя в участники списка с ролью owner, editor или viewer либо меняет роль участника.
+// @Description Доступно только владельцу списка. У списка должен остаться хотя бы один владелец (409)
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Param userID path string true "ID пользователя"
+// @Param input body domain.SetListMemberRequest true "Роль участника"
+// @Success 200 {object} domain.ListMember
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/members/{userID} [put]
+func (h *ListHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	var request domain.SetListMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	member, err := h.service.SetMember(r.Context(), params["id"], params["userID"], request)
+	if err != nil {
+		writeListMemberError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, member)
+}
+
+// RemoveMember исключает пользователя из участников списка
+// @Summary Исключить участника списка
+// @Description Владелец может исключить любого участника, остальные участники — только выйти из списка сами.
+// @Description Единственного владельца исключить нельзя (409)
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID списка"
+// @Param userID path string true "ID пользователя"
+// @Success 204 "Участник исключен"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/lists/{id}/members/{userID} [delete]
+func (h *ListHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.RemoveMember(r.Context(), params["id"], params["userID"]); err != nil {
+		writeListMemberError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeListMemberError переводит ошибки управления участниками списка в HTTP-ответ
+func writeListMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid member data",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not enough permissions in the list",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "List or member not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrConflict):
+		WriteJSON(w, http.StatusConflict, ErrorResponse{
+			Code:    "CONFLICT",
+			Message: "Member cannot be changed",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
+
 func WriteJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(status)
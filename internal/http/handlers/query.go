@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"RestApi/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// parsePagination читает limit и offset из query-параметров.
+// Некорректные значения заменяются значениями по умолчанию.
+func parsePagination(r *http.Request) (int, int) {
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	limit := 20
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l >= 0 {
+			limit = l
+		}
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := 0
+	if offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	return limit, offset
+}
+
+// parseCursor читает query-параметр cursor. Если он передан, выдача идет по курсору,
+// а пустое значение означает первую страницу; byCursor = false — выдача со смещением
+func parseCursor(r *http.Request) (cursor *domain.Cursor, byCursor bool, err error) {
+	query := r.URL.Query()
+	if !query.Has("cursor") {
+		return nil, false, nil
+	}
+	if query.Has("offset") {
+		return nil, true, fmt.Errorf("offset cannot be combined with cursor")
+	}
+
+	value := query.Get("cursor")
+	if value == "" {
+		return nil, true, nil
+	}
+	parsed, err := domain.ParseCursor(value)
+	if err != nil {
+		return nil, true, fmt.Errorf("cursor: %w", err)
+	}
+	return &parsed, true, nil
+}
+
+// setCursorHeaders передает курсоры соседних страниц в заголовках X-Next-Cursor
+// и X-Prev-Cursor, а ссылки на эти страницы — в заголовке Link (RFC 8288).
+// Ссылки сохраняют остальные query-параметры запроса
+func setCursorHeaders(w http.ResponseWriter, r *http.Request, page domain.CursorPage) {
+	var links []string
+	for _, link := range []struct {
+		rel    string
+		header string
+		cursor string
+	}{
+		{rel: "next", header: "X-Next-Cursor", cursor: page.Next},
+		{rel: "prev", header: "X-Prev-Cursor", cursor: page.Prev},
+	} {
+		if link.cursor == "" {
+			continue
+		}
+		w.Header().Set(link.header, link.cursor)
+
+		query := r.URL.Query()
+		query.Set("cursor", link.cursor)
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), link.rel))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// parseListFilter читает include_archived и archived_only из query-параметров
+func parseListFilter(r *http.Request) (domain.ListFilter, error) {
+	query := r.URL.Query()
+
+	var filter domain.ListFilter
+
+	if value := query.Get("include_archived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.ListFilter{}, fmt.Errorf("include_archived must be boolean: %w", err)
+		}
+		filter.IncludeArchived = includeArchived
+	}
+
+	if value := query.Get("archived_only"); value != "" {
+		archivedOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.ListFilter{}, fmt.Errorf("archived_only must be boolean: %w", err)
+		}
+		filter.ArchivedOnly = archivedOnly
+	}
+
+	return filter, nil
+}
+
+// parseTitleSearch читает строку, способ и порог поиска списков по названию из query-параметров
+func parseTitleSearch(r *http.Request) (domain.TitleSearch, error) {
+	query := r.URL.Query()
+
+	search := domain.TitleSearch{
+		Query:     query.Get("q"),
+		Mode:      domain.TitleSearchMode(query.Get("mode")),
+		Threshold: domain.DefaultSimilarityThreshold,
+	}
+
+	if value := query.Get("threshold"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return domain.TitleSearch{}, fmt.Errorf("threshold must be a number: %w", err)
+		}
+		search.Threshold = threshold
+	}
+
+	return search, nil
+}
+
+// parseTaskFilter читает фильтры задач из query-параметров
+func parseTaskFilter(r *http.Request) (domain.TaskFilter, error) {
+	query := r.URL.Query()
+
+	var filter domain.TaskFilter
+
+	for _, bound := range []struct {
+		param  string
+		target **time.Time
+	}{
+		{param: "due_before", target: &filter.DueBefore},
+		{param: "due_after", target: &filter.DueAfter},
+		{param: "created_before", target: &filter.CreatedBefore},
+		{param: "created_after", target: &filter.CreatedAfter},
+		{param: "updated_before", target: &filter.UpdatedBefore},
+		{param: "updated_after", target: &filter.UpdatedAfter},
+	} {
+		value := query.Get(bound.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("%s must be RFC3339: %w", bound.param, err)
+		}
+		*bound.target = &parsed
+	}
+
+	if value := query.Get("overdue"); value != "" {
+		overdue, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("overdue must be boolean: %w", err)
+		}
+		filter.Overdue = overdue
+	}
+
+	if value := query.Get("blocked"); value != "" {
+		blocked, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("blocked must be boolean: %w", err)
+		}
+		filter.Blocked = &blocked
+	}
+
+	if value := query.Get("completed"); value != "" {
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.TaskFilter{}, fmt.Errorf("completed must be boolean: %w", err)
+		}
+		filter.Completed = &completed
+	}
+
+	filter.TextContains = strings.TrimSpace(query.Get("text_contains"))
+
+	if value := query.Get("status"); value != "" {
+		for _, status := range strings.Split(value, ",") {
+			filter.Statuses = append(filter.Statuses, domain.TaskStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	if value := query.Get("tags"); value != "" {
+		for _, tagID := range strings.Split(value, ",") {
+			tagID = strings.TrimSpace(tagID)
+			if _, err := uuid.Parse(tagID); err != nil {
+				return domain.TaskFilter{}, fmt.Errorf("tags must be a comma-separated list of tag IDs: %w", err)
+			}
+			filter.TagIDs = append(filter.TagIDs, tagID)
+		}
+		filter.TagMode = domain.TagMatchMode(query.Get("tag_mode"))
+	}
+
+	sort, err := parseTaskSort(query.Get("sort"), query.Get("order"))
+	if err != nil {
+		return domain.TaskFilter{}, err
+	}
+	filter.Sort = sort
+
+	return filter, nil
+}
+
+// parseSearchFilter читает параметры поиска из query-параметров
+func parseSearchFilter(r *http.Request) (domain.SearchFilter, error) {
+	query := r.URL.Query()
+
+	filter := domain.SearchFilter{
+		Query: query.Get("q"),
+		Type:  domain.SearchItemType(query.Get("type")),
+	}
+
+	if value := query.Get("list_id"); value != "" {
+		if _, err := uuid.Parse(value); err != nil {
+			return domain.SearchFilter{}, fmt.Errorf("list_id must be a UUID: %w", err)
+		}
+		filter.ListID = value
+	}
+
+	if value := query.Get("completed"); value != "" {
+		completed, err := strconv.ParseBool(value)
+		if err != nil {
+			return domain.SearchFilter{}, fmt.Errorf("completed must be boolean: %w", err)
+		}
+		filter.Completed = &completed
+	}
+
+	return filter, nil
+}
+
+// parseTaskSort читает ключи сортировки: поля через запятую, минус перед полем —
+// по убыванию (sort=-updated_at,text). Без минуса сроки, ручной порядок и текст
+// сортируются по возрастанию, остальные поля — по убыванию. order задает направление
+// единственного поля без минуса и оставлен для совместимости.
+// Допустимость полей проверяет сервис.
+func parseTaskSort(value string, order string) ([]domain.TaskSort, error) {
+	if value == "" && order == "" {
+		return nil, nil
+	}
+	if order != "" && order != "asc" && order != "desc" {
+		return nil, fmt.Errorf("order must be asc or desc")
+	}
+	if value == "" {
+		value = string(domain.TaskSortCreatedAt)
+	}
+
+	fields := strings.Split(value, ",")
+	if order != "" && (len(fields) > 1 || strings.HasPrefix(fields[0], "-")) {
+		return nil, fmt.Errorf("order can be combined only with a single sort field without a minus")
+	}
+
+	sorts := make([]domain.TaskSort, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		sort := domain.TaskSort{Field: domain.TaskSortField(strings.TrimPrefix(field, "-"))}
+
+		switch {
+		case strings.HasPrefix(field, "-"):
+			sort.Desc = true
+		case order != "":
+			sort.Desc = order == "desc"
+		default:
+			sort.Desc = sort.Field != domain.TaskSortDue && sort.Field != domain.TaskSortPosition && sort.Field != domain.TaskSortText
+		}
+		sorts = append(sorts, sort)
+	}
+
+	return sorts, nil
+}
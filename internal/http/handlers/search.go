@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/service"
+)
+
+type SearchHandler struct {
+	service *service.SearchService
+}
+
+func NewSearchHandler(service *service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// Search ищет списки и задачи
+// @Summary Полнотекстовый поиск
+// @Description Ищет по тексту задач, названиям и описаниям списков, начиная с наиболее релевантных.
+// @Description Запрос поддерживает "точную фразу", or и -исключение; русские слова ищутся в любой форме.
+// @Description В snippet совпадения выделены тегом <mark>, остальной текст экранирован для HTML.
+// @Description Архивные и удаленные списки и их задачи не ищутся
+// @Tags search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param q query string true "Поисковый запрос"
+// @Param type query string false "Вид объектов" Enums(list, task)
+// @Param list_id query string false "Только указанный список и его задачи"
+// @Param completed query bool false "Только выполненные или невыполненные задачи"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.SearchResult
+// @Header 200 {integer} X-Total-Count "Общее количество найденных объектов"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	filter, err := parseSearchFilter(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	results, total, err := h.service.Search(r.Context(), filter, limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid query parameters",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to search",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, results)
+}
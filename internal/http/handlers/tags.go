@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+type TagHandler struct {
+	service *service.TagService
+}
+
+func NewTagHandler(service *service.TagService) *TagHandler {
+	return &TagHandler{
+		service: service,
+	}
+}
+
+// Create создает новую метку
+// @Summary Создать метку
+// @Description Создает новую метку для задач в рабочем пространстве запроса (X-Workspace-Id). Имена меток уникальны без учета регистра в пределах рабочего пространства
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param input body domain.CreateTagRequest true "Данные для создания метки"
+// @Success 201 {object} domain.Tag
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags [post]
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request domain.CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	tag, err := h.service.Create(r.Context(), request.Name)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, tag)
+}
+
+// GetByID получает метку по ID
+// @Summary Получить метку по ID
+// @Description Возвращает метку по ее идентификатору
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID метки"
+// @Success 200 {object} domain.Tag
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{id} [get]
+func (h *TagHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	tag, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tag)
+}
+
+// List получает метки с пагинацией
+// @Summary Получить метки
+// @Description Возвращает метки рабочего пространства запроса, упорядоченные по имени, с пагинацией
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.Tag
+// @Header 200 {integer} X-Total-Count "Общее количество меток"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags [get]
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	tags, total, err := h.service.List(r.Context(), limit, offset)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get tags",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, tags)
+}
+
+// Update переименовывает метку
+// @Summary Обновить метку
+// @Description Переименовывает метку
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID метки"
+// @Param input body domain.UpdateTagRequest true "Новое название метки"
+// @Success 200 {object} domain.Tag
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{id} [patch]
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var request domain.UpdateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	tag, err := h.service.Update(r.Context(), id, request.Name)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tag)
+}
+
+// Delete удаляет метку
+// @Summary Удалить метку
+// @Description Удаляет метку и снимает ее со всех задач
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID метки"
+// @Success 204 "Удалено"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTaskTags получает метки задачи
+// @Summary Получить метки задачи
+// @Description Возвращает метки, назначенные задаче
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Success 200 {array} domain.Tag
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/tags [get]
+func (h *TagHandler) ListTaskTags(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	tags, err := h.service.ListByTask(r.Context(), taskID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tags)
+}
+
+// AttachToTask назначает метку задаче
+// @Summary Назначить метку задаче
+// @Description Назначает метку задаче. Повторное назначение ничего не меняет
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param tagID path string true "ID метки"
+// @Success 204 "Назначено"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/tags/{tagID} [put]
+func (h *TagHandler) AttachToTask(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.AttachToTask(r.Context(), params["taskID"], params["tagID"]); err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DetachFromTask снимает метку с задачи
+// @Summary Снять метку с задачи
+// @Description Снимает метку с задачи
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param tagID path string true "ID метки"
+// @Success 204 "Снято"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/tags/{tagID} [delete]
+func (h *TagHandler) DetachFromTask(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.DetachFromTask(r.Context(), params["taskID"], params["tagID"]); err != nil {
+		writeTagError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTagError переводит ошибки сервиса меток в HTTP-ответ
+func writeTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "name must be 1..50 chars",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not enough permissions in the list",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Tag or task not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrAlreadyExists):
+		WriteJSON(w, http.StatusConflict, ErrorResponse{
+			Code:    "CONFLICT",
+			Message: "Tag with this name already exists",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,15 +26,21 @@ func NewTaskHandler(service *service.TaskService) *TaskHandler {
 
 // CreateTask создает новую задачу
 // @Summary Создать задачу
-// @Description Создает новую задачу в указанном списке
+// @Description Создает новую задачу в указанном списке. В архивный список добавлять задачи нельзя (409).
+// @Description recurrence_rule задает повторение: daily, weekly, monthly, yearly или RRULE
+// @Description (FREQ, INTERVAL, BYDAY для WEEKLY, BYMONTHDAY для MONTHLY, COUNT, UNTIL)
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param listID path string true "ID списка"
 // @Param input body domain.CreateTaskRequest true "Данные для создания задачи"
 // @Success 201 {object} domain.Task
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/{listID}/tasks [post]
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -52,12 +59,28 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := h.service.CreateTask(listID, request.Text)
+	task, err := h.service.CreateTask(r.Context(), listID, request)
 	if err != nil {
-		if err == service.ErrValidation {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
 			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
 				Code:    "VALIDATION_FAILED",
-				Message: "text must be 1..500 chars",
+				Message: "Invalid task data",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "List is archived",
 				Details: err.Error(),
 			})
 			return
@@ -82,8 +105,11 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param taskID path string true "ID задачи"
 // @Success 200 {object} domain.Task
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/tasks/{taskID} [get]
@@ -92,8 +118,16 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["taskID"]
 
-	task, err := h.service.GetByIDTask(id)
+	task, err := h.service.GetByIDTask(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
 		if err == postgres.ErrNotFound {
 			WriteJSON(w, http.StatusNotFound, ErrorResponse{
 				Code:    "NOT_FOUND",
@@ -116,15 +150,43 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 // ListTasks получает задачи списка
 // @Summary Получить задачи списка
-// @Description Возвращает задачи указанного списка с пагинацией
+// @Description Возвращает задачи указанного списка с пагинацией.
+// @Description При view=tree возвращаются задачи верхнего уровня (domain.TaskNode) с вложенными подзадачами, пагинация применяется к задачам верхнего уровня.
+// @Description С параметром cursor (пустое значение — первая страница) плоский список задач, упорядоченный по created_at,
+// @Description выдается по курсору без подсчета общего количества: курсоры соседних страниц передаются в X-Next-Cursor, X-Prev-Cursor и Link
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param listID path string true "ID списка"
 // @Param limit query int false "Лимит" default(20)
 // @Param offset query int false "Смещение" default(0)
+// @Param cursor query string false "Курсор страницы из X-Next-Cursor или X-Prev-Cursor"
+// @Param due_before query string false "Срок раньше указанного времени (RFC3339)"
+// @Param due_after query string false "Срок позже указанного времени (RFC3339)"
+// @Param created_before query string false "Создана раньше указанного времени (RFC3339)"
+// @Param created_after query string false "Создана позже указанного времени (RFC3339)"
+// @Param updated_before query string false "Изменена раньше указанного времени (RFC3339)"
+// @Param updated_after query string false "Изменена позже указанного времени (RFC3339)"
+// @Param completed query bool false "Только выполненные (true) или невыполненные (false) задачи"
+// @Param text_contains query string false "Подстрока текста задачи без учета регистра"
+// @Param overdue query bool false "Только просроченные незавершенные задачи"
+// @Param blocked query bool false "Только задачи с невыполненными блокирующими задачами (true) или без них (false)"
+// @Param status query string false "Статусы через запятую"
+// @Param group_by query string false "Группировка: задачи по статусам (domain.TaskGroup), пагинация применяется к каждой группе" Enums(status)
+// @Param view query string false "Представление: плоский список или дерево подзадач" Enums(flat, tree)
+// @Param tags query string false "ID меток через запятую"
+// @Param tag_mode query string false "Режим сопоставления меток (по умолчанию any)" Enums(any, all)
+// @Param sort query string false "Поля сортировки через запятую, минус — по убыванию (-updated_at,text): created_at, updated_at, priority, due, position (ручной порядок), text"
+// @Param order query string false "Направление единственного поля сортировки без минуса (по умолчанию desc, для due, position и text — asc)" Enums(asc, desc)
 // @Success 200 {array} domain.Task
-// @Header 200 {integer} X-Total-Count "Общее количество задач"
+// @Header 200 {integer} X-Total-Count "Общее количество задач (при view=tree — задач верхнего уровня; без cursor)"
+// @Header 200 {string} X-Next-Cursor "Курсор следующей страницы"
+// @Header 200 {string} X-Prev-Cursor "Курсор предыдущей страницы"
+// @Header 200 {string} Link "Ссылки на соседние страницы (RFC 8288)"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/lists/{listID}/tasks [get]
@@ -133,29 +195,181 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	listID := params["listID"]
 
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
+	limit, offset := parsePagination(r)
 
-	limit := 20
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l >= 0 {
-			limit = l
-		}
+	filter, err := parseTaskFilter(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: err.Error(),
+		})
+		return
 	}
 
-	if limit > 100 {
-		limit = 100
+	cursor, byCursor, err := parseCursor(r)
+	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: err.Error(),
+		})
+		return
 	}
 
-	offset := 0
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
+	view := r.URL.Query().Get("view")
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "" && (groupBy != "status" || view == "tree") {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: "group_by must be status and cannot be combined with view=tree",
+		})
+		return
+	}
+	if byCursor && (groupBy != "" || view == "tree") {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: "cursor cannot be combined with group_by or view=tree",
+		})
+		return
+	}
+
+	var tasks interface{}
+	var total int
+	var page domain.CursorPage
+	switch {
+	case byCursor:
+		tasks, page, err = h.service.ListTasksByCursor(r.Context(), listID, filter, cursor, limit)
+	case groupBy == "status":
+		tasks, total, err = h.service.ListTaskGroups(r.Context(), listID, filter, limit, offset)
+	case view == "" || view == "flat":
+		tasks, total, err = h.service.ListTasks(r.Context(), listID, filter, limit, offset)
+	case view == "tree":
+		tasks, total, err = h.service.ListTaskTree(r.Context(), listID, filter, limit, offset)
+	default:
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: "view must be flat or tree",
+		})
+		return
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "List not found",
+				Details: err.Error(),
+			})
+			return
 		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid filter parameters",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get tasks",
+			Details: err.Error(),
+		})
+		return
 	}
 
-	tasks, total, err := h.service.ListTasks(listID, limit, offset)
+	if byCursor {
+		setCursorHeaders(w, r, page)
+	} else {
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	}
+	WriteJSON(w, http.StatusOK, tasks)
+}
+
+// ListAssignedTasks получает задачи, назначенные пользователю
+// @Summary Получить задачи пользователя
+// @Description Возвращает задачи, назначенные пользователю, из всех списков с пагинацией. Фильтры и сортировка те же, что у задач списка
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Param due_before query string false "Срок раньше указанного времени (RFC3339)"
+// @Param due_after query string false "Срок позже указанного времени (RFC3339)"
+// @Param created_before query string false "Создана раньше указанного времени (RFC3339)"
+// @Param created_after query string false "Создана позже указанного времени (RFC3339)"
+// @Param updated_before query string false "Изменена раньше указанного времени (RFC3339)"
+// @Param updated_after query string false "Изменена позже указанного времени (RFC3339)"
+// @Param completed query bool false "Только выполненные (true) или невыполненные (false) задачи"
+// @Param text_contains query string false "Подстрока текста задачи без учета регистра"
+// @Param overdue query bool false "Только просроченные незавершенные задачи"
+// @Param blocked query bool false "Только задачи с невыполненными блокирующими задачами (true) или без них (false)"
+// @Param status query string false "Статусы через запятую"
+// @Param tags query string false "ID меток через запятую"
+// @Param tag_mode query string false "Режим сопоставления меток (по умолчанию any)" Enums(any, all)
+// @Param sort query string false "Поля сортировки через запятую, минус — по убыванию (-updated_at,text): created_at, updated_at, priority, due, text"
+// @Param order query string false "Направление единственного поля сортировки без минуса (по умолчанию desc, для due и text — asc)" Enums(asc, desc)
+// @Success 200 {array} domain.Task
+// @Header 200 {integer} X-Total-Count "Общее количество задач"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id}/tasks [get]
+func (h *TaskHandler) ListAssignedTasks(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	limit, offset := parsePagination(r)
+
+	filter, err := parseTaskFilter(r)
 	if err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid filter parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	tasks, total, err := h.service.ListAssignedTasks(r.Context(), userID, filter, limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid filter parameters",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "User not found",
+				Details: err.Error(),
+			})
+			return
+		}
 		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: "Failed to get tasks",
@@ -168,17 +382,568 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, tasks)
 }
 
+// ListSubtasks получает подзадачи задачи
+// @Summary Получить подзадачи
+// @Description Возвращает непосредственные подзадачи задачи в порядке создания
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Success 200 {array} domain.Task
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/subtasks [get]
+func (h *TaskHandler) ListSubtasks(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	subtasks, err := h.service.ListSubtasks(r.Context(), taskID)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get subtasks",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, subtasks)
+}
+
+// ListBlockers получает задачи, блокирующие задачу
+// @Summary Получить блокирующие задачи
+// @Description Возвращает задачи, которые должны быть выполнены до завершения задачи, в порядке добавления зависимостей
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Success 200 {array} domain.Task
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/dependencies [get]
+func (h *TaskHandler) ListBlockers(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	blockers, err := h.service.ListBlockers(r.Context(), taskID)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get dependencies",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, blockers)
+}
+
+// AddDependency делает задачу blockerID блокирующей для задачи
+// @Summary Добавить зависимость
+// @Description Задачу нельзя будет завершить, пока не выполнена blockerID. Повторное добавление ничего не меняет.
+// @Description Зависимость, замыкающая цикл, отклоняется (409)
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param blockerID path string true "ID блокирующей задачи"
+// @Success 204 "Добавлено"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/dependencies/{blockerID} [put]
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.AddDependency(r.Context(), params["taskID"], params["blockerID"]); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid dependency",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "Dependency would create a cycle",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveDependency убирает зависимость задачи от blockerID
+// @Summary Удалить зависимость
+// @Description Убирает задачу blockerID из блокирующих задач
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param blockerID path string true "ID блокирующей задачи"
+// @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/dependencies/{blockerID} [delete]
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.RemoveDependency(r.Context(), params["taskID"], params["blockerID"]); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Dependency not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MoveTask меняет место задачи в ручном порядке или переносит ее в другой список
+// @Summary Переместить задачу
+// @Description Ставит задачу сразу после after_task_id и/или сразу перед before_task_id.
+// @Description Меняется только позиция перемещаемой задачи; порядок читается через sort=position.
+// @Description При list_id другого списка задача переносится туда вместе с подзадачами и встает в конец,
+// @Description если соседи не указаны. reset_completed=true снимает отметку о выполнении с перенесенных задач
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param input body domain.MoveTaskRequest true "Целевой список и соседние задачи"
+// @Success 200 {object} domain.Task
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/move [post]
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	var request domain.MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	task, err := h.service.MoveTask(r.Context(), taskID, request)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid move request",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "List is archived",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, task)
+}
+
+// CopyTask создает копию задачи в том же или другом списке
+// @Summary Скопировать задачу
+// @Description Создает копию задачи (текст, приоритет, срок и метки) без подзадач в конце списка list_id.
+// @Description Без list_id копия создается в том же списке; reset_completed=true делает копию невыполненной
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Param input body domain.CopyTaskRequest true "Целевой список"
+// @Success 201 {object} domain.Task
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/copy [post]
+func (h *TaskHandler) CopyTask(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	var request domain.CopyTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	task, err := h.service.CopyTask(r.Context(), taskID, request)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid copy request",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "List is archived",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, task)
+}
+
+// MoveTasks переносит несколько задач в другой список
+// @Summary Перенести задачи в другой список
+// @Description Переносит задачи вместе с подзадачами в конец списка list_id в одной транзакции.
+// @Description Задачи, уже находящиеся в этом списке, не меняются. Возвращает все перенесенные задачи
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param input body domain.TransferTasksRequest true "Задачи и целевой список"
+// @Success 200 {array} domain.Task
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/move [post]
+func (h *TaskHandler) MoveTasks(w http.ResponseWriter, r *http.Request) {
+	var request domain.TransferTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	tasks, err := h.service.MoveTasks(r.Context(), request)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid move request",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "List is archived",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tasks)
+}
+
+// CopyTasks копирует несколько задач в список
+// @Summary Скопировать задачи в список
+// @Description Создает копии задач без подзадач в конце списка list_id в одной транзакции
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param input body domain.TransferTasksRequest true "Задачи и целевой список"
+// @Success 201 {array} domain.Task
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/copy [post]
+func (h *TaskHandler) CopyTasks(w http.ResponseWriter, r *http.Request) {
+	var request domain.TransferTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	tasks, err := h.service.CopyTasks(r.Context(), request)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid copy request",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "List is archived",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, tasks)
+}
+
+// GetWorkflow получает статусы задач и допустимые переходы
+// @Summary Получить рабочий процесс задач
+// @Description Возвращает статусы задач в порядке отображения (первый присваивается новым задачам)
+// @Description и статусы, в которые можно перейти из каждого статуса
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} domain.TaskWorkflow
+// @Router /api/v1/tasks/workflow [get]
+func (h *TaskHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, h.service.Workflow())
+}
+
+// ListOverdueTasks получает просроченные задачи
+// @Summary Получить просроченные задачи
+// @Description Возвращает незавершенные задачи с истекшим сроком из всех списков, начиная с самых давних
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.Task
+// @Header 200 {integer} X-Total-Count "Общее количество просроченных задач"
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/overdue [get]
+func (h *TaskHandler) ListOverdueTasks(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	tasks, total, err := h.service.ListOverdueTasks(r.Context(), limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get overdue tasks",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, tasks)
+}
+
 // Update обновляет задачу
 // @Summary Обновить задачу
-// @Description Обновляет описание и/или статус выполнения задачи
+// @Description Обновляет описание, статус, приоритет, срок, исполнителя и/или родительскую задачу.
+// @Description Переход между статусами проверяется по рабочему процессу (GET /api/v1/tasks/workflow), недопустимый переход — 409.
+// @Description completed=true переводит задачу в статус done, completed=false возвращает выполненную задачу в начальный статус.
+// @Description При переводе в done и cascade=true завершаются также все подзадачи; если переход запрещен рабочим процессом хотя бы для одной подзадачи — 409.
+// @Description Задачу с невыполненными блокирующими задачами нельзя завершить (409), если не передан force=true.
+// @Description Завершение повторяющейся задачи в той же транзакции создает следующее повторение
+// @Description с новым сроком и метками; правило повторения переходит к новой задаче
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param taskID path string true "ID списка"
 // @Param input body domain.UpdateTaskRequest true "Данные для обновления задачи"
 // @Success 200 {object} domain.Task
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/tasks/{taskID} [patch]
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
@@ -208,21 +973,41 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 	fmt.Printf("===============================\n")
 
-	if request.Text == nil && request.Completed == nil {
+	if request.Text == nil && request.Status == nil && request.Completed == nil && request.Priority == nil &&
+		request.DueAt == nil && !request.ClearDueAt &&
+		request.ParentTaskID == nil && !request.ClearParent &&
+		request.RecurrenceRule == nil && !request.ClearRecurrence &&
+		request.AssigneeID == nil && !request.ClearAssignee {
 		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
 			Code:    "VALIDATION_FAILED",
-			Message: "At least one field (text or completed) must be provided",
+			Message: "At least one field (text, status, completed, priority, due_at, clear_due_at, parent_task_id, clear_parent, recurrence_rule, clear_recurrence, assignee_id or clear_assignee) must be provided",
 			Details: "No fields to update",
 		})
 		return
 	}
 
-	updatedTask, err := h.service.UpdateTask(taskID, request.Text, request.Completed)
+	updatedTask, err := h.service.UpdateTask(r.Context(), taskID, request)
 	if err != nil {
-		if err == service.ErrValidation {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
 			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
 				Code:    "VALIDATION_FAILED",
-				Message: "text must be 1..500 chars",
+				Message: "Invalid task data",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "Status change is not allowed",
 				Details: err.Error(),
 			})
 			return
@@ -245,14 +1030,18 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, updatedTask)
 }
 
-// Delete удаляет задачу
+// Delete перемещает задачу в корзину
 // @Summary Удалить задачу
-// @Description Удаляет задачу по ее идентификатору
+// @Description Перемещает задачу вместе с подзадачами в корзину. Восстановить ее можно через
+// @Description POST /api/v1/tasks/{taskID}/restore до окончательной очистки корзины
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Param taskID path string true "ID задачи"
 // @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/tasks/{taskID} [delete]
@@ -261,8 +1050,16 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	taskID := params["taskID"]
 
-	err := h.service.DeleteTask(taskID)
+	err := h.service.DeleteTask(r.Context(), taskID)
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
 		if err == postgres.ErrNotFound {
 			WriteJSON(w, http.StatusNotFound, ErrorResponse{
 				Code:    "NOT_FOUND",
@@ -286,3 +1083,60 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		"message": "Задача успешно удалена",
 	})
 }
+
+// RestoreTask возвращает задачу из корзины
+// @Summary Восстановить задачу
+// @Description Возвращает задачу из корзины вместе с подзадачами, удаленными одновременно с ней.
+// @Description Если родительская задача удалена, задача восстанавливается на верхнем уровне.
+// @Description Задачу удаленного списка нужно восстанавливать вместе со списком (409)
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param taskID path string true "ID задачи"
+// @Success 200 {object} domain.Task
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tasks/{taskID}/restore [post]
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	taskID := mux.Vars(r)["taskID"]
+
+	task, err := h.service.RestoreTask(r.Context(), taskID)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Not enough permissions in the list",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			WriteJSON(w, http.StatusNotFound, ErrorResponse{
+				Code:    "NOT_FOUND",
+				Message: "Task not found in trash",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrConflict) {
+			WriteJSON(w, http.StatusConflict, ErrorResponse{
+				Code:    "CONFLICT",
+				Message: "Task cannot be restored",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, task)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+)
+
+type TrashHandler struct {
+	service *service.TrashService
+}
+
+func NewTrashHandler(service *service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		service: service,
+	}
+}
+
+// List получает содержимое корзины
+// @Summary Получить корзину
+// @Description Возвращает удаленные списки и задачи, начиная с недавно удаленных.
+// @Description Задачи, удаленные вместе со списком или родительской задачей, отдельно не показываются.
+// @Description Пользователь видит только объекты списков, в которых состоит.
+// @Description Объекты окончательно удаляются по истечении срока хранения (TRASH_RETENTION)
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param type query string false "Вид объектов" Enums(list, task)
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.TrashItem
+// @Header 200 {integer} X-Total-Count "Общее количество объектов в корзине"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/trash [get]
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	itemType := domain.TrashItemType(r.URL.Query().Get("type"))
+
+	items, total, err := h.service.List(r.Context(), itemType, limit, offset)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			WriteJSON(w, http.StatusForbidden, ErrorResponse{
+				Code:    "FORBIDDEN",
+				Message: "Request must be made on behalf of a user",
+				Details: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, service.ErrValidation) {
+			WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid query parameters",
+				Details: err.Error(),
+			})
+			return
+		}
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get trash",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, items)
+}
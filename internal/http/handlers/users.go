@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+type UserHandler struct {
+	service *service.UserService
+}
+
+func NewUserHandler(service *service.UserService) *UserHandler {
+	return &UserHandler{
+		service: service,
+	}
+}
+
+// Create создает пользователя
+// @Summary Создать пользователя
+// @Description Создает пользователя, которому можно назначать задачи. Email уникален без учета регистра. С паролем пользователь может получить токен доступа. Нужно право admin
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param input body domain.CreateUserRequest true "Данные для создания пользователя"
+// @Success 201 {object} domain.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users [post]
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request domain.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.service.Create(r.Context(), request)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, user)
+}
+
+// GetByID получает пользователя по ID
+// @Summary Получить пользователя по ID
+// @Description Возвращает пользователя, состоящего в рабочем пространстве запроса, по его идентификатору
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID пользователя"
+// @Success 200 {object} domain.User
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id} [get]
+func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	user, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, user)
+}
+
+// List получает пользователей с пагинацией
+// @Summary Получить пользователей
+// @Description Возвращает пользователей, состоящих в рабочем пространстве запроса, упорядоченных по имени, с пагинацией
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.User
+// @Header 200 {integer} X-Total-Count "Общее количество пользователей"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users [get]
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	users, total, err := h.service.List(r.Context(), limit, offset)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get users",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, users)
+}
+
+// Update обновляет пользователя
+// @Summary Обновить пользователя
+// @Description Меняет только переданные поля: имя, email и/или пароль. Менять пользователя может он сам или субъект с правом admin.
+// @Description Свой пароль меняется только с current_password. После смены пароля или email все сессии пользователя завершаются
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID пользователя"
+// @Param input body domain.UpdateUserRequest true "Данные для обновления пользователя"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id} [patch]
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var request domain.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.service.Update(r.Context(), id, request)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, user)
+}
+
+// Delete удаляет пользователя
+// @Summary Удалить пользователя
+// @Description Удаляет пользователя. Назначенные ему задачи остаются без исполнителя.
+// @Description Удалить пользователя может он сам или субъект с правом admin
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID пользователя"
+// @Success 204 "Удалено"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/{id} [delete]
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeUserError переводит ошибки сервиса пользователей в HTTP-ответ
+func writeUserError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid user data",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not allowed to change this user",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "User not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrAlreadyExists):
+		WriteJSON(w, http.StatusConflict, ErrorResponse{
+			Code:    "CONFLICT",
+			Message: "User with this email already exists",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/service"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/gorilla/mux"
+)
+
+type WorkspaceHandler struct {
+	service *service.WorkspaceService
+}
+
+func NewWorkspaceHandler(service *service.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		service: service,
+	}
+}
+
+// Create создает рабочее пространство
+// @Summary Создать рабочее пространство
+// @Description Создает рабочее пространство. Списки и задачи видны только внутри своего рабочего пространства, оно задается привязкой ключа или токена, а для ключа admin без привязки — заголовком X-Workspace-Id. Требует права admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param input body domain.CreateWorkspaceRequest true "Данные для создания рабочего пространства"
+// @Success 201 {object} domain.Workspace
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workspaces [post]
+func (h *WorkspaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request domain.CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid JSON format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	workspace, err := h.service.Create(r.Context(), request)
+	if err != nil {
+		writeWorkspaceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, workspace)
+}
+
+// GetByID получает рабочее пространство по ID
+// @Summary Получить рабочее пространство по ID
+// @Description Возвращает рабочее пространство по его идентификатору. Требует права admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID рабочего пространства"
+// @Success 200 {object} domain.Workspace
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workspaces/{id} [get]
+func (h *WorkspaceHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	workspace, err := h.service.GetByID(id)
+	if err != nil {
+		writeWorkspaceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, workspace)
+}
+
+// List получает рабочие пространства с пагинацией
+// @Summary Получить рабочие пространства
+// @Description Возвращает рабочие пространства в порядке создания с пагинацией. Требует права admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} domain.Workspace
+// @Header 200 {integer} X-Total-Count "Общее количество рабочих пространств"
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workspaces [get]
+func (h *WorkspaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	workspaces, total, err := h.service.List(limit, offset)
+	if err != nil {
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Failed to get workspaces",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	WriteJSON(w, http.StatusOK, workspaces)
+}
+
+// AddMember добавляет пользователя в рабочее пространство
+// @Summary Добавить пользователя в рабочее пространство
+// @Description Добавляет пользователя в рабочее пространство; повторное добавление не ошибка. Войти по паролю можно только в рабочее пространство, в котором состоит пользователь. Требует права admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID рабочего пространства"
+// @Param userID path string true "ID пользователя"
+// @Success 204 "Пользователь добавлен"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workspaces/{id}/members/{userID} [put]
+func (h *WorkspaceHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.AddMember(r.Context(), vars["id"], vars["userID"]); err != nil {
+		writeWorkspaceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveMember исключает пользователя из рабочего пространства
+// @Summary Исключить пользователя из рабочего пространства
+// @Description Исключает пользователя из рабочего пространства. Уже выданные токены действуют до истечения срока. Требует права admin
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "ID рабочего пространства"
+// @Param userID path string true "ID пользователя"
+// @Success 204 "Пользователь исключен"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/workspaces/{id}/members/{userID} [delete]
+func (h *WorkspaceHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.service.RemoveMember(r.Context(), vars["id"], vars["userID"]); err != nil {
+		writeWorkspaceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeWorkspaceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		WriteJSON(w, http.StatusBadRequest, ErrorResponse{
+			Code:    "VALIDATION_FAILED",
+			Message: "Invalid workspace data",
+			Details: err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		WriteJSON(w, http.StatusForbidden, ErrorResponse{
+			Code:    "FORBIDDEN",
+			Message: "Not allowed to manage this workspace",
+			Details: err.Error(),
+		})
+	case errors.Is(err, postgres.ErrNotFound):
+		WriteJSON(w, http.StatusNotFound, ErrorResponse{
+			Code:    "NOT_FOUND",
+			Message: "Workspace not found",
+			Details: err.Error(),
+		})
+	default:
+		WriteJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: "Internal server error",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"RestApi/internal/requestctx"
+)
+
+// maxActorLength ограничивает длину имени автора из заголовка
+const maxActorLength = 100
+
+// Actor кладет в контекст запроса автора изменений из заголовка X-Actor.
+// Он попадает в историю изменений списков и задач.
+// Если субъект запроса уже известен, заголовок не учитывается: автор — сам субъект.
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := requestctx.Principal(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		actor := strings.TrimSpace(r.Header.Get("X-Actor"))
+		if runes := []rune(actor); len(runes) > maxActorLength {
+			actor = string(runes[:maxActorLength])
+		}
+		if actor != "" {
+			r = r.WithContext(requestctx.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
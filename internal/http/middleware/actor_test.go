@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActor(t *testing.T) {
+	var actor string
+	handler := Actor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = requestctx.Actor(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("actor from header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/lists", nil)
+		r.Header.Set("X-Actor", " alice ")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "alice", actor)
+	})
+
+	t.Run("long header is truncated", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/lists", nil)
+		r.Header.Set("X-Actor", strings.Repeat("я", maxActorLength+1))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, strings.Repeat("я", maxActorLength), actor)
+	})
+
+	t.Run("authenticated principal wins over header", func(t *testing.T) {
+		principal := domain.Principal{Type: domain.PrincipalAPIKey, ID: "key-1", Name: "CI"}
+		ctx := requestctx.WithActor(requestctx.WithPrincipal(context.Background(), principal), principal.Name)
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/lists", nil).WithContext(ctx)
+		r.Header.Set("X-Actor", "mallory")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "CI", actor)
+	})
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/http/handlers"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/service"
+)
+
+// Authenticator проверяет API-ключ и возвращает его описание
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (domain.APIKey, error)
+}
+
+// TokenVerifier проверяет токен доступа и возвращает субъекта, которому он выдан
+type TokenVerifier interface {
+	VerifyAccessToken(token string) (domain.Principal, error)
+}
+
+// adminPaths — управление ключами и рабочими пространствами доступно только с правом admin
+var adminPaths = []string{"/api/v1/api-keys", "/api/v1/workspaces"}
+
+// adminRequests — создание пользователей тоже доступно только с правом admin
+var adminRequests = map[string]bool{
+	http.MethodPost + " /api/v1/users": true,
+}
+
+// publicPaths — выдача и обновление токенов доступны без аутентификации
+var publicPaths = map[string]bool{
+	"/health":              true,
+	"/api/v1/auth/token":   true,
+	"/api/v1/auth/refresh": true,
+	"/api/v1/auth/logout":  true,
+}
+
+// Auth пропускает только аутентифицированные запросы с нужным правом:
+// read для чтения, write для изменений, admin для управления ключами, рабочими пространствами
+// и для создания пользователей.
+// Заголовок Authorization содержит API-ключ ("ApiKey <ключ>" или просто ключ)
+// или токен доступа ("Bearer <токен>").
+// Проверка работоспособности, выдача токенов, Swagger и preflight-запросы CORS открыты.
+// Субъект запроса кладется в контекст; его имя становится автором изменений
+// вместо заголовка X-Actor.
+func Auth(apiKeys Authenticator, tokens TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isPublic(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, scheme, err := authenticate(r, apiKeys, tokens)
+			if errors.Is(err, service.ErrUnauthorized) {
+				w.Header().Set("WWW-Authenticate", scheme)
+				handlers.WriteJSON(w, http.StatusUnauthorized, handlers.ErrorResponse{
+					Code:    "UNAUTHORIZED",
+					Message: "Valid API key or access token is required",
+					Details: err.Error(),
+				})
+				return
+			}
+			if err != nil {
+				handlers.WriteJSON(w, http.StatusInternalServerError, handlers.ErrorResponse{
+					Code:    "INTERNAL_ERROR",
+					Message: "Failed to authenticate request",
+					Details: err.Error(),
+				})
+				return
+			}
+
+			if scope := requiredScope(r); !principal.Allows(scope) {
+				handlers.WriteJSON(w, http.StatusForbidden, handlers.ErrorResponse{
+					Code:    "FORBIDDEN",
+					Message: "Credentials do not have the required scope",
+					Details: "required scope: " + string(scope),
+				})
+				return
+			}
+
+			ctx := requestctx.WithPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(requestctx.WithActor(ctx, principal.Name)))
+		})
+	}
+}
+
+// authenticate проверяет учетные данные из заголовка Authorization
+// и возвращает субъекта запроса и схему аутентификации
+func authenticate(r *http.Request, apiKeys Authenticator, tokens TokenVerifier) (domain.Principal, string, error) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	scheme, credentials, found := strings.Cut(header, " ")
+	credentials = strings.TrimSpace(credentials)
+
+	switch {
+	case !found:
+		// Swagger UI передает API-ключ без схемы
+		credentials = header
+	case strings.EqualFold(scheme, "Bearer"):
+		principal, err := tokens.VerifyAccessToken(credentials)
+		return principal, "Bearer", err
+	case !strings.EqualFold(scheme, "ApiKey"):
+		return domain.Principal{}, "ApiKey", fmt.Errorf("%w: unsupported authorization scheme %q", service.ErrUnauthorized, scheme)
+	}
+
+	key, err := apiKeys.Authenticate(r.Context(), credentials)
+	if err != nil {
+		return domain.Principal{}, "ApiKey", err
+	}
+	return key.Principal(), "ApiKey", nil
+}
+
+// isPublic сообщает, доступен ли запрос без аутентификации
+func isPublic(r *http.Request) bool {
+	return r.Method == http.MethodOptions ||
+		publicPaths[r.URL.Path] ||
+		strings.HasPrefix(r.URL.Path, "/swagger/")
+}
+
+// requiredScope возвращает право, нужное для запроса
+func requiredScope(r *http.Request) domain.APIKeyScope {
+	if adminRequests[r.Method+" "+r.URL.Path] {
+		return domain.ScopeAdmin
+	}
+	for _, path := range adminPaths {
+		if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path+"/") {
+			return domain.ScopeAdmin
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return domain.ScopeRead
+	default:
+		return domain.ScopeWrite
+	}
+}
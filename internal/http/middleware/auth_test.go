@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/http/handlers"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubAuthenticator знает ключи из карты
+type stubAuthenticator map[string]domain.APIKey
+
+func (s stubAuthenticator) Authenticate(ctx context.Context, key string) (domain.APIKey, error) {
+	if key == "broken" {
+		return domain.APIKey{}, errors.New("db is down")
+	}
+	apiKey, ok := s[key]
+	if !ok {
+		return domain.APIKey{}, fmt.Errorf("%w: api key is invalid", service.ErrUnauthorized)
+	}
+	return apiKey, nil
+}
+
+// stubVerifier принимает единственный токен пользователя
+type stubVerifier struct{}
+
+func (stubVerifier) VerifyAccessToken(token string) (domain.Principal, error) {
+	if token != "user-token" {
+		return domain.Principal{}, fmt.Errorf("%w: jwt: invalid token", service.ErrUnauthorized)
+	}
+	return domain.Principal{Type: domain.PrincipalUser, ID: "user-1", Name: "Алиса", Scopes: []domain.APIKeyScope{domain.ScopeWrite}}, nil
+}
+
+func TestAuth(t *testing.T) {
+	authenticator := stubAuthenticator{
+		"reader": {Name: "reader", Scopes: []domain.APIKeyScope{domain.ScopeRead}},
+		"writer": {Name: "writer", Scopes: []domain.APIKeyScope{domain.ScopeWrite}},
+		"admin":  {Name: "admin", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}},
+	}
+
+	var actor string
+	var principal domain.Principal
+	handler := Auth(authenticator, stubVerifier{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = requestctx.Actor(r.Context())
+		principal, _ = requestctx.Principal(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tc := range []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		status        int
+		code          string
+		challenge     string
+	}{
+		{"health is public", http.MethodGet, "/health", "", http.StatusOK, "", ""},
+		{"swagger is public", http.MethodGet, "/swagger/index.html", "", http.StatusOK, "", ""},
+		{"preflight is public", http.MethodOptions, "/api/v1/lists", "", http.StatusOK, "", ""},
+		{"login is public", http.MethodPost, "/api/v1/auth/token", "", http.StatusOK, "", ""},
+		{"missing key", http.MethodGet, "/api/v1/lists", "", http.StatusUnauthorized, "UNAUTHORIZED", "ApiKey"},
+		{"unknown key", http.MethodGet, "/api/v1/lists", "ApiKey nobody", http.StatusUnauthorized, "UNAUTHORIZED", "ApiKey"},
+		{"unsupported scheme", http.MethodGet, "/api/v1/lists", "Basic reader", http.StatusUnauthorized, "UNAUTHORIZED", "ApiKey"},
+		{"key as bearer token", http.MethodGet, "/api/v1/lists", "Bearer reader", http.StatusUnauthorized, "UNAUTHORIZED", "Bearer"},
+		{"authenticator failure", http.MethodGet, "/api/v1/lists", "broken", http.StatusInternalServerError, "INTERNAL_ERROR", ""},
+		{"read with scheme", http.MethodGet, "/api/v1/lists", "ApiKey reader", http.StatusOK, "", ""},
+		{"read without scheme", http.MethodGet, "/api/v1/lists", "reader", http.StatusOK, "", ""},
+		{"write needs write scope", http.MethodPost, "/api/v1/lists", "ApiKey reader", http.StatusForbidden, "FORBIDDEN", ""},
+		{"write", http.MethodDelete, "/api/v1/lists/1", "apikey writer", http.StatusOK, "", ""},
+		{"keys need admin scope", http.MethodGet, "/api/v1/api-keys", "ApiKey writer", http.StatusForbidden, "FORBIDDEN", ""},
+		{"key revoke needs admin scope", http.MethodDelete, "/api/v1/api-keys/1", "ApiKey writer", http.StatusForbidden, "FORBIDDEN", ""},
+		{"admin", http.MethodPost, "/api/v1/api-keys", "ApiKey admin", http.StatusOK, "", ""},
+		{"user creation needs admin scope", http.MethodPost, "/api/v1/users", "ApiKey writer", http.StatusForbidden, "FORBIDDEN", ""},
+		{"user token cannot create users", http.MethodPost, "/api/v1/users", "Bearer user-token", http.StatusForbidden, "FORBIDDEN", ""},
+		{"admin creates user", http.MethodPost, "/api/v1/users", "ApiKey admin", http.StatusOK, "", ""},
+		{"user list needs read scope", http.MethodGet, "/api/v1/users", "ApiKey reader", http.StatusOK, "", ""},
+		{"user token", http.MethodPatch, "/api/v1/tasks/1", "Bearer user-token", http.StatusOK, "", ""},
+		{"user token cannot manage keys", http.MethodGet, "/api/v1/api-keys", "Bearer user-token", http.StatusForbidden, "FORBIDDEN", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.authorization != "" {
+				r.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tc.status, w.Code)
+			if tc.code == "" {
+				return
+			}
+
+			var response handlers.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.code, response.Code)
+			assert.Equal(t, tc.challenge, w.Header().Get("WWW-Authenticate"))
+		})
+	}
+
+	t.Run("key name becomes actor", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/lists", nil)
+		r.Header.Set("Authorization", "ApiKey writer")
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		assert.Equal(t, "writer", actor)
+		assert.Equal(t, domain.PrincipalAPIKey, principal.Type)
+	})
+
+	t.Run("user becomes principal", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+		r.Header.Set("Authorization", "Bearer user-token")
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		assert.Equal(t, "Алиса", actor)
+		assert.Equal(t, domain.PrincipalUser, principal.Type)
+		assert.Equal(t, "user-1", principal.ID)
+	})
+
+	t.Run("X-User-Id is ignored", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+		r.Header.Set("Authorization", "Bearer user-token")
+		r.Header.Set("X-User-Id", "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b")
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		assert.Equal(t, "user-1", principal.ID)
+
+		r = httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+		r.Header.Set("X-User-Id", "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -1,12 +1,22 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
 
+	"github.com/google/uuid"
+
+	"RestApi/internal/requestctx"
+)
+
+// RequestID берет идентификатор запроса из X-Request-Id или генерирует новый,
+// возвращает его в ответе и кладет в контекст запроса
 func RequestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if requestID := r.Header.Get("X-Request-Id"); requestID != "" {
-			w.Header().Set("X-Request-Id", requestID)
+		requestID := r.Header.Get("X-Request-Id")
+		if requestID == "" {
+			requestID = uuid.NewString()
 		}
-		next.ServeHTTP(w, r)
+		w.Header().Set("X-Request-Id", requestID)
+		next.ServeHTTP(w, r.WithContext(requestctx.WithRequestID(r.Context(), requestID)))
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/http/handlers"
+	"RestApi/internal/requestctx"
+)
+
+// User кладет в контекст запроса пользователя из заголовка X-User-Id.
+// Используется при выключенной аутентификации и отмечает это в контексте: запросы
+// с заголовком видят только списки, в которых пользователь состоит, запросы без него
+// не ограничиваются.
+func User(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := requestctx.WithAuthDisabled(r.Context())
+		userID := strings.TrimSpace(r.Header.Get("X-User-Id"))
+		if userID == "" {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		if _, err := uuid.Parse(userID); err != nil {
+			handlers.WriteJSON(w, http.StatusBadRequest, handlers.ErrorResponse{
+				Code:    "VALIDATION_FAILED",
+				Message: "Invalid X-User-Id header",
+				Details: err.Error(),
+			})
+			return
+		}
+
+		principal := domain.Principal{
+			Type:   domain.PrincipalUser,
+			ID:     userID,
+			Scopes: []domain.APIKeyScope{domain.ScopeWrite},
+		}
+		next.ServeHTTP(w, r.WithContext(requestctx.WithPrincipal(ctx, principal)))
+	})
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser(t *testing.T) {
+	var principal domain.Principal
+	var authenticated, authDisabled bool
+	handler := User(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, authenticated = requestctx.Principal(r.Context())
+		authDisabled = requestctx.AuthDisabled(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("no header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, authenticated)
+		assert.True(t, authDisabled)
+	})
+
+	t.Run("user from header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+		r.Header.Set("X-User-Id", " 6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b ")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, authenticated)
+		assert.Equal(t, domain.PrincipalUser, principal.Type)
+		assert.Equal(t, "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b", principal.ID)
+		assert.True(t, authDisabled)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		authenticated = false
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+		r.Header.Set("X-User-Id", "alice")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.False(t, authenticated)
+	})
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/http/handlers"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+)
+
+// WorkspaceResolver получает рабочее пространство по ID
+type WorkspaceResolver interface {
+	GetByID(id string) (domain.Workspace, error)
+}
+
+// Workspace кладет в контекст рабочее пространство запроса из заголовка X-Workspace-Id.
+// Учетные данные работают ровно в одном рабочем пространстве: в том, к которому
+// привязаны, а без привязки — в рабочем пространстве по умолчанию. Заголовок можно
+// не передавать, заголовок с другим рабочим пространством отклоняется. Выбирать
+// рабочее пространство заголовком могут только учетные данные с правом admin без привязки
+// и запросы без субъекта (выключенная аутентификация и открытые пути).
+func Workspace(workspaces WorkspaceResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			workspaceID := strings.TrimSpace(r.Header.Get("X-Workspace-Id"))
+			if workspaceID != "" {
+				parsed, err := uuid.Parse(workspaceID)
+				if err != nil {
+					handlers.WriteJSON(w, http.StatusBadRequest, handlers.ErrorResponse{
+						Code:    "VALIDATION_FAILED",
+						Message: "Invalid X-Workspace-Id header",
+						Details: err.Error(),
+					})
+					return
+				}
+				workspaceID = parsed.String()
+			}
+
+			if principal, ok := requestctx.Principal(r.Context()); ok {
+				if bound, ok := credentialWorkspace(principal); ok {
+					if workspaceID != "" && workspaceID != bound {
+						handlers.WriteJSON(w, http.StatusForbidden, handlers.ErrorResponse{
+							Code:    "FORBIDDEN",
+							Message: "Credentials are bound to another workspace",
+							Details: "workspace: " + bound,
+						})
+						return
+					}
+					workspaceID = bound
+				}
+			}
+
+			// Рабочее пространство по умолчанию создается миграцией, его не нужно проверять
+			if workspaceID == "" {
+				workspaceID = domain.DefaultWorkspaceID
+			} else if workspaceID != domain.DefaultWorkspaceID {
+				if _, err := workspaces.GetByID(workspaceID); err != nil {
+					if errors.Is(err, postgres.ErrNotFound) {
+						handlers.WriteJSON(w, http.StatusNotFound, handlers.ErrorResponse{
+							Code:    "NOT_FOUND",
+							Message: "Workspace not found",
+							Details: err.Error(),
+						})
+						return
+					}
+					handlers.WriteJSON(w, http.StatusInternalServerError, handlers.ErrorResponse{
+						Code:    "INTERNAL_ERROR",
+						Message: "Failed to resolve workspace",
+						Details: err.Error(),
+					})
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(requestctx.WithWorkspace(r.Context(), workspaceID)))
+		})
+	}
+}
+
+// credentialWorkspace возвращает рабочее пространство, в котором работают учетные данные:
+// привязку или рабочее пространство по умолчанию. false — учетные данные с правом admin
+// без привязки, которым рабочее пространство задается заголовком
+func credentialWorkspace(principal domain.Principal) (string, bool) {
+	if principal.WorkspaceID != "" {
+		return principal.WorkspaceID, true
+	}
+	if principal.Allows(domain.ScopeAdmin) {
+		return "", false
+	}
+	return domain.DefaultWorkspaceID, true
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubWorkspaces знает рабочие пространства из карты
+type stubWorkspaces map[string]domain.Workspace
+
+func (s stubWorkspaces) GetByID(id string) (domain.Workspace, error) {
+	workspace, ok := s[id]
+	if !ok {
+		return domain.Workspace{}, postgres.ErrNotFound
+	}
+	return workspace, nil
+}
+
+func TestWorkspace(t *testing.T) {
+	const teamA = "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+	const teamB = "0a9b8c7d-6e5f-4a3b-8c1d-2e3f4a5b6c7d"
+	workspaces := stubWorkspaces{
+		teamA: {ID: teamA, Name: "Команда А"},
+		teamB: {ID: teamB, Name: "Команда Б"},
+	}
+
+	var workspaceID string
+	handler := Workspace(workspaces)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		workspaceID = requestctx.Workspace(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tc := range []struct {
+		name      string
+		header    string
+		principal *domain.Principal
+		status    int
+		workspace string
+	}{
+		{name: "no header", status: http.StatusOK, workspace: domain.DefaultWorkspaceID},
+		{name: "workspace from header", header: " " + teamA + " ", status: http.StatusOK, workspace: teamA},
+		{name: "invalid header", header: "team-a", status: http.StatusBadRequest},
+		{name: "unknown workspace", header: "11111111-2222-4333-8444-555555555555", status: http.StatusNotFound},
+		{name: "bound credentials", principal: &domain.Principal{ID: "key-1", WorkspaceID: teamB}, status: http.StatusOK, workspace: teamB},
+		{name: "bound credentials same header", header: teamB, principal: &domain.Principal{ID: "key-1", WorkspaceID: teamB}, status: http.StatusOK, workspace: teamB},
+		{name: "bound credentials other header", header: teamA, principal: &domain.Principal{ID: "key-1", WorkspaceID: teamB}, status: http.StatusForbidden},
+		{name: "unbound credentials", principal: &domain.Principal{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeWrite}}, status: http.StatusOK, workspace: domain.DefaultWorkspaceID},
+		{name: "unbound credentials default header", header: domain.DefaultWorkspaceID, principal: &domain.Principal{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeWrite}}, status: http.StatusOK, workspace: domain.DefaultWorkspaceID},
+		{name: "unbound credentials other header", header: teamA, principal: &domain.Principal{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeWrite}}, status: http.StatusForbidden},
+		{name: "unbound admin credentials", header: teamA, principal: &domain.Principal{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}}, status: http.StatusOK, workspace: teamA},
+		{name: "bound admin credentials other header", header: teamA, principal: &domain.Principal{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}, WorkspaceID: teamB}, status: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			workspaceID = ""
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+			if tc.header != "" {
+				r.Header.Set("X-Workspace-Id", tc.header)
+			}
+			if tc.principal != nil {
+				r = r.WithContext(requestctx.WithPrincipal(r.Context(), *tc.principal))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.workspace, workspaceID)
+		})
+	}
+}
@@ -16,7 +16,7 @@ type HTTPServer struct {
 	router *mux.Router
 }
 
-func NewHTTPServer(httpHandler *handlers.ListHandler, taskHandlers *handlers.TaskHandler) *HTTPServer {
+func NewHTTPServer(httpHandler *handlers.ListHandler, taskHandlers *handlers.TaskHandler, tagHandlers *handlers.TagHandler, trashHandlers *handlers.TrashHandler, historyHandlers *handlers.HistoryHandler, commentHandlers *handlers.CommentHandler, attachmentHandlers *handlers.AttachmentHandler, userHandlers *handlers.UserHandler, apiKeyHandlers *handlers.APIKeyHandler, authHandlers *handlers.AuthHandler, workspaceHandlers *handlers.WorkspaceHandler, searchHandlers *handlers.SearchHandler) *HTTPServer {
 	router := mux.NewRouter()
 	enableCORS(router)
 
@@ -41,12 +41,78 @@ func NewHTTPServer(httpHandler *handlers.ListHandler, taskHandlers *handlers.Tas
 	router.HandleFunc("/api/v1/lists/{id}", httpHandler.GetByID).Methods("GET")
 	router.HandleFunc("/api/v1/lists/{id}", httpHandler.Update).Methods("PATCH")
 	router.HandleFunc("/api/v1/lists/{id}", httpHandler.Delete).Methods("DELETE")
+	router.HandleFunc("/api/v1/lists/{id}/restore", httpHandler.Restore).Methods("POST")
+	router.HandleFunc("/api/v1/lists/{id}/archive", httpHandler.Archive).Methods("POST")
+	router.HandleFunc("/api/v1/lists/{id}/unarchive", httpHandler.Unarchive).Methods("POST")
+	router.HandleFunc("/api/v1/lists/{id}/stats", httpHandler.Stats).Methods("GET")
+	router.HandleFunc("/api/v1/lists/{id}/history", historyHandlers.ListHistory).Methods("GET")
+	router.HandleFunc("/api/v1/lists/{id}/members", httpHandler.ListMembers).Methods("GET")
+	router.HandleFunc("/api/v1/lists/{id}/members/{userID}", httpHandler.SetMember).Methods("PUT")
+	router.HandleFunc("/api/v1/lists/{id}/members/{userID}", httpHandler.RemoveMember).Methods("DELETE")
 
 	router.HandleFunc("/api/v1/lists/{listID}/tasks", taskHandlers.CreateTask).Methods("POST")
 	router.HandleFunc("/api/v1/lists/{listID}/tasks", taskHandlers.ListTasks).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/overdue", taskHandlers.ListOverdueTasks).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/workflow", taskHandlers.GetWorkflow).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/move", taskHandlers.MoveTasks).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/copy", taskHandlers.CopyTasks).Methods("POST")
 	router.HandleFunc("/api/v1/tasks/{taskID}", taskHandlers.GetTask).Methods("GET")
 	router.HandleFunc("/api/v1/tasks/{taskID}", taskHandlers.UpdateTask).Methods("PATCH")
 	router.HandleFunc("/api/v1/tasks/{taskID}", taskHandlers.DeleteTask).Methods("DELETE")
+	router.HandleFunc("/api/v1/tasks/{taskID}/subtasks", taskHandlers.ListSubtasks).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/dependencies", taskHandlers.ListBlockers).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/dependencies/{blockerID}", taskHandlers.AddDependency).Methods("PUT")
+	router.HandleFunc("/api/v1/tasks/{taskID}/dependencies/{blockerID}", taskHandlers.RemoveDependency).Methods("DELETE")
+	router.HandleFunc("/api/v1/tasks/{taskID}/move", taskHandlers.MoveTask).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/{taskID}/copy", taskHandlers.CopyTask).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/{taskID}/restore", taskHandlers.RestoreTask).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/{taskID}/history", historyHandlers.TaskHistory).Methods("GET")
+
+	router.HandleFunc("/api/v1/tasks/{taskID}/comments", commentHandlers.Create).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/{taskID}/comments", commentHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/comments/{commentID}", commentHandlers.Update).Methods("PATCH")
+	router.HandleFunc("/api/v1/tasks/{taskID}/comments/{commentID}", commentHandlers.Delete).Methods("DELETE")
+
+	router.HandleFunc("/api/v1/tasks/{taskID}/attachments", attachmentHandlers.Upload).Methods("POST")
+	router.HandleFunc("/api/v1/tasks/{taskID}/attachments", attachmentHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/attachments/{attachmentID}", attachmentHandlers.Get).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/attachments/{attachmentID}", attachmentHandlers.Delete).Methods("DELETE")
+	router.HandleFunc("/api/v1/tasks/{taskID}/attachments/{attachmentID}/content", attachmentHandlers.Download).Methods("GET")
+
+	router.HandleFunc("/api/v1/trash", trashHandlers.List).Methods("GET")
+
+	router.HandleFunc("/api/v1/search", searchHandlers.Search).Methods("GET")
+
+	router.HandleFunc("/api/v1/users", userHandlers.Create).Methods("POST")
+	router.HandleFunc("/api/v1/users", userHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/users/{id}", userHandlers.GetByID).Methods("GET")
+	router.HandleFunc("/api/v1/users/{id}", userHandlers.Update).Methods("PATCH")
+	router.HandleFunc("/api/v1/users/{id}", userHandlers.Delete).Methods("DELETE")
+	router.HandleFunc("/api/v1/users/{id}/tasks", taskHandlers.ListAssignedTasks).Methods("GET")
+
+	router.HandleFunc("/api/v1/auth/token", authHandlers.Token).Methods("POST")
+	router.HandleFunc("/api/v1/auth/refresh", authHandlers.Refresh).Methods("POST")
+	router.HandleFunc("/api/v1/auth/logout", authHandlers.Logout).Methods("POST")
+	router.HandleFunc("/api/v1/auth/me", authHandlers.Me).Methods("GET")
+
+	router.HandleFunc("/api/v1/api-keys", apiKeyHandlers.Create).Methods("POST")
+	router.HandleFunc("/api/v1/api-keys", apiKeyHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/api-keys/{id}", apiKeyHandlers.Revoke).Methods("DELETE")
+
+	router.HandleFunc("/api/v1/workspaces", workspaceHandlers.Create).Methods("POST")
+	router.HandleFunc("/api/v1/workspaces", workspaceHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/workspaces/{id}", workspaceHandlers.GetByID).Methods("GET")
+	router.HandleFunc("/api/v1/workspaces/{id}/members/{userID}", workspaceHandlers.AddMember).Methods("PUT")
+	router.HandleFunc("/api/v1/workspaces/{id}/members/{userID}", workspaceHandlers.RemoveMember).Methods("DELETE")
+
+	router.HandleFunc("/api/v1/tags", tagHandlers.Create).Methods("POST")
+	router.HandleFunc("/api/v1/tags", tagHandlers.List).Methods("GET")
+	router.HandleFunc("/api/v1/tags/{id}", tagHandlers.GetByID).Methods("GET")
+	router.HandleFunc("/api/v1/tags/{id}", tagHandlers.Update).Methods("PATCH")
+	router.HandleFunc("/api/v1/tags/{id}", tagHandlers.Delete).Methods("DELETE")
+	router.HandleFunc("/api/v1/tasks/{taskID}/tags", tagHandlers.ListTaskTags).Methods("GET")
+	router.HandleFunc("/api/v1/tasks/{taskID}/tags/{tagID}", tagHandlers.AttachToTask).Methods("PUT")
+	router.HandleFunc("/api/v1/tasks/{taskID}/tags/{tagID}", tagHandlers.DetachFromTask).Methods("DELETE")
 
 	return &HTTPServer{
 		router: router,
@@ -61,7 +127,7 @@ func enableCORS(router *mux.Router) {
 	router.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-Id, X-Actor, X-User-Id, X-Workspace-Id")
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -0,0 +1,245 @@
+// Package jwt выпускает и проверяет JSON Web Token в компактной форме
+// с подписью HS256 или RS256.
+//
+// Проверка принимает только алгоритм, заданный подписью: значение alg
+// из заголовка токена не может заменить его, поэтому токен, подписанный
+// открытым ключом RS256 как секретом HS256, будет отвергнут.
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("jwt: invalid token")
+	ErrExpiredToken = errors.New("jwt: token is expired")
+	ErrInvalidKey   = errors.New("jwt: invalid key")
+)
+
+// Алгоритмы подписи
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// Claims — утверждения токена. Время хранится в секундах Unix
+type Claims struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	ID        string `json:"jti,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	// Workspace — рабочее пространство, к которому привязан токен
+	Workspace string `json:"wid,omitempty"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// Signer подписывает и проверяет подпись токена одним алгоритмом
+type Signer interface {
+	Algorithm() string
+	Sign(data []byte) ([]byte, error)
+	Verify(data, signature []byte) error
+}
+
+type hmacSigner struct {
+	secret []byte
+}
+
+// NewHS256 возвращает подпись HMAC-SHA256 общим секретом
+func NewHS256(secret []byte) (Signer, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("%w: HS256 secret must be at least 32 bytes", ErrInvalidKey)
+	}
+	return hmacSigner{secret: secret}, nil
+}
+
+func (s hmacSigner) Algorithm() string {
+	return HS256
+}
+
+func (s hmacSigner) Sign(data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+func (s hmacSigner) Verify(data, signature []byte) error {
+	expected, _ := s.Sign(data)
+	if !hmac.Equal(expected, signature) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+type rsaSigner struct {
+	private *rsa.PrivateKey
+	public  *rsa.PublicKey
+}
+
+// NewRS256 возвращает подпись RSASSA-PKCS1-v1_5 с SHA-256.
+// Без закрытого ключа подпись умеет только проверять токены.
+func NewRS256(private *rsa.PrivateKey, public *rsa.PublicKey) (Signer, error) {
+	if public == nil && private != nil {
+		public = &private.PublicKey
+	}
+	if public == nil {
+		return nil, fmt.Errorf("%w: RS256 requires a public or private key", ErrInvalidKey)
+	}
+	if public.N.BitLen() < 2048 {
+		return nil, fmt.Errorf("%w: RS256 key must be at least 2048 bits", ErrInvalidKey)
+	}
+	return rsaSigner{private: private, public: public}, nil
+}
+
+func (s rsaSigner) Algorithm() string {
+	return RS256
+}
+
+func (s rsaSigner) Sign(data []byte) ([]byte, error) {
+	if s.private == nil {
+		return nil, fmt.Errorf("%w: private key is not configured", ErrInvalidKey)
+	}
+	digest := sha256.Sum256(data)
+	return rsa.SignPKCS1v15(nil, s.private, crypto.SHA256, digest[:])
+}
+
+func (s rsaSigner) Verify(data, signature []byte) error {
+	digest := sha256.Sum256(data)
+	if err := rsa.VerifyPKCS1v15(s.public, crypto.SHA256, digest[:], signature); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// ParseRSAPrivateKey читает закрытый ключ RSA в PEM (PKCS #1 или PKCS #8)
+func ParseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: PEM block not found", ErrInvalidKey)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: not an RSA key", ErrInvalidKey)
+	}
+	return rsaKey, nil
+}
+
+// ParseRSAPublicKey читает открытый ключ RSA в PEM (PKIX или PKCS #1)
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: PEM block not found", ErrInvalidKey)
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: not an RSA key", ErrInvalidKey)
+	}
+	return rsaKey, nil
+}
+
+// Encode подписывает утверждения и возвращает токен
+func Encode(signer Signer, claims Claims) (string, error) {
+	headerJSON, err := json.Marshal(header{Algorithm: signer.Algorithm(), Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+	signature, err := signer.Sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// Decode проверяет подпись и срок действия токена на момент now
+// и возвращает его утверждения. Токен без exp не принимается.
+func Decode(signer Signer, token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeJSONSegment(parts[0], &h); err != nil {
+		return Claims{}, err
+	}
+	if h.Algorithm != signer.Algorithm() {
+		return Claims{}, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, h.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := signer.Verify([]byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return Claims{}, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeJSONSegment(parts[1], &claims); err != nil {
+		return Claims{}, err
+	}
+	if claims.ExpiresAt == 0 {
+		return Claims{}, fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrExpiredToken
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return Claims{}, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJSONSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncodeDecode(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	claims := Claims{
+		Issuer:    "todo-api",
+		Subject:   "user-1",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(15 * time.Minute).Unix(),
+		Name:      "Алиса",
+		Scope:     "write",
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	hs, err := NewHS256(secret)
+	require.NoError(t, err)
+	rs, err := NewRS256(rsaKey, nil)
+	require.NoError(t, err)
+
+	for _, signer := range []Signer{hs, rs} {
+		t.Run(signer.Algorithm(), func(t *testing.T) {
+			token, err := Encode(signer, claims)
+			require.NoError(t, err)
+			assert.Equal(t, 2, strings.Count(token, "."))
+
+			decoded, err := Decode(signer, token, now)
+			require.NoError(t, err)
+			assert.Equal(t, claims, decoded)
+
+			_, err = Decode(signer, token, now.Add(15*time.Minute))
+			assert.ErrorIs(t, err, ErrExpiredToken)
+
+			// Подмена утверждений ломает подпись
+			parts := strings.Split(token, ".")
+			forged, err := Encode(signer, Claims{Subject: "admin", ExpiresAt: claims.ExpiresAt})
+			require.NoError(t, err)
+			_, err = Decode(signer, parts[0]+"."+strings.Split(forged, ".")[1]+"."+parts[2], now)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+
+	t.Run("verify only RS256", func(t *testing.T) {
+		token, err := Encode(rs, claims)
+		require.NoError(t, err)
+
+		verifier, err := NewRS256(nil, &rsaKey.PublicKey)
+		require.NoError(t, err)
+		_, err = Decode(verifier, token, now)
+		assert.NoError(t, err)
+
+		_, err = Encode(verifier, claims)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("algorithm from header is not trusted", func(t *testing.T) {
+		// Токен HS256, подписанный открытым ключом RS256 как секретом
+		publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPKIX(t, &rsaKey.PublicKey)})
+		confused, err := NewHS256(publicPEM)
+		require.NoError(t, err)
+		token, err := Encode(confused, claims)
+		require.NoError(t, err)
+
+		_, err = Decode(rs, token, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+
+		_, err = Decode(rs, token[:strings.Index(token, ".")]+".e30.", now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("exp is required", func(t *testing.T) {
+		token, err := Encode(hs, Claims{Subject: "user-1"})
+		require.NoError(t, err)
+		_, err = Decode(hs, token, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{"", "a.b", "a.b.c", "e30.e30.!!"} {
+			_, err := Decode(hs, token, now)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
+
+func TestKeys(t *testing.T) {
+	_, err := NewHS256([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewRS256(nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	parsed, err := ParseRSAPrivateKey(pkcs1)
+	require.NoError(t, err)
+	assert.True(t, rsaKey.Equal(parsed))
+
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+	parsed, err = ParseRSAPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes}))
+	require.NoError(t, err)
+	assert.True(t, rsaKey.Equal(parsed))
+
+	public, err := ParseRSAPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: mustMarshalPKIX(t, &rsaKey.PublicKey)}))
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(public))
+
+	_, err = ParseRSAPrivateKey([]byte("not a pem"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func mustMarshalPKIX(t *testing.T, key *rsa.PublicKey) []byte {
+	t.Helper()
+	data, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,106 @@
+// Package rank генерирует лексикографические ключи для ручной сортировки.
+//
+// Ключ — строка из цифр и строчных латинских букв. Между любыми двумя
+// ключами всегда можно вставить третий, поэтому перемещение элемента
+// меняет только его собственный ключ, а не ключи соседей.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidKey   = errors.New("rank: invalid key")
+	ErrInvalidRange = errors.New("rank: lower key must be less than upper key")
+)
+
+// Between возвращает ключ, лежащий строго между lower и upper.
+// Пустой lower означает начало последовательности, пустой upper — конец.
+func Between(lower, upper string) (string, error) {
+	if err := validate(lower); err != nil {
+		return "", err
+	}
+	if err := validate(upper); err != nil {
+		return "", err
+	}
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidRange
+	}
+	return midpoint(lower, upper), nil
+}
+
+// After возвращает ключ, следующий за key (или первый ключ, если key пустой)
+func After(key string) (string, error) {
+	return Between(key, "")
+}
+
+// validate проверяет алфавит ключа. Ключ не может оканчиваться на минимальную
+// цифру: иначе между ним и его префиксом не останется места.
+func validate(key string) error {
+	if key == "" {
+		return nil
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return ErrInvalidKey
+		}
+	}
+	if key[len(key)-1] == digits[0] {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// midpoint находит ключ между a и b, считая a дополненным справа нулями.
+// Пустой b означает отсутствие верхней границы.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Пропускаем общий префикс
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB)/2])
+	}
+
+	// Первые цифры соседние: если у b есть продолжение, его первая цифра
+	// уже больше a и меньше b
+	if b != "" && len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	return string(digits[digitA]) + midpoint(rest, "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
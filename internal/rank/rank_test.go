@@ -0,0 +1,80 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		lower, upper string
+		want         string
+	}{
+		{"empty sequence", "", "", "i"},
+		{"append to end", "i", "", "r"},
+		{"prepend to start", "", "i", "9"},
+		{"adjacent digits", "a", "b", "ai"},
+		{"common prefix", "0000000001i", "0000000002i", "0000000002"},
+		{"upper is longer", "a", "a5", "a2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.lower, tt.upper)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Greater(t, got, tt.lower)
+			if tt.upper != "" {
+				assert.Less(t, got, tt.upper)
+			}
+		})
+	}
+}
+
+func TestBetween_Errors(t *testing.T) {
+	_, err := Between("b", "a")
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = Between("a", "a")
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = Between("A", "")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = Between("a0", "")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestBetween_RandomInsertsKeepOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	keys := []string{}
+
+	for i := 0; i < 500; i++ {
+		// Вставляем новый ключ в случайное место упорядоченной последовательности
+		pos := rnd.Intn(len(keys) + 1)
+		lower, upper := "", ""
+		if pos > 0 {
+			lower = keys[pos-1]
+		}
+		if pos < len(keys) {
+			upper = keys[pos]
+		}
+
+		key, err := Between(lower, upper)
+		require.NoError(t, err)
+		require.Greater(t, key, lower)
+		if upper != "" {
+			require.Less(t, key, upper)
+		}
+
+		keys = append(keys, "")
+		copy(keys[pos+1:], keys[pos:])
+		keys[pos] = key
+		require.True(t, sort.StringsAreSorted(keys))
+	}
+}
@@ -0,0 +1,99 @@
+// Package requestctx хранит в контексте сведения о текущем запросе:
+// идентификатор запроса, автора изменений, аутентифицированного субъекта
+// и рабочее пространство.
+package requestctx
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	actorKey
+	principalKey
+	workspaceKey
+	authDisabledKey
+)
+
+// WithRequestID возвращает контекст с идентификатором запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID возвращает идентификатор запроса или пустую строку
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithActor возвращает контекст с автором изменений
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor возвращает автора изменений или пустую строку
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// WithPrincipal возвращает контекст с аутентифицированным субъектом запроса
+func WithPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// Principal возвращает субъекта запроса; false — запрос не аутентифицирован
+func Principal(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(domain.Principal)
+	return principal, ok
+}
+
+// UserID возвращает ID пользователя, от имени которого выполняется запрос: вошедшего
+// пользователя или пользователя API-ключа. Пустая строка — ключ admin без пользователя
+// или запрос без субъекта
+func UserID(ctx context.Context) string {
+	principal, ok := Principal(ctx)
+	if !ok {
+		return ""
+	}
+	if principal.Type == domain.PrincipalUser {
+		return principal.ID
+	}
+	return principal.UserID
+}
+
+// WithAuthDisabled отмечает запрос, выполняемый при выключенной аутентификации
+func WithAuthDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authDisabledKey, true)
+}
+
+// AuthDisabled сообщает, что запрос выполняется при выключенной аутентификации:
+// только такие запросы без пользователя не ограничиваются ролями в списках
+func AuthDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(authDisabledKey).(bool)
+	return disabled
+}
+
+// WithWorkspace возвращает контекст с рабочим пространством запроса
+func WithWorkspace(ctx context.Context, workspaceID string) context.Context {
+	return context.WithValue(ctx, workspaceKey, workspaceID)
+}
+
+// Workspace возвращает рабочее пространство запроса или рабочее пространство по умолчанию
+func Workspace(ctx context.Context) string {
+	if workspaceID, ok := LookupWorkspace(ctx); ok {
+		return workspaceID
+	}
+	return domain.DefaultWorkspaceID
+}
+
+// LookupWorkspace возвращает рабочее пространство, явно заданное в контексте;
+// false — контекст фоновой задачи, не привязанной к рабочему пространству
+func LookupWorkspace(ctx context.Context) (string, bool) {
+	workspaceID, ok := ctx.Value(workspaceKey).(string)
+	return workspaceID, ok && workspaceID != ""
+}
@@ -0,0 +1,320 @@
+// Package rrule разбирает правила повторения задач и вычисляет следующую дату.
+//
+// Поддерживается подмножество RRULE из RFC 5545: FREQ (DAILY, WEEKLY,
+// MONTHLY, YEARLY), INTERVAL, BYDAY (только для WEEKLY), BYMONTHDAY
+// (только для MONTHLY), COUNT и UNTIL. Вместо полного правила можно
+// передать сокращение: daily, weekly, monthly или yearly.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("rrule: invalid rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxSkips ограничивает поиск даты, когда правило пропускает периоды
+// (например, 31-е число в коротких месяцах или 29 февраля)
+const maxSkips = 1000
+
+const (
+	untilLayout     = "20060102T150405Z"
+	untilDateLayout = "20060102"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule — разобранное правило повторения
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	// Count — сколько повторений осталось, включая текущее; 0 — без ограничения
+	Count int
+	Until *time.Time
+}
+
+// Parse разбирает правило в формате RRULE или сокращение
+func Parse(value string) (Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	switch Frequency(value) {
+	case Daily, Weekly, Monthly, Yearly:
+		return Rule{Freq: Frequency(value), Interval: 1}, nil
+	}
+
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return Rule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(val)
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = fmt.Errorf("unsupported FREQ %s", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(val)
+		case "COUNT":
+			rule.Count, err = parsePositive(val)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(val)
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	case rule.Count > 0 && rule.Until != nil:
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	case len(rule.ByDay) > 0 && rule.Freq != Weekly:
+		return Rule{}, fmt.Errorf("%w: BYDAY is supported only with FREQ=WEEKLY", ErrInvalidRule)
+	case len(rule.ByMonthDay) > 0 && rule.Freq != Monthly:
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY is supported only with FREQ=MONTHLY", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// String возвращает правило в каноничном виде RRULE без префикса
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			codes = append(codes, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next возвращает дату следующего повторения после from и правило для него.
+// ok=false — повторения закончились (исчерпан COUNT или пройден UNTIL).
+func (r Rule) Next(from time.Time) (next time.Time, rest Rule, ok bool) {
+	if r.Count == 1 {
+		return time.Time{}, Rule{}, false
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Freq {
+	case Daily:
+		next, ok = from.AddDate(0, 0, interval), true
+	case Weekly:
+		next, ok = nextWeekly(from, interval, r.ByDay), true
+	case Monthly:
+		next, ok = nextMonthly(from, interval, r.ByMonthDay)
+	case Yearly:
+		next, ok = nextYearly(from, interval)
+	}
+	if !ok || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, Rule{}, false
+	}
+
+	rest = r
+	if rest.Count > 0 {
+		rest.Count--
+	}
+	return next, rest, true
+}
+
+// nextWeekly ищет ближайший день из days в текущей неделе (неделя начинается
+// с понедельника), иначе — первый такой день через interval недель
+func nextWeekly(from time.Time, interval int, days []time.Weekday) time.Time {
+	if len(days) == 0 {
+		return from.AddDate(0, 0, 7*interval)
+	}
+
+	offset := weekOffset(from.Weekday())
+	for _, day := range days {
+		if weekOffset(day) > offset {
+			return from.AddDate(0, 0, weekOffset(day)-offset)
+		}
+	}
+
+	weekStart := from.AddDate(0, 0, -offset+7*interval)
+	return weekStart.AddDate(0, 0, weekOffset(days[0]))
+}
+
+// nextMonthly ищет ближайшее подходящее число месяца, пропуская месяцы,
+// в которых такого числа нет
+func nextMonthly(from time.Time, interval int, monthDays []int) (time.Time, bool) {
+	if len(monthDays) == 0 {
+		monthDays = []int{from.Day()}
+	}
+
+	year, month, _ := from.Date()
+	for i := 0; i <= maxSkips; i++ {
+		first := time.Date(year, month+time.Month(i*interval), 1, 0, 0, 0, 0, from.Location())
+		days := resolveMonthDays(first, monthDays)
+		for _, day := range days {
+			candidate := time.Date(first.Year(), first.Month(), day, from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location())
+			if candidate.After(from) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextYearly переносит дату на interval лет, пропуская годы без 29 февраля
+func nextYearly(from time.Time, interval int) (time.Time, bool) {
+	for i := 1; i <= maxSkips; i++ {
+		year := from.Year() + i*interval
+		if from.Day() > daysIn(year, from.Month()) {
+			continue
+		}
+		return time.Date(year, from.Month(), from.Day(), from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// resolveMonthDays переводит BYMONTHDAY (включая отрицательные значения)
+// в существующие числа месяца по возрастанию
+func resolveMonthDays(first time.Time, monthDays []int) []int {
+	total := daysIn(first.Year(), first.Month())
+	days := make([]int, 0, len(monthDays))
+	for _, day := range monthDays {
+		if day < 0 {
+			day = total + day + 1
+		}
+		if day >= 1 && day <= total {
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	return days
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekOffset возвращает номер дня в неделе, начинающейся с понедельника
+func weekOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("expected positive integer, got %s", value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(untilLayout, value); err == nil {
+		return until, nil
+	}
+	until, err := time.Parse(untilDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %s", value)
+	}
+	// Дата без времени включает весь день
+	return until.Add(24*time.Hour - time.Second), nil
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool)
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		day, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY %s", code)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return weekOffset(days[i]) < weekOffset(days[j])
+	})
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	seen := make(map[int]bool)
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(part)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("invalid BYMONTHDAY %s", part)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+	return days, nil
+}
@@ -0,0 +1,113 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"shorthand", "weekly", "FREQ=WEEKLY"},
+		{"prefix and lowercase", "rrule:freq=daily;interval=1", "FREQ=DAILY"},
+		{"interval", "FREQ=DAILY;INTERVAL=3", "FREQ=DAILY;INTERVAL=3"},
+		{"byday sorted from monday", "FREQ=WEEKLY;BYDAY=FR,MO,FR", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"bymonthday", "FREQ=MONTHLY;BYMONTHDAY=-1,15", "FREQ=MONTHLY;BYMONTHDAY=-1,15"},
+		{"count", "FREQ=YEARLY;COUNT=3", "FREQ=YEARLY;COUNT=3"},
+		{"until date", "FREQ=DAILY;UNTIL=20250301", "FREQ=DAILY;UNTIL=20250301T235959Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"hourly",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		_, err := Parse(value)
+		assert.ErrorIs(t, err, ErrInvalidRule, value)
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		want time.Time
+	}{
+		{"daily", "FREQ=DAILY", date(2025, 1, 31), date(2025, 2, 1)},
+		{"every other day", "FREQ=DAILY;INTERVAL=2", date(2025, 1, 1), date(2025, 1, 3)},
+		{"weekly", "FREQ=WEEKLY", date(2025, 1, 1), date(2025, 1, 8)},
+		// 2025-01-01 — среда
+		{"byday later this week", "FREQ=WEEKLY;BYDAY=MO,FR", date(2025, 1, 1), date(2025, 1, 3)},
+		{"byday next week", "FREQ=WEEKLY;BYDAY=MO,FR", date(2025, 1, 3), date(2025, 1, 6)},
+		{"byday every other week", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", date(2025, 1, 3), date(2025, 1, 13)},
+		{"monthly", "FREQ=MONTHLY", date(2025, 1, 15), date(2025, 2, 15)},
+		{"monthly skips short months", "FREQ=MONTHLY", date(2025, 1, 31), date(2025, 3, 31)},
+		{"last day of month", "FREQ=MONTHLY;BYMONTHDAY=-1", date(2025, 1, 31), date(2025, 2, 28)},
+		{"bymonthday later this month", "FREQ=MONTHLY;BYMONTHDAY=1,15", date(2025, 1, 10), date(2025, 1, 15)},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", date(2025, 1, 1), date(2025, 4, 1)},
+		{"yearly", "FREQ=YEARLY", date(2025, 3, 1), date(2026, 3, 1)},
+		{"leap day", "FREQ=YEARLY", date(2024, 2, 29), date(2028, 2, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			next, _, ok := rule.Next(tt.from)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestRule_NextLimits(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;COUNT=2")
+	require.NoError(t, err)
+
+	next, rest, ok := rule.Next(date(2025, 1, 1))
+	require.True(t, ok)
+	assert.Equal(t, date(2025, 1, 2), next)
+	assert.Equal(t, "FREQ=DAILY;COUNT=1", rest.String())
+
+	_, _, ok = rest.Next(next)
+	assert.False(t, ok)
+
+	rule, err = Parse("FREQ=WEEKLY;UNTIL=20250110")
+	require.NoError(t, err)
+
+	_, _, ok = rule.Next(date(2025, 1, 1))
+	assert.True(t, ok)
+	_, _, ok = rule.Next(date(2025, 1, 8))
+	assert.False(t, ok)
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnauthorized — ключ, токен или пароль не переданы, неверны, отозваны или истекли
+var ErrUnauthorized = errors.New("UNAUTHORIZED")
+
+// MaxAPIKeyNameLength — максимальная длина названия API-ключа в символах
+const MaxAPIKeyNameLength = 100
+
+const (
+	// apiKeyMarker начинает каждый выпущенный ключ, чтобы его было легко опознать
+	apiKeyMarker = "tk_"
+	// apiKeyPrefixLength — сколько первых символов ключа хранится открыто
+	apiKeyPrefixLength = len(apiKeyMarker) + 8
+	// tokenSecretBytes — количество случайных байт ключа или токена обновления
+	tokenSecretBytes = 32
+)
+
+// BootstrapAPIKeyID — ID ключа администратора из конфигурации.
+// Такой ключ не хранится в БД и нужен, чтобы выпустить первые ключи.
+const BootstrapAPIKeyID = "bootstrap"
+
+type APIKeyService struct {
+	repo storage.APIKeyRepository
+	// users — пользователи, от имени которых действуют ключи
+	users storage.UserRepository
+	// bootstrapHash — хэш ключа администратора из конфигурации; пустой, если ключ не задан
+	bootstrapHash string
+	// now — источник текущего времени, подменяется в тестах
+	now func() time.Time
+}
+
+func NewAPIKeyService(repo storage.APIKeyRepository, users storage.UserRepository) *APIKeyService {
+	return &APIKeyService{
+		repo:  repo,
+		users: users,
+		now:   time.Now,
+	}
+}
+
+// WithBootstrapKey разрешает ключ администратора, заданный в конфигурации
+func (s *APIKeyService) WithBootstrapKey(key string) *APIKeyService {
+	s.bootstrapHash = ""
+	if key != "" {
+		s.bootstrapHash = hashToken(key)
+	}
+	return s
+}
+
+// Create выпускает ключ. Значение ключа возвращается только здесь,
+// в БД попадает лишь его хэш. Ключ или сессия, привязанные к рабочему пространству,
+// выпускают ключи только в нем: другое рабочее пространство дает ErrForbidden.
+// Ключ без права admin действует от имени пользователя, состоящего в рабочем пространстве ключа
+func (s *APIKeyService) Create(ctx context.Context, request domain.CreateAPIKeyRequest) (domain.CreatedAPIKey, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || utf8.RuneCountInString(name) > MaxAPIKeyNameLength {
+		return domain.CreatedAPIKey{}, fmt.Errorf("%w: name must be 1..%d chars", ErrValidation, MaxAPIKeyNameLength)
+	}
+
+	scopes, err := normalizeScopes(request.Scopes)
+	if err != nil {
+		return domain.CreatedAPIKey{}, err
+	}
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(s.now()) {
+		return domain.CreatedAPIKey{}, fmt.Errorf("%w: expires_at must be in the future", ErrValidation)
+	}
+
+	workspaceID, err := normalizeWorkspaceID(request.WorkspaceID)
+	if err != nil {
+		return domain.CreatedAPIKey{}, err
+	}
+	if principal, ok := requestctx.Principal(ctx); ok && principal.WorkspaceID != "" {
+		if workspaceID == "" {
+			workspaceID = principal.WorkspaceID
+		} else if workspaceID != principal.WorkspaceID {
+			return domain.CreatedAPIKey{}, fmt.Errorf("%w: key is bound to workspace %s", ErrForbidden, principal.WorkspaceID)
+		}
+	}
+
+	userID, err := s.keyUser(ctx, request.UserID, scopes, workspaceID)
+	if err != nil {
+		return domain.CreatedAPIKey{}, err
+	}
+
+	key, err := generateToken(apiKeyMarker)
+	if err != nil {
+		return domain.CreatedAPIKey{}, err
+	}
+
+	created, err := s.repo.Create(ctx, domain.APIKey{
+		Name:        name,
+		Prefix:      key[:apiKeyPrefixLength],
+		Scopes:      scopes,
+		ExpiresAt:   request.ExpiresAt,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	}, hashToken(key))
+	if errors.Is(err, postgres.ErrNotFound) {
+		return domain.CreatedAPIKey{}, fmt.Errorf("%w: workspace or user not found", ErrValidation)
+	}
+	if err != nil {
+		return domain.CreatedAPIKey{}, err
+	}
+
+	return domain.CreatedAPIKey{APIKey: created, Key: key}, nil
+}
+
+// keyUser проверяет пользователя, от имени которого будет действовать ключ.
+// Без пользователя можно выпустить только ключ с правом admin: его запросы
+// не ограничиваются ролями в списках. Пользователь должен состоять в рабочем
+// пространстве ключа; ключ без привязки работает в рабочем пространстве по умолчанию
+func (s *APIKeyService) keyUser(ctx context.Context, userID string, scopes []domain.APIKeyScope, workspaceID string) (string, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		if !(domain.Principal{Scopes: scopes}).Allows(domain.ScopeAdmin) {
+			return "", fmt.Errorf("%w: user_id is required for keys without admin scope", ErrValidation)
+		}
+		return "", nil
+	}
+	parsed, err := uuid.Parse(userID)
+	if err != nil {
+		return "", fmt.Errorf("%w: user_id must be a UUID", ErrValidation)
+	}
+
+	if workspaceID == "" {
+		workspaceID = domain.DefaultWorkspaceID
+	}
+	if _, err := s.users.GetByID(requestctx.WithWorkspace(ctx, workspaceID), parsed.String()); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return "", fmt.Errorf("%w: user %s is not a member of the key workspace", ErrValidation, parsed)
+		}
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// List получает ключи рабочего пространства запроса; ключи без привязки
+// относятся к рабочему пространству по умолчанию
+func (s *APIKeyService) List(ctx context.Context, limit, offset int) ([]domain.APIKey, int, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+// Revoke отзывает ключ рабочего пространства запроса; отозванный ключ остается в списке ключей
+func (s *APIKeyService) Revoke(ctx context.Context, id string) error {
+	if id == BootstrapAPIKeyID {
+		return fmt.Errorf("%w: bootstrap key is configured by ADMIN_API_KEY and cannot be revoked", ErrValidation)
+	}
+	return s.repo.Revoke(ctx, id)
+}
+
+// Authenticate проверяет ключ и возвращает его описание.
+// Неизвестный, отозванный или истекший ключ дает ErrUnauthorized.
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (domain.APIKey, error) {
+	if key == "" {
+		return domain.APIKey{}, fmt.Errorf("%w: api key is required", ErrUnauthorized)
+	}
+
+	keyHash := hashToken(key)
+	if s.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(keyHash), []byte(s.bootstrapHash)) == 1 {
+		return domain.APIKey{
+			ID:     BootstrapAPIKeyID,
+			Name:   BootstrapAPIKeyID,
+			Scopes: []domain.APIKeyScope{domain.ScopeAdmin},
+		}, nil
+	}
+
+	apiKey, err := s.repo.GetByHash(ctx, keyHash)
+	if errors.Is(err, postgres.ErrNotFound) {
+		return domain.APIKey{}, fmt.Errorf("%w: api key is invalid", ErrUnauthorized)
+	}
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	if !apiKey.Active(s.now()) {
+		return domain.APIKey{}, fmt.Errorf("%w: api key is revoked or expired", ErrUnauthorized)
+	}
+
+	// Отметка об использовании не должна мешать запросу
+	if err := s.repo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		log.Printf("API key %s last use update failed: %v", apiKey.ID, err)
+	}
+
+	return apiKey, nil
+}
+
+// normalizeScopes проверяет права и убирает повторы, сохраняя порядок
+func normalizeScopes(scopes []domain.APIKeyScope) ([]domain.APIKeyScope, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrValidation)
+	}
+
+	normalized := make([]domain.APIKeyScope, 0, len(scopes))
+	seen := make(map[domain.APIKeyScope]bool, len(scopes))
+	for _, scope := range scopes {
+		if !scope.Valid() {
+			return nil, fmt.Errorf("%w: unknown scope %q, allowed: read, write, admin", ErrValidation, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// generateToken создает секрет из tokenSecretBytes случайных байт с меткой marker
+func generateToken(marker string) (string, error) {
+	secret := make([]byte, tokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return marker + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashToken возвращает SHA-256 секрета в hex. Секрет случайный и длинный,
+// поэтому медленный хэш, как для паролей, не нужен
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
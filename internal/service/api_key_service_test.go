@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock для APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key domain.APIKey, keyHash string) (domain.APIKey, error) {
+	args := m.Called(key, keyHash)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	args := m.Called(keyHash)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, limit, offset int) ([]domain.APIKey, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]domain.APIKey), args.Int(1), args.Error(2)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// keyUserID — пользователь, от имени которого выпускаются ключи в тестах
+const keyUserID = "0b6e5f4d-3c2b-4a19-8e7d-6c5b4a392817"
+
+// keyUsers возвращает пользователей, в которых есть только keyUserID
+func keyUsers() *MockUserRepository {
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", keyUserID).Return(domain.User{ID: keyUserID}, nil).Maybe()
+	return userRepo
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	t.Run("key is returned once and stored hashed", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		var storedHash string
+		apiKeyRepo.On("Create", mock.MatchedBy(func(key domain.APIKey) bool {
+			return key.Name == "CI" && key.UserID == keyUserID &&
+				assert.ObjectsAreEqual([]domain.APIKeyScope{domain.ScopeRead, domain.ScopeWrite}, key.Scopes)
+		}), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { storedHash = args.String(1) }).
+			Return(domain.APIKey{ID: "key-1", Name: "CI", Prefix: "tk_abcdefgh"}, nil)
+
+		created, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{
+			Name:   " CI ",
+			Scopes: []domain.APIKeyScope{domain.ScopeRead, domain.ScopeWrite, domain.ScopeRead},
+			UserID: keyUserID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", created.ID)
+		assert.True(t, strings.HasPrefix(created.Key, "tk_"))
+		assert.Equal(t, hashToken(created.Key), storedHash)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("key bound to workspace", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		workspaceID := "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		apiKeyRepo.On("Create", mock.MatchedBy(func(key domain.APIKey) bool {
+			return key.WorkspaceID == workspaceID
+		}), mock.AnythingOfType("string")).Return(domain.APIKey{ID: "key-1", WorkspaceID: workspaceID}, nil)
+
+		created, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{
+			Name:        "CI",
+			Scopes:      []domain.APIKeyScope{domain.ScopeRead},
+			WorkspaceID: workspaceID,
+			UserID:      keyUserID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, workspaceID, created.Principal().WorkspaceID)
+	})
+
+	t.Run("caller bound to workspace", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		workspaceID := "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		ctx := requestctx.WithPrincipal(context.Background(), domain.Principal{
+			Type:        domain.PrincipalAPIKey,
+			ID:          "key-0",
+			Scopes:      []domain.APIKeyScope{domain.ScopeAdmin},
+			WorkspaceID: workspaceID,
+		})
+
+		_, err := service.Create(ctx, domain.CreateAPIKeyRequest{
+			Name:        "CI",
+			Scopes:      []domain.APIKeyScope{domain.ScopeRead},
+			WorkspaceID: "00000000-0000-0000-0000-000000000000",
+			UserID:      keyUserID,
+		})
+		assert.ErrorIs(t, err, ErrForbidden)
+		apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+		// Без workspace_id ключ остается в рабочем пространстве вызывающего
+		apiKeyRepo.On("Create", mock.MatchedBy(func(key domain.APIKey) bool {
+			return key.WorkspaceID == workspaceID
+		}), mock.AnythingOfType("string")).Return(domain.APIKey{ID: "key-1", WorkspaceID: workspaceID}, nil)
+
+		_, err = service.Create(ctx, domain.CreateAPIKeyRequest{Name: "CI", Scopes: []domain.APIKeyScope{domain.ScopeRead}, UserID: keyUserID})
+		require.NoError(t, err)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		apiKeyRepo.On("Create", mock.Anything, mock.Anything).Return(domain.APIKey{}, postgres.ErrNotFound)
+
+		_, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{
+			Name:        "CI",
+			Scopes:      []domain.APIKeyScope{domain.ScopeRead},
+			WorkspaceID: "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b",
+			UserID:      keyUserID,
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("admin key without user", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		apiKeyRepo.On("Create", mock.MatchedBy(func(key domain.APIKey) bool {
+			return key.UserID == ""
+		}), mock.AnythingOfType("string")).Return(domain.APIKey{ID: "key-1"}, nil)
+
+		_, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{Name: "Админ", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}})
+		require.NoError(t, err)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("user outside key workspace", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		userRepo := new(MockUserRepository)
+		service := NewAPIKeyService(apiKeyRepo, userRepo)
+
+		userRepo.On("GetByID", keyUserID).Return(domain.User{}, postgres.ErrNotFound)
+
+		_, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{
+			Name:        "CI",
+			Scopes:      []domain.APIKeyScope{domain.ScopeWrite},
+			WorkspaceID: "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b",
+			UserID:      keyUserID,
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+		apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	past := time.Now().Add(-time.Hour)
+	for name, request := range map[string]domain.CreateAPIKeyRequest{
+		"empty name":    {Name: " ", Scopes: []domain.APIKeyScope{domain.ScopeRead}},
+		"long name":     {Name: strings.Repeat("я", MaxAPIKeyNameLength+1), Scopes: []domain.APIKeyScope{domain.ScopeRead}},
+		"no scopes":     {Name: "CI"},
+		"unknown scope": {Name: "CI", Scopes: []domain.APIKeyScope{"root"}},
+		"expired":       {Name: "CI", Scopes: []domain.APIKeyScope{domain.ScopeRead}, ExpiresAt: &past},
+		"bad workspace": {Name: "CI", Scopes: []domain.APIKeyScope{domain.ScopeRead}, WorkspaceID: "team-a", UserID: keyUserID},
+		"no user":       {Name: "CI", Scopes: []domain.APIKeyScope{domain.ScopeWrite}},
+		"bad user":      {Name: "CI", Scopes: []domain.APIKeyScope{domain.ScopeWrite}, UserID: "alice"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			apiKeyRepo := new(MockAPIKeyRepository)
+			service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+			_, err := service.Create(context.Background(), request)
+			assert.ErrorIs(t, err, ErrValidation)
+			apiKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	t.Run("active key", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+		service.now = func() time.Time { return now }
+
+		apiKeyRepo.On("GetByHash", hashToken("tk_secret")).
+			Return(domain.APIKey{ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeRead}}, nil)
+		apiKeyRepo.On("TouchLastUsed", "key-1").Return(errors.New("db is down"))
+
+		key, err := service.Authenticate(context.Background(), "tk_secret")
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", key.ID)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("bootstrap key", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers()).WithBootstrapKey("admin-secret")
+
+		key, err := service.Authenticate(context.Background(), "admin-secret")
+		require.NoError(t, err)
+		assert.Equal(t, BootstrapAPIKeyID, key.ID)
+		assert.True(t, key.Allows(domain.ScopeAdmin))
+		apiKeyRepo.AssertNotCalled(t, "GetByHash", mock.Anything)
+	})
+
+	for name, stored := range map[string]domain.APIKey{
+		"revoked": {ID: "key-1", RevokedAt: &past},
+		"expired": {ID: "key-1", ExpiresAt: &past},
+	} {
+		t.Run(name, func(t *testing.T) {
+			apiKeyRepo := new(MockAPIKeyRepository)
+			service := NewAPIKeyService(apiKeyRepo, keyUsers())
+			service.now = func() time.Time { return now }
+
+			apiKeyRepo.On("GetByHash", hashToken("tk_secret")).Return(stored, nil)
+
+			_, err := service.Authenticate(context.Background(), "tk_secret")
+			assert.ErrorIs(t, err, ErrUnauthorized)
+			apiKeyRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything)
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		apiKeyRepo := new(MockAPIKeyRepository)
+		service := NewAPIKeyService(apiKeyRepo, keyUsers())
+
+		apiKeyRepo.On("GetByHash", mock.Anything).Return(domain.APIKey{}, postgres.ErrNotFound)
+
+		_, err := service.Authenticate(context.Background(), "tk_unknown")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		service := NewAPIKeyService(new(MockAPIKeyRepository), keyUsers())
+
+		_, err := service.Authenticate(context.Background(), "")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	apiKeyRepo := new(MockAPIKeyRepository)
+	service := NewAPIKeyService(apiKeyRepo, keyUsers()).WithBootstrapKey("admin-secret")
+
+	assert.ErrorIs(t, service.Revoke(context.Background(), BootstrapAPIKeyID), ErrValidation)
+
+	apiKeyRepo.On("Revoke", "key-1").Return(nil)
+	assert.NoError(t, service.Revoke(context.Background(), "key-1"))
+	apiKeyRepo.AssertExpectations(t)
+}
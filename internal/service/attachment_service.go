@@ -0,0 +1,286 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"RestApi/internal/blob"
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrPayloadTooLarge — вложение превышает допустимый размер
+	ErrPayloadTooLarge = errors.New("PAYLOAD_TOO_LARGE")
+	// ErrUnsupportedMediaType — тип содержимого вложения не разрешен
+	ErrUnsupportedMediaType = errors.New("UNSUPPORTED_MEDIA_TYPE")
+)
+
+// MaxAttachmentFileNameLength — максимальная длина имени файла вложения в символах
+const MaxAttachmentFileNameLength = 255
+
+// blobCleanupBatch — сколько ключей содержимого удаляется за один проход очистки
+const blobCleanupBatch = 100
+
+type AttachmentService struct {
+	repo     storage.AttachmentRepository
+	taskRepo storage.TaskRepository
+	listRepo storage.ListRepository
+	store    blob.Store
+	// maxSize — максимальный размер вложения в байтах
+	maxSize int64
+	// allowedTypes — допустимые типы содержимого
+	allowedTypes map[string]bool
+}
+
+func NewAttachmentService(repo storage.AttachmentRepository, taskRepo storage.TaskRepository, listRepo storage.ListRepository, store blob.Store, maxSize int64, allowedTypes []string) *AttachmentService {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, contentType := range allowedTypes {
+		allowed[strings.ToLower(contentType)] = true
+	}
+
+	return &AttachmentService{
+		repo:         repo,
+		taskRepo:     taskRepo,
+		listRepo:     listRepo,
+		store:        store,
+		maxSize:      maxSize,
+		allowedTypes: allowed,
+	}
+}
+
+// MaxSize возвращает максимальный размер вложения в байтах
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload сохраняет содержимое файла в хранилище и метаданные вложения в базе.
+// Пустой или application/octet-stream contentType определяется по первым байтам файла.
+func (s *AttachmentService) Upload(ctx context.Context, taskID, fileName, contentType string, r io.Reader) (domain.Attachment, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleEditor); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	reader := bufio.NewReaderSize(r, 512)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.Attachment{}, fmt.Errorf("read attachment: %w", err)
+	}
+	if len(head) == 0 {
+		return domain.Attachment{}, fmt.Errorf("%w: file must not be empty", ErrValidation)
+	}
+
+	contentType = normalizeContentType(contentType)
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = normalizeContentType(http.DetectContentType(head))
+	}
+	if !s.allowedTypes[contentType] {
+		return domain.Attachment{}, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+
+	id := uuid.NewString()
+	key := "tasks/" + taskID + "/" + id
+
+	// Читаем на байт больше лимита, чтобы отличить файл ровно в лимит от превышающего его
+	counter := &countingReader{r: io.LimitReader(reader, s.maxSize+1)}
+	if err := s.store.Put(ctx, key, counter, -1, contentType); err != nil {
+		return domain.Attachment{}, fmt.Errorf("store attachment: %w", err)
+	}
+	if counter.n > s.maxSize {
+		s.deleteBlob(ctx, key)
+		return domain.Attachment{}, fmt.Errorf("%w: file exceeds %d bytes", ErrPayloadTooLarge, s.maxSize)
+	}
+
+	attachment, err := s.repo.Create(ctx, domain.Attachment{
+		ID:          id,
+		TaskID:      taskID,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        counter.n,
+		StorageKey:  key,
+		UploadedBy:  requestctx.Actor(ctx),
+	})
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return domain.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+// ListByTask получает вложения задачи в порядке загрузки
+func (s *AttachmentService) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Attachment, int, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListByTask(ctx, taskID, limit, offset)
+}
+
+// Get получает метаданные вложения задачи
+func (s *AttachmentService) Get(ctx context.Context, taskID, attachmentID string) (domain.Attachment, error) {
+	return s.getTaskAttachment(ctx, taskID, attachmentID, domain.RoleViewer)
+}
+
+// Download получает метаданные вложения и открывает его содержимое на чтение.
+// Вызывающий закрывает возвращенный поток.
+func (s *AttachmentService) Download(ctx context.Context, taskID, attachmentID string) (domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getTaskAttachment(ctx, taskID, attachmentID, domain.RoleViewer)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return domain.Attachment{}, nil, fmt.Errorf("attachment %s content: %w", attachmentID, postgres.ErrNotFound)
+		}
+		return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// Delete удаляет вложение задачи. Если содержимое не удалось удалить сразу,
+// его удалит фоновая очистка.
+func (s *AttachmentService) Delete(ctx context.Context, taskID, attachmentID string) error {
+	attachment, err := s.getTaskAttachment(ctx, taskID, attachmentID, domain.RoleEditor)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, attachmentID); err != nil {
+		return err
+	}
+
+	s.deleteBlob(ctx, attachment.StorageKey)
+	return nil
+}
+
+// CleanupBlobs удаляет содержимое вложений из очереди удаления.
+// Ключи, которые не удалось удалить, остаются в очереди до следующего прохода.
+func (s *AttachmentService) CleanupBlobs(ctx context.Context) (int, error) {
+	keys, err := s.repo.PendingBlobDeletions(ctx, blobCleanupBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("Attachment blob %s deletion failed: %v", key, err)
+			continue
+		}
+		if err := s.repo.ConfirmBlobDeletion(ctx, key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// RunBlobCleanup периодически удаляет содержимое удаленных вложений, пока не отменен ctx
+func (s *AttachmentService) RunBlobCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.CleanupBlobs(ctx)
+		if err != nil {
+			log.Printf("Attachment blob cleanup failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Attachment blob cleanup removed %d blobs", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// getTaskAttachment получает вложение и проверяет, что оно относится к задаче
+// из рабочего пространства запроса, а у пользователя запроса есть в ее списке роль role
+func (s *AttachmentService) getTaskAttachment(ctx context.Context, taskID, attachmentID string, role domain.ListRole) (domain.Attachment, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, role); err != nil {
+		return domain.Attachment{}, err
+	}
+	attachment, err := s.repo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.TaskID != taskID {
+		return domain.Attachment{}, fmt.Errorf("attachment %s of task %s: %w", attachmentID, taskID, postgres.ErrNotFound)
+	}
+	return attachment, nil
+}
+
+// deleteBlob удаляет содержимое сразу, не дожидаясь фоновой очистки.
+// Ошибка только логируется: ключ остается в очереди удаления.
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("Attachment blob %s deletion failed: %v", key, err)
+		return
+	}
+	if err := s.repo.ConfirmBlobDeletion(ctx, key); err != nil {
+		log.Printf("Attachment blob %s deletion not confirmed: %v", key, err)
+	}
+}
+
+// normalizeContentType оставляет от типа содержимого только media type без параметров
+func normalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(mediaType)
+}
+
+// sanitizeFileName убирает из имени файла путь и управляющие символы
+// и обрезает его до MaxAttachmentFileNameLength символов
+func sanitizeFileName(fileName string) string {
+	if i := strings.LastIndexAny(fileName, `/\`); i >= 0 {
+		fileName = fileName[i+1:]
+	}
+	fileName = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, fileName)
+	fileName = strings.TrimSpace(fileName)
+
+	if utf8.RuneCountInString(fileName) > MaxAttachmentFileNameLength {
+		fileName = string([]rune(fileName)[:MaxAttachmentFileNameLength])
+	}
+	if fileName == "" || fileName == "." || fileName == ".." {
+		return "file"
+	}
+	return fileName
+}
+
+// countingReader считает прочитанные байты
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"RestApi/internal/blob"
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock для AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+	args := m.Called(attachment)
+	// Возвращаемое значение может вычисляться по сохраняемому вложению
+	if fn, ok := args.Get(0).(func(domain.Attachment) domain.Attachment); ok {
+		return fn(attachment), args.Error(1)
+	}
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, id string) (domain.Attachment, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Attachment, int, error) {
+	args := m.Called(taskID, limit, offset)
+	return args.Get(0).([]domain.Attachment), args.Int(1), args.Error(2)
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) PendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) ConfirmBlobDeletion(ctx context.Context, key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// memoryStore — blob.Store в памяти для тестов
+type memoryStore struct {
+	mu      sync.Mutex
+	blobs   map[string][]byte
+	failDel bool
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failDel {
+		return errors.New("storage unavailable")
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+func newTestAttachmentService(repo *MockAttachmentRepository, taskRepo *MockTaskRepository, store *memoryStore) *AttachmentService {
+	return NewAttachmentService(repo, taskRepo, new(MockListRepository), store, 16, []string{"text/plain", "image/png"})
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	t.Run("stores content and metadata", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		store := newMemoryStore()
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		repo.On("Create", mock.MatchedBy(func(a domain.Attachment) bool {
+			return a.TaskID == "task-1" && a.FileName == "notes.txt" && a.ContentType == "text/plain" &&
+				a.Size == 5 && a.UploadedBy == "alice" && a.StorageKey == "tasks/task-1/"+a.ID
+		})).Return(func(a domain.Attachment) domain.Attachment { return a }, nil)
+
+		ctx := requestctx.WithActor(authDisabledContext(), "alice")
+		attachment, err := service.Upload(ctx, "task-1", "../../notes.txt", "text/plain; charset=utf-8", strings.NewReader("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello"), store.blobs[attachment.StorageKey])
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("detects octet-stream content type", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		service := newTestAttachmentService(repo, taskRepo, newMemoryStore())
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		repo.On("Create", mock.MatchedBy(func(a domain.Attachment) bool {
+			return a.ContentType == "image/png"
+		})).Return(func(a domain.Attachment) domain.Attachment { return a }, nil)
+
+		png := "\x89PNG\r\n\x1a\n"
+		_, err := service.Upload(authDisabledContext(), "task-1", "a.png", "application/octet-stream", strings.NewReader(png))
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("disallowed content type", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		store := newMemoryStore()
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+
+		_, err := service.Upload(authDisabledContext(), "task-1", "x.html", "", strings.NewReader("<html><body>hi</body></html>"))
+		assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+		assert.Empty(t, store.blobs)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		store := newMemoryStore()
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		repo.On("ConfirmBlobDeletion", mock.Anything).Return(nil)
+
+		_, err := service.Upload(authDisabledContext(), "task-1", "big.txt", "text/plain", strings.NewReader(strings.Repeat("a", 17)))
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+		assert.Empty(t, store.blobs)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("exactly max size", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		service := newTestAttachmentService(repo, taskRepo, newMemoryStore())
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		repo.On("Create", mock.Anything).Return(func(a domain.Attachment) domain.Attachment { return a }, nil)
+
+		attachment, err := service.Upload(authDisabledContext(), "task-1", "max.txt", "text/plain", strings.NewReader(strings.Repeat("a", 16)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(16), attachment.Size)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := newTestAttachmentService(new(MockAttachmentRepository), taskRepo, newMemoryStore())
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+
+		_, err := service.Upload(authDisabledContext(), "task-1", "empty.txt", "text/plain", strings.NewReader(""))
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("metadata failure removes content", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		store := newMemoryStore()
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		repo.On("Create", mock.Anything).Return(domain.Attachment{}, postgres.ErrNotFound)
+		repo.On("ConfirmBlobDeletion", mock.Anything).Return(nil)
+
+		_, err := service.Upload(authDisabledContext(), "task-1", "a.txt", "text/plain", strings.NewReader("hello"))
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		assert.Empty(t, store.blobs)
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := newTestAttachmentService(new(MockAttachmentRepository), taskRepo, newMemoryStore())
+
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+
+		_, err := service.Upload(authDisabledContext(), "missing", "a.txt", "text/plain", strings.NewReader("hello"))
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestAttachmentService_DownloadDelete(t *testing.T) {
+	attachment := domain.Attachment{ID: "a-1", TaskID: "task-1", FileName: "a.txt", ContentType: "text/plain", Size: 5, StorageKey: "tasks/task-1/a-1"}
+	// Задачи существуют в рабочем пространстве запроса
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("GetByIDTask", mock.Anything).Return(domain.Task{}, nil)
+
+	t.Run("download", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		store := newMemoryStore()
+		store.blobs[attachment.StorageKey] = []byte("hello")
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		repo.On("GetByID", "a-1").Return(attachment, nil)
+
+		got, content, err := service.Download(authDisabledContext(), "task-1", "a-1")
+		require.NoError(t, err)
+		defer content.Close()
+		data, _ := io.ReadAll(content)
+		assert.Equal(t, "a.txt", got.FileName)
+		assert.Equal(t, "hello", string(data))
+	})
+
+	t.Run("attachment of another task", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		service := newTestAttachmentService(repo, taskRepo, newMemoryStore())
+
+		repo.On("GetByID", "a-1").Return(attachment, nil)
+
+		_, _, err := service.Download(authDisabledContext(), "task-2", "a-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+
+	t.Run("delete removes content", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		store := newMemoryStore()
+		store.blobs[attachment.StorageKey] = []byte("hello")
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		repo.On("GetByID", "a-1").Return(attachment, nil)
+		repo.On("Delete", "a-1").Return(nil)
+		repo.On("ConfirmBlobDeletion", attachment.StorageKey).Return(nil)
+
+		err := service.Delete(authDisabledContext(), "task-1", "a-1")
+		assert.NoError(t, err)
+		assert.Empty(t, store.blobs)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("delete keeps key queued when storage fails", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		store := newMemoryStore()
+		store.failDel = true
+		service := newTestAttachmentService(repo, taskRepo, store)
+
+		repo.On("GetByID", "a-1").Return(attachment, nil)
+		repo.On("Delete", "a-1").Return(nil)
+
+		err := service.Delete(authDisabledContext(), "task-1", "a-1")
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "ConfirmBlobDeletion", mock.Anything)
+	})
+}
+
+func TestAttachmentService_CleanupBlobs(t *testing.T) {
+	repo := new(MockAttachmentRepository)
+	store := newMemoryStore()
+	store.blobs["tasks/t/1"] = []byte("1")
+	store.blobs["tasks/t/2"] = []byte("2")
+	service := newTestAttachmentService(repo, new(MockTaskRepository), store)
+
+	repo.On("PendingBlobDeletions", blobCleanupBatch).Return([]string{"tasks/t/1", "tasks/t/2"}, nil)
+	repo.On("ConfirmBlobDeletion", "tasks/t/1").Return(nil)
+	repo.On("ConfirmBlobDeletion", "tasks/t/2").Return(nil)
+
+	deleted, err := service.CleanupBlobs(authDisabledContext())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Empty(t, store.blobs)
+	repo.AssertExpectations(t)
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":             "report.pdf",
+		"../../etc/passwd":       "passwd",
+		`C:\Users\me\a.txt`:      "a.txt",
+		"bad\x00\nname.txt":      "badname.txt",
+		"   ":                    "file",
+		"..":                     "file",
+		strings.Repeat("я", 300): strings.Repeat("я", MaxAttachmentFileNameLength),
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, sanitizeFileName(input), input)
+	}
+}
+
+func TestAttachmentService_ListAccess(t *testing.T) {
+	attachment := domain.Attachment{ID: "a-1", TaskID: "task-1", FileName: "a.txt", ContentType: "text/plain", Size: 5, StorageKey: "tasks/task-1/a-1"}
+	newService := func() (*AttachmentService, *MockAttachmentRepository, *memoryStore) {
+		repo := new(MockAttachmentRepository)
+		taskRepo := new(MockTaskRepository)
+		store := newMemoryStore()
+		store.blobs[attachment.StorageKey] = []byte("hello")
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1"}, nil)
+		repo.On("GetByID", "a-1").Return(attachment, nil)
+		return NewAttachmentService(repo, taskRepo, memberRoles(), store, 16, []string{"text/plain"}), repo, store
+	}
+
+	t.Run("viewer downloads but cannot upload or delete", func(t *testing.T) {
+		service, repo, store := newService()
+
+		_, content, err := service.Download(userContext("viewer"), "task-1", "a-1")
+		require.NoError(t, err)
+		content.Close()
+
+		_, err = service.Upload(userContext("viewer"), "task-1", "b.txt", "text/plain", strings.NewReader("hello"))
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorIs(t, service.Delete(userContext("viewer"), "task-1", "a-1"), ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+		repo.AssertNotCalled(t, "Delete", mock.Anything)
+		assert.Len(t, store.blobs, 1)
+	})
+
+	t.Run("non-member cannot read attachments", func(t *testing.T) {
+		service, repo, _ := newService()
+
+		_, _, err := service.ListByTask(userContext("stranger"), "task-1", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		_, err = service.Get(userContext("stranger"), "task-1", "a-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		_, _, err = service.Download(userContext("stranger"), "task-1", "a-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		repo.AssertNotCalled(t, "ListByTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/jwt"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// refreshTokenMarker начинает каждый токен обновления
+const refreshTokenMarker = "rt_"
+
+// userScopes — права пользователя, вошедшего по паролю: чтение и изменение данных.
+// Управление API-ключами остается за ключами с правом admin.
+var userScopes = []domain.APIKeyScope{domain.ScopeWrite}
+
+// TokenConfig — параметры выпуска токенов
+type TokenConfig struct {
+	// Issuer — значение iss в токенах доступа; токены другого издателя не принимаются
+	Issuer string
+	// AccessTTL — срок действия токена доступа
+	AccessTTL time.Duration
+	// RefreshTTL — срок действия токена обновления
+	RefreshTTL time.Duration
+}
+
+type AuthService struct {
+	users      storage.UserRepository
+	tokens     storage.RefreshTokenRepository
+	workspaces storage.WorkspaceRepository
+	signer     jwt.Signer
+	config     TokenConfig
+	// now — источник текущего времени, подменяется в тестах
+	now func() time.Time
+
+	// dummyHash сравнивается с паролем неизвестного пользователя,
+	// чтобы по времени ответа нельзя было узнать, существует ли email
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+}
+
+func NewAuthService(users storage.UserRepository, tokens storage.RefreshTokenRepository, workspaces storage.WorkspaceRepository, signer jwt.Signer, config TokenConfig) *AuthService {
+	return &AuthService{
+		users:      users,
+		tokens:     tokens,
+		workspaces: workspaces,
+		signer:     signer,
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// Login проверяет email и пароль и выдает пару токенов.
+// С workspace_id токены работают только в этом рабочем пространстве, без него —
+// в рабочем пространстве по умолчанию. Пользователь должен в нем состоять, иначе ErrForbidden
+func (s *AuthService) Login(ctx context.Context, request domain.LoginRequest) (domain.TokenPair, error) {
+	workspaceID, err := normalizeWorkspaceID(request.WorkspaceID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	user, err := s.users.GetByEmail(strings.TrimSpace(request.Email))
+	if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return domain.TokenPair{}, err
+	}
+
+	hash := []byte(user.PasswordHash)
+	if len(hash) == 0 {
+		hash = s.fakeHash()
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(request.Password)); err != nil || user.PasswordHash == "" {
+		return domain.TokenPair{}, fmt.Errorf("%w: invalid email or password", ErrUnauthorized)
+	}
+
+	member, err := s.isMember(ctx, workspaceID, user.ID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	if !member {
+		return domain.TokenPair{}, fmt.Errorf("%w: user is not a member of the workspace", ErrForbidden)
+	}
+
+	return s.issue(ctx, user, workspaceID)
+}
+
+// Refresh обменивает токен обновления на новую пару токенов; старый токен отзывается.
+// Повторное предъявление отозванного токена означает его утечку:
+// тогда отзываются все токены пользователя.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (domain.TokenPair, error) {
+	token, err := s.findRefreshToken(refreshToken)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	if token.RevokedAt != nil {
+		return domain.TokenPair{}, s.revokeReused(ctx, token)
+	}
+	if !token.Active(s.now()) {
+		return domain.TokenPair{}, fmt.Errorf("%w: refresh token is expired", ErrUnauthorized)
+	}
+	if err := s.tokens.Revoke(ctx, token.ID); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			// Токен успели использовать параллельно
+			return domain.TokenPair{}, s.revokeReused(ctx, token)
+		}
+		return domain.TokenPair{}, err
+	}
+
+	// Пользователь ищется в рабочем пространстве токена, а не запроса
+	workspaceID := token.WorkspaceID
+	if workspaceID == "" {
+		workspaceID = domain.DefaultWorkspaceID
+	}
+	user, err := s.users.GetByID(requestctx.WithWorkspace(ctx, workspaceID), token.UserID)
+	if errors.Is(err, postgres.ErrNotFound) {
+		return domain.TokenPair{}, fmt.Errorf("%w: user not found", ErrUnauthorized)
+	}
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	// Пользователя могли исключить из рабочего пространства после входа
+	member, err := s.isMember(ctx, token.WorkspaceID, user.ID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	if !member {
+		return domain.TokenPair{}, fmt.Errorf("%w: user is not a member of the workspace", ErrUnauthorized)
+	}
+
+	return s.issue(ctx, user, token.WorkspaceID)
+}
+
+// isMember проверяет, состоит ли пользователь в рабочем пространстве токенов.
+// Пустой workspaceID — рабочее пространство по умолчанию
+func (s *AuthService) isMember(ctx context.Context, workspaceID, userID string) (bool, error) {
+	if workspaceID == "" {
+		workspaceID = domain.DefaultWorkspaceID
+	}
+	return s.workspaces.HasMember(ctx, workspaceID, userID)
+}
+
+// Logout отзывает токен обновления. Неизвестный или уже отозванный токен не ошибка
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	token, err := s.findRefreshToken(refreshToken)
+	if errors.Is(err, ErrUnauthorized) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokens.Revoke(ctx, token.ID); err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// VerifyAccessToken проверяет токен доступа и возвращает пользователя,
+// которому он выдан. Токен проверяется без обращения к БД
+func (s *AuthService) VerifyAccessToken(token string) (domain.Principal, error) {
+	claims, err := jwt.Decode(s.signer, token, s.now())
+	if err != nil {
+		return domain.Principal{}, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if claims.Issuer != s.config.Issuer || claims.Subject == "" {
+		return domain.Principal{}, fmt.Errorf("%w: token was not issued by this server", ErrUnauthorized)
+	}
+
+	var scopes []domain.APIKeyScope
+	for _, scope := range strings.Fields(claims.Scope) {
+		scopes = append(scopes, domain.APIKeyScope(scope))
+	}
+
+	return domain.Principal{
+		Type:        domain.PrincipalUser,
+		ID:          claims.Subject,
+		Name:        claims.Name,
+		Scopes:      scopes,
+		WorkspaceID: claims.Workspace,
+	}, nil
+}
+
+// issue выпускает токен доступа и сохраняет новый токен обновления.
+// Непустой workspaceID привязывает оба токена к рабочему пространству
+func (s *AuthService) issue(ctx context.Context, user domain.User, workspaceID string) (domain.TokenPair, error) {
+	now := s.now()
+
+	scopes := make([]string, len(userScopes))
+	for i, scope := range userScopes {
+		scopes[i] = string(scope)
+	}
+
+	accessToken, err := jwt.Encode(s.signer, jwt.Claims{
+		Issuer:    s.config.Issuer,
+		Subject:   user.ID,
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.config.AccessTTL).Unix(),
+		Name:      user.Name,
+		Scope:     strings.Join(scopes, " "),
+		Workspace: workspaceID,
+	})
+	if err != nil {
+		return domain.TokenPair{}, fmt.Errorf("sign access token: %w", err)
+	}
+
+	refreshToken, err := generateToken(refreshTokenMarker)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	stored, err := s.tokens.Create(ctx, domain.RefreshToken{
+		UserID:      user.ID,
+		WorkspaceID: workspaceID,
+		ExpiresAt:   now.Add(s.config.RefreshTTL),
+	}, hashToken(refreshToken))
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return domain.TokenPair{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(s.config.AccessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// findRefreshToken находит токен обновления по значению; неизвестный токен дает ErrUnauthorized
+func (s *AuthService) findRefreshToken(refreshToken string) (domain.RefreshToken, error) {
+	if refreshToken == "" {
+		return domain.RefreshToken{}, fmt.Errorf("%w: refresh token is required", ErrUnauthorized)
+	}
+
+	token, err := s.tokens.GetByHash(hashToken(refreshToken))
+	if errors.Is(err, postgres.ErrNotFound) {
+		return domain.RefreshToken{}, fmt.Errorf("%w: refresh token is invalid", ErrUnauthorized)
+	}
+	return token, err
+}
+
+// revokeReused отзывает все токены пользователя после повторного использования токена
+func (s *AuthService) revokeReused(ctx context.Context, token domain.RefreshToken) error {
+	if err := s.tokens.RevokeAllForUser(ctx, token.UserID); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: refresh token was already used, all sessions are revoked", ErrUnauthorized)
+}
+
+func (s *AuthService) fakeHash() []byte {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), passwordCost)
+	})
+	return s.dummyHash
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/jwt"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mock для RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token domain.RefreshToken, tokenHash string) (domain.RefreshToken, error) {
+	args := m.Called(token, tokenHash)
+	return args.Get(0).(domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(tokenHash string) (domain.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+var testTokenConfig = TokenConfig{Issuer: "todo-api", AccessTTL: 15 * time.Minute, RefreshTTL: 24 * time.Hour}
+
+func newTestAuthService(t *testing.T, userRepo *MockUserRepository, tokenRepo *MockRefreshTokenRepository, now time.Time) *AuthService {
+	t.Helper()
+	signer, err := jwt.NewHS256([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	// По умолчанию пользователь состоит в любом рабочем пространстве
+	workspaceRepo := new(MockWorkspaceRepository)
+	workspaceRepo.On("HasMember", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+
+	service := NewAuthService(userRepo, tokenRepo, workspaceRepo, signer, testTokenConfig)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+// withMembership подменяет участников рабочих пространств: пользователь
+// состоит только в рабочих пространствах из workspaceIDs
+func withMembership(service *AuthService, userID string, workspaceIDs ...string) {
+	workspaceRepo := new(MockWorkspaceRepository)
+	for _, workspaceID := range workspaceIDs {
+		workspaceRepo.On("HasMember", workspaceID, userID).Return(true, nil)
+	}
+	workspaceRepo.On("HasMember", mock.Anything, mock.Anything).Return(false, nil)
+	service.workspaces = workspaceRepo
+}
+
+func TestAuthService_Login(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+	alice := domain.User{ID: "user-1", Name: "Алиса", Email: "alice@example.com", PasswordHash: string(hash)}
+
+	t.Run("valid credentials", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(alice, nil)
+		tokenRepo.On("Create", domain.RefreshToken{UserID: "user-1", ExpiresAt: now.Add(24 * time.Hour)}, mock.AnythingOfType("string")).
+			Return(domain.RefreshToken{ID: "rt-1", UserID: "user-1", ExpiresAt: now.Add(24 * time.Hour)}, nil)
+
+		pair, err := service.Login(context.Background(), domain.LoginRequest{Email: " alice@example.com ", Password: "correct horse"})
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", pair.TokenType)
+		assert.Equal(t, 900, pair.ExpiresIn)
+		assert.NotEmpty(t, pair.RefreshToken)
+		tokenRepo.AssertCalled(t, "Create", mock.Anything, hashToken(pair.RefreshToken))
+
+		principal, err := service.VerifyAccessToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Principal{
+			Type:   domain.PrincipalUser,
+			ID:     "user-1",
+			Name:   "Алиса",
+			Scopes: []domain.APIKeyScope{domain.ScopeWrite},
+		}, principal)
+		assert.False(t, principal.Allows(domain.ScopeAdmin))
+	})
+
+	for name, user := range map[string]domain.User{
+		"wrong password": alice,
+		"no password":    {ID: "user-1", Email: "alice@example.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			tokenRepo := new(MockRefreshTokenRepository)
+			service := newTestAuthService(t, userRepo, tokenRepo, now)
+
+			userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+
+			_, err := service.Login(context.Background(), domain.LoginRequest{Email: "alice@example.com", Password: "wrong horse"})
+			assert.ErrorIs(t, err, ErrUnauthorized)
+			tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("unknown email", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := newTestAuthService(t, userRepo, new(MockRefreshTokenRepository), now)
+
+		userRepo.On("GetByEmail", "bob@example.com").Return(domain.User{}, postgres.ErrNotFound)
+
+		_, err := service.Login(context.Background(), domain.LoginRequest{Email: "bob@example.com", Password: "correct horse"})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("tokens bound to workspace", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+
+		workspaceID := "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		userRepo.On("GetByEmail", "alice@example.com").Return(alice, nil)
+		tokenRepo.On("Create", domain.RefreshToken{UserID: "user-1", ExpiresAt: now.Add(24 * time.Hour), WorkspaceID: workspaceID}, mock.AnythingOfType("string")).
+			Return(domain.RefreshToken{ID: "rt-1", UserID: "user-1", ExpiresAt: now.Add(24 * time.Hour), WorkspaceID: workspaceID}, nil)
+
+		pair, err := service.Login(context.Background(), domain.LoginRequest{Email: "alice@example.com", Password: "correct horse", WorkspaceID: strings.ToUpper(workspaceID)})
+		require.NoError(t, err)
+
+		principal, err := service.VerifyAccessToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, workspaceID, principal.WorkspaceID)
+	})
+
+	t.Run("not a member of workspace", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+		withMembership(service, "user-1", domain.DefaultWorkspaceID)
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(alice, nil)
+
+		_, err := service.Login(context.Background(), domain.LoginRequest{Email: "alice@example.com", Password: "correct horse", WorkspaceID: "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not a member of default workspace", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+		withMembership(service, "user-1", "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b")
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(alice, nil)
+
+		_, err := service.Login(context.Background(), domain.LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid workspace id", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := newTestAuthService(t, userRepo, new(MockRefreshTokenRepository), now)
+
+		_, err := service.Login(context.Background(), domain.LoginRequest{Email: "alice@example.com", Password: "correct horse", WorkspaceID: "team-a"})
+		assert.ErrorIs(t, err, ErrValidation)
+		userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything)
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	active := domain.RefreshToken{ID: "rt-1", UserID: "user-1", ExpiresAt: now.Add(time.Hour)}
+
+	t.Run("rotates token", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(active, nil)
+		tokenRepo.On("Revoke", "rt-1").Return(nil)
+		userRepo.On("GetByID", "user-1").Return(domain.User{ID: "user-1", Name: "Алиса"}, nil)
+		tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("string")).
+			Return(domain.RefreshToken{ID: "rt-2", UserID: "user-1"}, nil)
+
+		pair, err := service.Refresh(context.Background(), "rt_old")
+		require.NoError(t, err)
+		assert.NotEqual(t, "rt_old", pair.RefreshToken)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps workspace binding", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+
+		bound := active
+		bound.WorkspaceID = "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(bound, nil)
+		tokenRepo.On("Revoke", "rt-1").Return(nil)
+		userRepo.On("GetByID", "user-1").Return(domain.User{ID: "user-1", Name: "Алиса"}, nil)
+		tokenRepo.On("Create", mock.MatchedBy(func(token domain.RefreshToken) bool {
+			return token.WorkspaceID == bound.WorkspaceID
+		}), mock.AnythingOfType("string")).Return(domain.RefreshToken{ID: "rt-2", UserID: "user-1"}, nil)
+
+		pair, err := service.Refresh(context.Background(), "rt_old")
+		require.NoError(t, err)
+		principal, err := service.VerifyAccessToken(pair.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, bound.WorkspaceID, principal.WorkspaceID)
+	})
+
+	t.Run("removed from workspace", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, userRepo, tokenRepo, now)
+		withMembership(service, "user-1", domain.DefaultWorkspaceID)
+
+		bound := active
+		bound.WorkspaceID = "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(bound, nil)
+		tokenRepo.On("Revoke", "rt-1").Return(nil)
+		userRepo.On("GetByID", "user-1").Return(domain.User{ID: "user-1", Name: "Алиса"}, nil)
+
+		_, err := service.Refresh(context.Background(), "rt_old")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reused token revokes all sessions", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, new(MockUserRepository), tokenRepo, now)
+
+		revoked := active
+		revoked.RevokedAt = &past
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(revoked, nil)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		_, err := service.Refresh(context.Background(), "rt_old")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent use revokes all sessions", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, new(MockUserRepository), tokenRepo, now)
+
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(active, nil)
+		tokenRepo.On("Revoke", "rt-1").Return(postgres.ErrNotFound)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		_, err := service.Refresh(context.Background(), "rt_old")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, new(MockUserRepository), tokenRepo, now)
+
+		expired := active
+		expired.ExpiresAt = past
+		tokenRepo.On("GetByHash", hashToken("rt_old")).Return(expired, nil)
+
+		_, err := service.Refresh(context.Background(), "rt_old")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		tokenRepo.AssertNotCalled(t, "Revoke", mock.Anything)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := newTestAuthService(t, new(MockUserRepository), tokenRepo, now)
+
+		tokenRepo.On("GetByHash", mock.Anything).Return(domain.RefreshToken{}, postgres.ErrNotFound)
+
+		_, err := service.Refresh(context.Background(), "rt_unknown")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.NoError(t, service.Logout(context.Background(), "rt_unknown"))
+	})
+}
+
+func TestAuthService_VerifyAccessToken(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service := newTestAuthService(t, new(MockUserRepository), new(MockRefreshTokenRepository), now)
+
+	token, err := jwt.Encode(service.signer, jwt.Claims{Issuer: "todo-api", Subject: "user-1", ExpiresAt: now.Add(time.Minute).Unix()})
+	require.NoError(t, err)
+	_, err = service.VerifyAccessToken(token)
+	assert.NoError(t, err)
+
+	foreign, err := jwt.Encode(service.signer, jwt.Claims{Issuer: "other", Subject: "user-1", ExpiresAt: now.Add(time.Minute).Unix()})
+	require.NoError(t, err)
+	_, err = service.VerifyAccessToken(foreign)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	service.now = func() time.Time { return now.Add(time.Minute) }
+	_, err = service.VerifyAccessToken(token)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = service.VerifyAccessToken("not a token")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
+)
+
+// MaxCommentLength — максимальная длина комментария в символах
+const MaxCommentLength = 2000
+
+type CommentService struct {
+	repo     storage.CommentRepository
+	taskRepo storage.TaskRepository
+	listRepo storage.ListRepository
+}
+
+func NewCommentService(repo storage.CommentRepository, taskRepo storage.TaskRepository, listRepo storage.ListRepository) *CommentService {
+	return &CommentService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		listRepo: listRepo,
+	}
+}
+
+// Create добавляет комментарий к задаче от имени автора из контекста запроса
+func (s *CommentService) Create(ctx context.Context, taskID string, request domain.CreateCommentRequest) (domain.Comment, error) {
+	text := strings.TrimSpace(request.Text)
+	if err := validateCommentText(text); err != nil {
+		return domain.Comment{}, err
+	}
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleEditor); err != nil {
+		return domain.Comment{}, err
+	}
+
+	return s.repo.Create(ctx, domain.Comment{
+		TaskID: taskID,
+		Author: requestctx.Actor(ctx),
+		Text:   text,
+	})
+}
+
+// ListByTask получает комментарии задачи в порядке добавления
+func (s *CommentService) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Comment, int, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListByTask(ctx, taskID, limit, offset)
+}
+
+// Update меняет текст комментария задачи
+func (s *CommentService) Update(ctx context.Context, taskID, commentID string, request domain.UpdateCommentRequest) (domain.Comment, error) {
+	text := strings.TrimSpace(request.Text)
+	if err := validateCommentText(text); err != nil {
+		return domain.Comment{}, err
+	}
+	if _, err := s.getTaskComment(ctx, taskID, commentID); err != nil {
+		return domain.Comment{}, err
+	}
+	return s.repo.Update(ctx, commentID, text)
+}
+
+// Delete удаляет комментарий задачи
+func (s *CommentService) Delete(ctx context.Context, taskID, commentID string) error {
+	if _, err := s.getTaskComment(ctx, taskID, commentID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, commentID)
+}
+
+// getTaskComment получает комментарий и проверяет, что он относится к задаче
+// из рабочего пространства запроса, а пользователь запроса может менять ее список
+func (s *CommentService) getTaskComment(ctx context.Context, taskID, commentID string) (domain.Comment, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleEditor); err != nil {
+		return domain.Comment{}, err
+	}
+	comment, err := s.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.TaskID != taskID {
+		return domain.Comment{}, fmt.Errorf("comment %s of task %s: %w", commentID, taskID, postgres.ErrNotFound)
+	}
+	return comment, nil
+}
+
+func validateCommentText(text string) error {
+	if text == "" || utf8.RuneCountInString(text) > MaxCommentLength {
+		return fmt.Errorf("%w: text must be 1..%d chars", ErrValidation, MaxCommentLength)
+	}
+	return nil
+}
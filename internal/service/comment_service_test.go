@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для CommentRepository
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	args := m.Called(comment)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Comment, int, error) {
+	args := m.Called(taskID, limit, offset)
+	return args.Get(0).([]domain.Comment), args.Int(1), args.Error(2)
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, id, text string) (domain.Comment, error) {
+	args := m.Called(id, text)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestCommentService_Create(t *testing.T) {
+	t.Run("author from context", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		commentRepo.On("Create", domain.Comment{TaskID: "task-1", Author: "alice", Text: "Готово"}).
+			Return(domain.Comment{ID: "c-1", TaskID: "task-1", Author: "alice", Text: "Готово"}, nil)
+
+		ctx := requestctx.WithActor(authDisabledContext(), "alice")
+		comment, err := service.Create(ctx, "task-1", domain.CreateCommentRequest{Text: "  Готово "})
+		assert.NoError(t, err)
+		assert.Equal(t, "c-1", comment.ID)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid text", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		for _, text := range []string{"", "   ", strings.Repeat("я", MaxCommentLength+1)} {
+			_, err := service.Create(authDisabledContext(), "task-1", domain.CreateCommentRequest{Text: text})
+			assert.ErrorIs(t, err, ErrValidation)
+		}
+		commentRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+
+		_, err := service.Create(authDisabledContext(), "missing", domain.CreateCommentRequest{Text: "Текст"})
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestCommentService_UpdateDelete(t *testing.T) {
+	comment := domain.Comment{ID: "c-1", TaskID: "task-1", Text: "Старый"}
+	// Задачи существуют в рабочем пространстве запроса
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("GetByIDTask", mock.Anything).Return(domain.Task{}, nil)
+
+	t.Run("update", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		commentRepo.On("GetByID", "c-1").Return(comment, nil)
+		commentRepo.On("Update", "c-1", "Новый").Return(domain.Comment{ID: "c-1", TaskID: "task-1", Text: "Новый"}, nil)
+
+		updated, err := service.Update(authDisabledContext(), "task-1", "c-1", domain.UpdateCommentRequest{Text: "Новый"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Новый", updated.Text)
+	})
+
+	t.Run("comment of another task", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		commentRepo.On("GetByID", "c-1").Return(comment, nil)
+
+		_, err := service.Update(authDisabledContext(), "task-2", "c-1", domain.UpdateCommentRequest{Text: "Новый"})
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		err = service.Delete(authDisabledContext(), "task-2", "c-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		commentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		commentRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		service := NewCommentService(commentRepo, taskRepo, new(MockListRepository))
+
+		commentRepo.On("GetByID", "c-1").Return(comment, nil)
+		commentRepo.On("Delete", "c-1").Return(nil)
+
+		assert.NoError(t, service.Delete(authDisabledContext(), "task-1", "c-1"))
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("task of another workspace", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		otherTasks := new(MockTaskRepository)
+		service := NewCommentService(commentRepo, otherTasks, new(MockListRepository))
+
+		otherTasks.On("GetByIDTask", "task-1").Return(domain.Task{}, postgres.ErrNotFound)
+
+		err := service.Delete(authDisabledContext(), "task-1", "c-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		commentRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+}
+
+func TestCommentService_ListAccess(t *testing.T) {
+	comment := domain.Comment{ID: "c-1", TaskID: "task-1", Author: "editor", Text: "Готово"}
+	newService := func() (*CommentService, *MockCommentRepository) {
+		commentRepo := new(MockCommentRepository)
+		taskRepo := new(MockTaskRepository)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1"}, nil)
+		commentRepo.On("GetByID", "c-1").Return(comment, nil)
+		return NewCommentService(commentRepo, taskRepo, memberRoles()), commentRepo
+	}
+
+	t.Run("viewer reads but cannot change comments", func(t *testing.T) {
+		service, commentRepo := newService()
+		commentRepo.On("ListByTask", "task-1", 20, 0).Return([]domain.Comment{comment}, 1, nil)
+
+		_, _, err := service.ListByTask(userContext("viewer"), "task-1", 20, 0)
+		assert.NoError(t, err)
+
+		_, err = service.Create(userContext("viewer"), "task-1", domain.CreateCommentRequest{Text: "Нет"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		_, err = service.Update(userContext("viewer"), "task-1", "c-1", domain.UpdateCommentRequest{Text: "Нет"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorIs(t, service.Delete(userContext("viewer"), "task-1", "c-1"), ErrForbidden)
+		commentRepo.AssertNotCalled(t, "Create", mock.Anything)
+		commentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		commentRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("non-member does not see comments", func(t *testing.T) {
+		service, commentRepo := newService()
+
+		_, _, err := service.ListByTask(userContext("stranger"), "task-1", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		commentRepo.AssertNotCalled(t, "ListByTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("editor deletes comments", func(t *testing.T) {
+		service, commentRepo := newService()
+		commentRepo.On("Delete", "c-1").Return(nil)
+
+		assert.NoError(t, service.Delete(userContext("editor"), "task-1", "c-1"))
+	})
+}
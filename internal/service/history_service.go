@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
+)
+
+type HistoryService struct {
+	repo     storage.HistoryRepository
+	taskRepo storage.TaskRepository
+	listRepo storage.ListRepository
+}
+
+func NewHistoryService(repo storage.HistoryRepository, taskRepo storage.TaskRepository, listRepo storage.ListRepository) *HistoryService {
+	return &HistoryService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		listRepo: listRepo,
+	}
+}
+
+// TaskHistory получает историю изменений задачи, начиная с последних.
+// История доступна участникам списка задачи, пока задача существует в рабочем
+// пространстве запроса, в том числе в корзине.
+func (s *HistoryService) TaskHistory(ctx context.Context, taskID string, limit, offset int) ([]domain.HistoryEntry, int, error) {
+	task, err := s.taskRepo.GetByIDTask(ctx, taskID)
+	if errors.Is(err, postgres.ErrNotFound) {
+		task, err = s.taskRepo.GetDeletedTask(ctx, taskID)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkListRole(ctx, s.listRepo, task.ListID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListByEntity(ctx, domain.HistoryEntityTask, taskID, limit, offset)
+}
+
+// ListHistory получает историю изменений списка, начиная с последних.
+// История доступна участникам списка, пока список существует в рабочем
+// пространстве запроса, в том числе в корзине.
+func (s *HistoryService) ListHistory(ctx context.Context, listID string, limit, offset int) ([]domain.HistoryEntry, int, error) {
+	_, err := s.listRepo.GetByID(ctx, listID)
+	if errors.Is(err, postgres.ErrNotFound) {
+		_, err = s.listRepo.GetDeleted(ctx, listID)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkListRole(ctx, s.listRepo, listID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListByEntity(ctx, domain.HistoryEntityList, listID, limit, offset)
+}
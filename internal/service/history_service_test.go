@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для HistoryRepository
+type MockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRepository) ListByEntity(ctx context.Context, entityType domain.HistoryEntityType, entityID string, limit, offset int) ([]domain.HistoryEntry, int, error) {
+	args := m.Called(entityType, entityID, limit, offset)
+	return args.Get(0).([]domain.HistoryEntry), args.Int(1), args.Error(2)
+}
+
+func TestHistoryService_TaskHistory(t *testing.T) {
+	t.Run("returns entries", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewHistoryService(historyRepo, taskRepo, new(MockListRepository))
+
+		entries := []domain.HistoryEntry{{ID: "h-1", EntityID: "task-1", Action: domain.HistoryUpdated}}
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		historyRepo.On("ListByEntity", domain.HistoryEntityTask, "task-1", 20, 0).Return(entries, 1, nil)
+
+		result, total, err := service.TaskHistory(authDisabledContext(), "task-1", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, entries, result)
+		taskRepo.AssertNotCalled(t, "GetDeletedTask", mock.Anything)
+	})
+
+	t.Run("deleted task", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewHistoryService(historyRepo, taskRepo, new(MockListRepository))
+
+		entries := []domain.HistoryEntry{{ID: "h-1", EntityID: "task-1", Action: domain.HistoryDeleted}}
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{}, postgres.ErrNotFound)
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		historyRepo.On("ListByEntity", domain.HistoryEntityTask, "task-1", 20, 0).Return(entries, 1, nil)
+
+		result, total, err := service.TaskHistory(authDisabledContext(), "task-1", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, entries, result)
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewHistoryService(historyRepo, taskRepo, new(MockListRepository))
+
+		// Задача другого рабочего пространства не находится ни среди активных, ни в корзине
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+		taskRepo.On("GetDeletedTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+
+		_, _, err := service.TaskHistory(authDisabledContext(), "missing", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		historyRepo.AssertNotCalled(t, "ListByEntity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHistoryService_ListHistory(t *testing.T) {
+	t.Run("deleted list", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		listRepo := new(MockListRepository)
+		service := NewHistoryService(historyRepo, new(MockTaskRepository), listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{}, postgres.ErrNotFound)
+		listRepo.On("GetDeleted", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		historyRepo.On("ListByEntity", domain.HistoryEntityList, "list-1", 20, 0).Return([]domain.HistoryEntry{}, 0, nil)
+
+		_, total, err := service.ListHistory(authDisabledContext(), "list-1", 20, 0)
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("missing list", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		listRepo := new(MockListRepository)
+		service := NewHistoryService(historyRepo, new(MockTaskRepository), listRepo)
+
+		listRepo.On("GetByID", "missing").Return(domain.List{}, postgres.ErrNotFound)
+		listRepo.On("GetDeleted", "missing").Return(domain.List{}, postgres.ErrNotFound)
+
+		_, _, err := service.ListHistory(authDisabledContext(), "missing", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestHistoryService_ListAccess(t *testing.T) {
+	t.Run("non-member does not see task history", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewHistoryService(historyRepo, taskRepo, memberRoles())
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{}, postgres.ErrNotFound)
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1"}, nil)
+
+		_, _, err := service.TaskHistory(userContext("stranger"), "task-1", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		historyRepo.AssertNotCalled(t, "ListByEntity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("viewer reads list history", func(t *testing.T) {
+		historyRepo := new(MockHistoryRepository)
+		listRepo := memberRoles()
+		service := NewHistoryService(historyRepo, new(MockTaskRepository), listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		historyRepo.On("ListByEntity", domain.HistoryEntityList, "list-1", 20, 0).Return([]domain.HistoryEntry{}, 0, nil)
+
+		_, _, err := service.ListHistory(userContext("viewer"), "list-1", 20, 0)
+		assert.NoError(t, err)
+
+		_, _, err = service.ListHistory(userContext("stranger"), "list-1", 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+)
+
+// ErrForbidden — у пользователя недостаточно прав в списке
+var ErrForbidden = errors.New("FORBIDDEN")
+
+// memberID возвращает ID пользователя, от имени которого выполняется запрос:
+// вошедшего пользователя или пользователя API-ключа. Пустая строка — запрос
+// не ограничивается участием в списках: так работают ключ с правом admin без
+// пользователя и запрос при выключенной аутентификации. Остальные запросы
+// без пользователя получают ErrForbidden.
+func memberID(ctx context.Context) (string, error) {
+	if userID := requestctx.UserID(ctx); userID != "" {
+		return userID, nil
+	}
+	if principal, ok := requestctx.Principal(ctx); ok {
+		if principal.Allows(domain.ScopeAdmin) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%w: credentials are not bound to a user", ErrForbidden)
+	}
+	if requestctx.AuthDisabled(ctx) {
+		return "", nil
+	}
+	return "", fmt.Errorf("%w: request is not authenticated", ErrForbidden)
+}
+
+// checkListRole проверяет, что у пользователя запроса есть в списке роль не ниже required.
+// Список, в котором пользователь не состоит, для него не существует: ErrNotFound.
+func checkListRole(ctx context.Context, repo storage.ListRepository, listID string, required domain.ListRole) error {
+	userID, err := memberID(ctx)
+	if err != nil || userID == "" {
+		return err
+	}
+
+	role, err := repo.GetMemberRole(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Covers(required) {
+		return fmt.Errorf("%w: %s role is required, current role is %s", ErrForbidden, required, role)
+	}
+	return nil
+}
+
+// getTaskWithRole получает задачу из рабочего пространства запроса и проверяет,
+// что у пользователя запроса есть в ее списке роль не ниже required
+func getTaskWithRole(ctx context.Context, taskRepo storage.TaskRepository, listRepo storage.ListRepository, taskID string, required domain.ListRole) (domain.Task, error) {
+	task, err := taskRepo.GetByIDTask(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkListRole(ctx, listRepo, task.ListID, required); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
@@ -1,14 +1,22 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
 	"RestApi/internal/storage"
+	"RestApi/internal/storage/postgres"
 )
 
-var ErrValidation = errors.New("VALIDATION_FAILED")
+var (
+	ErrValidation = errors.New("VALIDATION_FAILED")
+	// ErrConflict — операция невозможна в текущем состоянии объекта
+	ErrConflict = errors.New("CONFLICT")
+)
 
 type ListService struct {
 	repo storage.ListRepository
@@ -18,35 +26,248 @@ func NewListService(repo storage.ListRepository) *ListService {
 	return &ListService{repo: repo}
 }
 
-func (l *ListService) Create(title string) (domain.List, error) {
-	if err := validateTitle(title); err != nil {
+// MaxDescriptionLength — максимальная длина описания списка в символах
+const MaxDescriptionLength = 1000
+
+// Create создает список; пользователь запроса становится его владельцем.
+// Без пользователя список можно создать только при выключенной аутентификации
+func (l *ListService) Create(ctx context.Context, request domain.CreateListRequest) (domain.List, error) {
+	if err := validateTitle(request.Title); err != nil {
+		return domain.List{}, err
+	}
+	if err := validateDescription(request.Description); err != nil {
+		return domain.List{}, err
+	}
+	owner, err := memberID(ctx)
+	if err != nil {
 		return domain.List{}, err
 	}
-	return l.repo.Create(title)
+	if owner == "" && !requestctx.AuthDisabled(ctx) {
+		return domain.List{}, fmt.Errorf("%w: list must be created on behalf of a user to have an owner", ErrForbidden)
+	}
+	return l.repo.Create(ctx, request.Title, request.Description)
 }
 
-func (l *ListService) GetByID(id string) (domain.List, error) {
-	return l.repo.GetByID(id)
+func (l *ListService) GetByID(ctx context.Context, id string) (domain.List, error) {
+	list, err := l.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.List{}, err
+	}
+	if err := checkListRole(ctx, l.repo, id, domain.RoleViewer); err != nil {
+		return domain.List{}, err
+	}
+	return list, nil
 }
 
-func (l *ListService) SearchByTitle(query string) ([]domain.List, error) {
-	return l.repo.SearchByTitle(query)
+// SearchByTitle ищет списки по названию среди списков, доступных пользователю запроса.
+// Пустой способ поиска — поиск подстроки
+func (l *ListService) SearchByTitle(ctx context.Context, search domain.TitleSearch, filter domain.ListFilter) ([]domain.List, error) {
+	if search.Mode == "" {
+		search.Mode = domain.TitleSearchSubstring
+	}
+	if !search.Mode.Valid() {
+		return nil, fmt.Errorf("%w: mode must be one of substring, fuzzy", ErrValidation)
+	}
+	if search.Mode == domain.TitleSearchFuzzy && (search.Threshold <= 0 || search.Threshold > 1) {
+		return nil, fmt.Errorf("%w: threshold must be greater than 0 and at most 1", ErrValidation)
+	}
+
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter.MemberID = member
+	return l.repo.SearchByTitle(ctx, search, filter)
 }
 
-func (l *ListService) Update(id string, title string) (domain.List, error) {
+// Update частично обновляет список: меняются только переданные поля
+func (l *ListService) Update(ctx context.Context, id string, request domain.UpdateListRequest) (domain.List, error) {
+	list, err := l.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.List{}, err
+	}
+	if err := checkListRole(ctx, l.repo, id, domain.RoleEditor); err != nil {
+		return domain.List{}, err
+	}
 
-	if err := validateTitle(title); err != nil {
+	if request.Title != nil {
+		if err := validateTitle(*request.Title); err != nil {
+			return domain.List{}, err
+		}
+		list.Title = *request.Title
+	}
+	if request.Description != nil {
+		if err := validateDescription(*request.Description); err != nil {
+			return domain.List{}, err
+		}
+		list.Description = *request.Description
+	}
+
+	return l.repo.Update(ctx, list)
+}
+
+// Delete перемещает список вместе с задачами в корзину
+func (l *ListService) Delete(ctx context.Context, id string) error {
+	if err := checkListRole(ctx, l.repo, id, domain.RoleOwner); err != nil {
+		return err
+	}
+	return l.repo.Delete(ctx, id)
+}
+
+// Restore возвращает список из корзины вместе с задачами, удаленными вместе с ним
+func (l *ListService) Restore(ctx context.Context, id string) (domain.List, error) {
+	if err := checkListRole(ctx, l.repo, id, domain.RoleOwner); err != nil {
 		return domain.List{}, err
 	}
-	return l.repo.Update(id, title)
+	return l.repo.Restore(ctx, id)
+}
+
+// List возвращает страницу списков, доступных пользователю запроса
+func (l *ListService) List(ctx context.Context, filter domain.ListFilter, limit, offset int) ([]domain.List, int, error) {
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.MemberID = member
+	return l.repo.List(ctx, filter, limit, offset)
 }
 
-func (l *ListService) Delete(id string) error {
-	return l.repo.Delete(id)
+// ListByCursor возвращает страницу списков, доступных пользователю запроса, после или
+// перед курсором (nil — первая страница) и курсоры соседних страниц
+func (l *ListService) ListByCursor(ctx context.Context, filter domain.ListFilter, cursor *domain.Cursor, limit int) ([]domain.List, domain.CursorPage, error) {
+	if limit <= 0 {
+		return nil, domain.CursorPage{}, fmt.Errorf("%w: limit must be positive for cursor pagination", ErrValidation)
+	}
+
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, domain.CursorPage{}, err
+	}
+	filter.MemberID = member
+	lists, more, err := l.repo.ListByCursor(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, domain.CursorPage{}, err
+	}
+
+	var first, last *domain.Cursor
+	if len(lists) > 0 {
+		first = &domain.Cursor{CreatedAt: lists[0].CreatedAt, ID: lists[0].ID}
+		last = &domain.Cursor{CreatedAt: lists[len(lists)-1].CreatedAt, ID: lists[len(lists)-1].ID}
+	}
+	return lists, domain.NewCursorPage(cursor, first, last, more), nil
 }
 
-func (l *ListService) List(limit, offset int) ([]domain.List, int, error) {
-	return l.repo.List(limit, offset)
+// Archive переносит список в архив: он скрывается из выдачи и не принимает новые задачи
+func (l *ListService) Archive(ctx context.Context, id string) (domain.List, error) {
+	if err := checkListRole(ctx, l.repo, id, domain.RoleOwner); err != nil {
+		return domain.List{}, err
+	}
+	return l.repo.SetArchived(ctx, id, true)
+}
+
+// Unarchive возвращает список из архива
+func (l *ListService) Unarchive(ctx context.Context, id string) (domain.List, error) {
+	if err := checkListRole(ctx, l.repo, id, domain.RoleOwner); err != nil {
+		return domain.List{}, err
+	}
+	return l.repo.SetArchived(ctx, id, false)
+}
+
+// Stats считает статистику выполнения задач списка
+func (l *ListService) Stats(ctx context.Context, id string) (domain.ListStats, error) {
+	if _, err := l.GetByID(ctx, id); err != nil {
+		return domain.ListStats{}, err
+	}
+
+	stats, err := l.repo.Stats(ctx, id)
+	if err != nil {
+		return domain.ListStats{}, err
+	}
+	if stats.Total > 0 {
+		stats.CompletionRate = float64(stats.Completed) / float64(stats.Total)
+	}
+	return stats, nil
+}
+
+// ListMembers возвращает участников списка
+func (l *ListService) ListMembers(ctx context.Context, listID string) ([]domain.ListMember, error) {
+	if _, err := l.GetByID(ctx, listID); err != nil {
+		return nil, err
+	}
+	return l.repo.ListMembers(ctx, listID)
+}
+
+// SetMember приглашает пользователя в список или меняет его роль.
+// Управлять участниками может только владелец; у списка всегда остается хотя бы один владелец.
+func (l *ListService) SetMember(ctx context.Context, listID, userID string, request domain.SetListMemberRequest) (domain.ListMember, error) {
+	if !request.Role.Valid() {
+		return domain.ListMember{}, fmt.Errorf("%w: role must be one of owner, editor, viewer", ErrValidation)
+	}
+	if _, err := l.repo.GetByID(ctx, listID); err != nil {
+		return domain.ListMember{}, err
+	}
+	if err := checkListRole(ctx, l.repo, listID, domain.RoleOwner); err != nil {
+		return domain.ListMember{}, err
+	}
+
+	if request.Role != domain.RoleOwner {
+		if err := l.checkNotLastOwner(ctx, listID, userID); err != nil {
+			return domain.ListMember{}, err
+		}
+	}
+
+	member, err := l.repo.SetMember(ctx, domain.ListMember{ListID: listID, UserID: userID, Role: request.Role})
+	if errors.Is(err, postgres.ErrNotFound) {
+		return domain.ListMember{}, fmt.Errorf("%w: user %s not found", ErrValidation, userID)
+	}
+	return member, err
+}
+
+// RemoveMember исключает пользователя из списка. Владелец может исключить
+// любого участника, остальные участники — только выйти из списка сами.
+func (l *ListService) RemoveMember(ctx context.Context, listID, userID string) error {
+	if _, err := l.repo.GetByID(ctx, listID); err != nil {
+		return err
+	}
+
+	member, err := memberID(ctx)
+	if err != nil {
+		return err
+	}
+	required := domain.RoleOwner
+	if member != "" && userID == member {
+		required = domain.RoleViewer
+	}
+	if err := checkListRole(ctx, l.repo, listID, required); err != nil {
+		return err
+	}
+
+	if err := l.checkNotLastOwner(ctx, listID, userID); err != nil {
+		return err
+	}
+	return l.repo.RemoveMember(ctx, listID, userID)
+}
+
+// checkNotLastOwner проверяет, что пользователь не единственный владелец списка:
+// иначе списком стало бы некому управлять
+func (l *ListService) checkNotLastOwner(ctx context.Context, listID, userID string) error {
+	members, err := l.repo.ListMembers(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	owners := 0
+	isOwner := false
+	for _, member := range members {
+		if member.Role == domain.RoleOwner {
+			owners++
+			isOwner = isOwner || member.UserID == userID
+		}
+	}
+	if isOwner && owners == 1 {
+		return fmt.Errorf("%w: list must keep at least one owner", ErrConflict)
+	}
+	return nil
 }
 
 func validateTitle(title string) error {
@@ -55,3 +276,10 @@ func validateTitle(title string) error {
 	}
 	return nil
 }
+
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d chars", ErrValidation, MaxDescriptionLength)
+	}
+	return nil
+}
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListService_Create(t *testing.T) {
+	t.Run("with description", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("Create", "Покупки", "На неделю").
+			Return(domain.List{ID: "list-1", Title: "Покупки", Description: "На неделю"}, nil)
+
+		result, err := service.Create(authDisabledContext(), domain.CreateListRequest{Title: "Покупки", Description: "На неделю"})
+		assert.NoError(t, err)
+		assert.Equal(t, "На неделю", result.Description)
+		listRepo.AssertExpectations(t)
+	})
+
+	t.Run("description length is counted in characters", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		longest := strings.Repeat("я", MaxDescriptionLength)
+		listRepo.On("Create", "Покупки", longest).Return(domain.List{ID: "list-1"}, nil)
+
+		_, err := service.Create(authDisabledContext(), domain.CreateListRequest{Title: "Покупки", Description: longest})
+		assert.NoError(t, err)
+
+		_, err = service.Create(authDisabledContext(), domain.CreateListRequest{Title: "Покупки", Description: longest + "я"})
+		assert.ErrorIs(t, err, ErrValidation)
+		listRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+}
+
+func TestListService_Update(t *testing.T) {
+	current := domain.List{ID: "list-1", Title: "Покупки", Description: "На неделю"}
+
+	t.Run("only description", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(current, nil)
+		listRepo.On("Update", domain.List{ID: "list-1", Title: "Покупки", Description: "На месяц"}).
+			Return(domain.List{ID: "list-1", Title: "Покупки", Description: "На месяц"}, nil)
+
+		description := "На месяц"
+		result, err := service.Update(authDisabledContext(), "list-1", domain.UpdateListRequest{Description: &description})
+		assert.NoError(t, err)
+		assert.Equal(t, "Покупки", result.Title)
+		listRepo.AssertExpectations(t)
+	})
+
+	t.Run("only title keeps description", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(current, nil)
+		listRepo.On("Update", domain.List{ID: "list-1", Title: "Дом", Description: "На неделю"}).
+			Return(domain.List{ID: "list-1", Title: "Дом", Description: "На неделю"}, nil)
+
+		title := "Дом"
+		_, err := service.Update(authDisabledContext(), "list-1", domain.UpdateListRequest{Title: &title})
+		assert.NoError(t, err)
+		listRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid title", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(current, nil)
+
+		title := ""
+		_, err := service.Update(authDisabledContext(), "list-1", domain.UpdateListRequest{Title: &title})
+		assert.ErrorIs(t, err, ErrValidation)
+		listRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("list not found", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "missing").Return(domain.List{}, postgres.ErrNotFound)
+
+		title := "Дом"
+		_, err := service.Update(authDisabledContext(), "missing", domain.UpdateListRequest{Title: &title})
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestListService_Archive(t *testing.T) {
+	listRepo := new(MockListRepository)
+	service := NewListService(listRepo)
+
+	listRepo.On("SetArchived", "list-1", true).Return(domain.List{ID: "list-1"}, nil)
+	listRepo.On("SetArchived", "list-1", false).Return(domain.List{ID: "list-1"}, nil)
+	listRepo.On("SetArchived", "missing", true).Return(domain.List{}, postgres.ErrNotFound)
+
+	_, err := service.Archive(authDisabledContext(), "list-1")
+	assert.NoError(t, err)
+	_, err = service.Unarchive(authDisabledContext(), "list-1")
+	assert.NoError(t, err)
+	_, err = service.Archive(authDisabledContext(), "missing")
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+	listRepo.AssertExpectations(t)
+}
+
+func TestListService_SearchByTitle(t *testing.T) {
+	t.Run("fuzzy search", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		search := domain.TitleSearch{Query: "Покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.4}
+		listRepo.On("SearchByTitle", search, domain.ListFilter{}).Return([]domain.List{{ID: "list-1", Title: "Покупки"}}, nil)
+
+		lists, err := service.SearchByTitle(authDisabledContext(), search, domain.ListFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, lists, 1)
+	})
+
+	for name, search := range map[string]domain.TitleSearch{
+		"unknown mode":   {Query: "Покупки", Mode: "regex"},
+		"zero threshold": {Query: "Покупки", Mode: domain.TitleSearchFuzzy},
+		"threshold > 1":  {Query: "Покупки", Mode: domain.TitleSearchFuzzy, Threshold: 1.5},
+	} {
+		t.Run(name, func(t *testing.T) {
+			listRepo := new(MockListRepository)
+			service := NewListService(listRepo)
+
+			_, err := service.SearchByTitle(authDisabledContext(), search, domain.ListFilter{})
+			assert.ErrorIs(t, err, ErrValidation)
+			listRepo.AssertNotCalled(t, "SearchByTitle", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListService_ListByCursor(t *testing.T) {
+	t.Run("first page", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		lists := []domain.List{{ID: "00000000-0000-0000-0000-000000000001", CreatedAt: createdAt}}
+		listRepo.On("ListByCursor", domain.ListFilter{}, (*domain.Cursor)(nil), 1).Return(lists, true, nil)
+
+		_, page, err := service.ListByCursor(authDisabledContext(), domain.ListFilter{}, nil, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, page.Prev)
+
+		next, err := domain.ParseCursor(page.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Cursor{CreatedAt: createdAt, ID: lists[0].ID}, next)
+	})
+
+	t.Run("zero limit", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		_, _, err := service.ListByCursor(authDisabledContext(), domain.ListFilter{}, nil, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+		listRepo.AssertNotCalled(t, "ListByCursor", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListService_Stats(t *testing.T) {
+	t.Run("completion rate", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		median := 3600.0
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		listRepo.On("Stats", "list-1").Return(domain.ListStats{
+			ListID: "list-1", Total: 4, Open: 3, Completed: 1, MedianTimeToCompleteSeconds: &median,
+		}, nil)
+
+		stats, err := service.Stats(authDisabledContext(), "list-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.25, stats.CompletionRate)
+		assert.Equal(t, &median, stats.MedianTimeToCompleteSeconds)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		listRepo.On("Stats", "list-1").Return(domain.ListStats{ListID: "list-1"}, nil)
+
+		stats, err := service.Stats(authDisabledContext(), "list-1")
+		assert.NoError(t, err)
+		assert.Zero(t, stats.CompletionRate)
+		assert.Nil(t, stats.MedianTimeToCompleteSeconds)
+	})
+
+	t.Run("list not found", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "missing").Return(domain.List{}, postgres.ErrNotFound)
+
+		_, err := service.Stats(authDisabledContext(), "missing")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		listRepo.AssertNotCalled(t, "Stats", mock.Anything)
+	})
+}
+
+// userContext возвращает контекст запроса от имени пользователя
+func userContext(userID string) context.Context {
+	return requestctx.WithPrincipal(context.Background(), domain.Principal{Type: domain.PrincipalUser, ID: userID})
+}
+
+// authDisabledContext возвращает контекст запроса при выключенной аутентификации:
+// запросы без пользователя не ограничиваются ролями в списках только в этом режиме
+func authDisabledContext() context.Context {
+	return requestctx.WithAuthDisabled(context.Background())
+}
+
+// memberRoles возвращает мок репозитория списков, в котором пользователи viewer и editor
+// состоят в списке list-1 с одноименными ролями, а остальные в нем не состоят
+func memberRoles() *MockListRepository {
+	listRepo := new(MockListRepository)
+	listRepo.On("GetMemberRole", "list-1", "viewer").Return(domain.RoleViewer, nil)
+	listRepo.On("GetMemberRole", "list-1", "editor").Return(domain.RoleEditor, nil)
+	listRepo.On("GetMemberRole", mock.Anything, mock.Anything).Return(domain.ListRole(""), postgres.ErrNotFound)
+	return listRepo
+}
+
+func TestListService_Access(t *testing.T) {
+	list := domain.List{ID: "list-1", Title: "Покупки"}
+
+	t.Run("lists are filtered by membership", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("List", domain.ListFilter{MemberID: "user-1"}, 20, 0).Return([]domain.List{list}, 1, nil)
+		listRepo.On("SearchByTitle", domain.TitleSearch{Query: "Пок", Mode: domain.TitleSearchSubstring}, domain.ListFilter{MemberID: "user-1"}).Return([]domain.List{list}, nil)
+		listRepo.On("List", domain.ListFilter{}, 20, 0).Return([]domain.List{list}, 1, nil)
+
+		_, _, err := service.List(userContext("user-1"), domain.ListFilter{}, 20, 0)
+		assert.NoError(t, err)
+		_, err = service.SearchByTitle(userContext("user-1"), domain.TitleSearch{Query: "Пок"}, domain.ListFilter{})
+		assert.NoError(t, err)
+		_, _, err = service.List(authDisabledContext(), domain.ListFilter{}, 20, 0)
+		assert.NoError(t, err)
+		listRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-member does not see the list", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(list, nil)
+		listRepo.On("GetMemberRole", "list-1", "user-2").Return(domain.ListRole(""), postgres.ErrNotFound)
+
+		_, err := service.GetByID(userContext("user-2"), "list-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+
+	t.Run("viewer cannot edit", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetByID", "list-1").Return(list, nil)
+		listRepo.On("GetMemberRole", "list-1", "user-1").Return(domain.RoleViewer, nil)
+
+		_, err := service.GetByID(userContext("user-1"), "list-1")
+		assert.NoError(t, err)
+
+		title := "Продукты"
+		_, err = service.Update(userContext("user-1"), "list-1", domain.UpdateListRequest{Title: &title})
+		assert.ErrorIs(t, err, ErrForbidden)
+		listRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("only owner deletes", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		listRepo.On("GetMemberRole", "list-1", "editor").Return(domain.RoleEditor, nil)
+		listRepo.On("GetMemberRole", "list-1", "owner").Return(domain.RoleOwner, nil)
+		listRepo.On("Delete", "list-1").Return(nil)
+
+		assert.ErrorIs(t, service.Delete(userContext("editor"), "list-1"), ErrForbidden)
+		assert.NoError(t, service.Delete(userContext("owner"), "list-1"))
+		listRepo.AssertNumberOfCalls(t, "Delete", 1)
+	})
+
+	t.Run("api key acts as its user", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		ctx := requestctx.WithPrincipal(context.Background(), domain.Principal{
+			Type:   domain.PrincipalAPIKey,
+			ID:     "key-1",
+			Scopes: []domain.APIKeyScope{domain.ScopeWrite},
+			UserID: "viewer",
+		})
+		listRepo.On("GetMemberRole", "list-1", "viewer").Return(domain.RoleViewer, nil)
+		listRepo.On("List", domain.ListFilter{MemberID: "viewer"}, 20, 0).Return([]domain.List{list}, 1, nil)
+
+		assert.ErrorIs(t, service.Delete(ctx, "list-1"), ErrForbidden)
+		_, _, err := service.List(ctx, domain.ListFilter{}, 20, 0)
+		assert.NoError(t, err)
+		listRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("request without user", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		key := domain.Principal{Type: domain.PrincipalAPIKey, ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeWrite}}
+		admin := domain.Principal{Type: domain.PrincipalAPIKey, ID: "key-2", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}}
+		listRepo.On("Delete", "list-1").Return(nil)
+
+		// Без пользователя проверки пропускают только ключ admin и выключенная аутентификация
+		assert.ErrorIs(t, service.Delete(requestctx.WithPrincipal(context.Background(), key), "list-1"), ErrForbidden)
+		assert.ErrorIs(t, service.Delete(context.Background(), "list-1"), ErrForbidden)
+		assert.NoError(t, service.Delete(requestctx.WithPrincipal(context.Background(), admin), "list-1"))
+		assert.NoError(t, service.Delete(authDisabledContext(), "list-1"))
+		listRepo.AssertNumberOfCalls(t, "Delete", 2)
+		listRepo.AssertNotCalled(t, "GetMemberRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("list always gets an owner", func(t *testing.T) {
+		listRepo := new(MockListRepository)
+		service := NewListService(listRepo)
+
+		admin := domain.Principal{Type: domain.PrincipalAPIKey, ID: "key-2", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}}
+		listRepo.On("Create", "Покупки", "").Return(list, nil)
+
+		_, err := service.Create(requestctx.WithPrincipal(context.Background(), admin), domain.CreateListRequest{Title: "Покупки"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		_, err = service.Create(userContext("owner"), domain.CreateListRequest{Title: "Покупки"})
+		assert.NoError(t, err)
+		_, err = service.Create(authDisabledContext(), domain.CreateListRequest{Title: "Покупки"})
+		assert.NoError(t, err)
+		listRepo.AssertNumberOfCalls(t, "Create", 2)
+	})
+}
+
+func TestListService_Members(t *testing.T) {
+	list := domain.List{ID: "list-1", Title: "Покупки"}
+	members := []domain.ListMember{
+		{ListID: "list-1", UserID: "owner", Role: domain.RoleOwner},
+		{ListID: "list-1", UserID: "editor", Role: domain.RoleEditor},
+	}
+
+	newService := func() (*ListService, *MockListRepository) {
+		listRepo := new(MockListRepository)
+		listRepo.On("GetByID", "list-1").Return(list, nil)
+		listRepo.On("GetMemberRole", "list-1", "owner").Return(domain.RoleOwner, nil)
+		listRepo.On("GetMemberRole", "list-1", "editor").Return(domain.RoleEditor, nil)
+		listRepo.On("ListMembers", "list-1").Return(members, nil)
+		return NewListService(listRepo), listRepo
+	}
+
+	t.Run("owner invites user", func(t *testing.T) {
+		service, listRepo := newService()
+		invited := domain.ListMember{ListID: "list-1", UserID: "viewer", Role: domain.RoleViewer}
+		listRepo.On("SetMember", invited).Return(invited, nil)
+
+		member, err := service.SetMember(userContext("owner"), "list-1", "viewer", domain.SetListMemberRequest{Role: domain.RoleViewer})
+		assert.NoError(t, err)
+		assert.Equal(t, invited, member)
+	})
+
+	t.Run("editor cannot invite", func(t *testing.T) {
+		service, listRepo := newService()
+
+		_, err := service.SetMember(userContext("editor"), "list-1", "viewer", domain.SetListMemberRequest{Role: domain.RoleViewer})
+		assert.ErrorIs(t, err, ErrForbidden)
+		listRepo.AssertNotCalled(t, "SetMember", mock.Anything)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.SetMember(userContext("owner"), "list-1", "viewer", domain.SetListMemberRequest{Role: "admin"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		service, listRepo := newService()
+		listRepo.On("SetMember", mock.Anything).Return(domain.ListMember{}, postgres.ErrNotFound)
+
+		_, err := service.SetMember(userContext("owner"), "list-1", "missing", domain.SetListMemberRequest{Role: domain.RoleEditor})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("last owner cannot step down", func(t *testing.T) {
+		service, listRepo := newService()
+
+		_, err := service.SetMember(userContext("owner"), "list-1", "owner", domain.SetListMemberRequest{Role: domain.RoleEditor})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.ErrorIs(t, service.RemoveMember(userContext("owner"), "list-1", "owner"), ErrConflict)
+		listRepo.AssertNotCalled(t, "SetMember", mock.Anything)
+		listRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("member leaves list", func(t *testing.T) {
+		service, listRepo := newService()
+		listRepo.On("RemoveMember", "list-1", "editor").Return(nil)
+
+		assert.NoError(t, service.RemoveMember(userContext("editor"), "list-1", "editor"))
+		assert.ErrorIs(t, service.RemoveMember(userContext("editor"), "list-1", "owner"), ErrForbidden)
+		listRepo.AssertNumberOfCalls(t, "RemoveMember", 1)
+	})
+}
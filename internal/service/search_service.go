@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage"
+)
+
+// MaxSearchQueryLength — максимальная длина поискового запроса в символах
+const MaxSearchQueryLength = 200
+
+type SearchService struct {
+	repo storage.SearchRepository
+}
+
+func NewSearchService(repo storage.SearchRepository) *SearchService {
+	return &SearchService{
+		repo: repo,
+	}
+}
+
+// Search ищет списки и задачи, доступные пользователю запроса
+func (s *SearchService) Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.SearchResult, int, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" || utf8.RuneCountInString(filter.Query) > MaxSearchQueryLength {
+		return nil, 0, fmt.Errorf("%w: q must be 1..%d chars", ErrValidation, MaxSearchQueryLength)
+	}
+	if filter.Type != "" && !filter.Type.Valid() {
+		return nil, 0, fmt.Errorf("%w: type must be one of list, task", ErrValidation)
+	}
+	if filter.Completed != nil && filter.Type == domain.SearchItemList {
+		return nil, 0, fmt.Errorf("%w: completed applies only to tasks", ErrValidation)
+	}
+
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.MemberID = member
+	return s.repo.Search(ctx, filter, limit, offset)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для SearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.SearchResult, int, error) {
+	args := m.Called(filter, limit, offset)
+	return args.Get(0).([]domain.SearchResult), args.Int(1), args.Error(2)
+}
+
+func TestSearchService_Search(t *testing.T) {
+	t.Run("trimmed query", func(t *testing.T) {
+		repo := new(MockSearchRepository)
+		service := NewSearchService(repo)
+
+		repo.On("Search", domain.SearchFilter{Query: "молоко", Type: domain.SearchItemTask}, 20, 0).
+			Return([]domain.SearchResult{{Type: domain.SearchItemTask, ID: "task-1"}}, 1, nil)
+
+		results, total, err := service.Search(authDisabledContext(), domain.SearchFilter{Query: "  молоко ", Type: domain.SearchItemTask}, 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("user sees only own lists", func(t *testing.T) {
+		repo := new(MockSearchRepository)
+		service := NewSearchService(repo)
+
+		repo.On("Search", domain.SearchFilter{Query: "молоко", MemberID: "user-1"}, 20, 0).
+			Return([]domain.SearchResult{}, 0, nil)
+
+		ctx := requestctx.WithPrincipal(authDisabledContext(), domain.Principal{Type: domain.PrincipalUser, ID: "user-1"})
+		_, _, err := service.Search(ctx, domain.SearchFilter{Query: "молоко"}, 20, 0)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	completed := true
+	for name, filter := range map[string]domain.SearchFilter{
+		"empty query":          {Query: "   "},
+		"long query":           {Query: strings.Repeat("я", MaxSearchQueryLength+1)},
+		"unknown type":         {Query: "молоко", Type: "comment"},
+		"completed with lists": {Query: "молоко", Type: domain.SearchItemList, Completed: &completed},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := new(MockSearchRepository)
+			service := NewSearchService(repo)
+
+			_, _, err := service.Search(authDisabledContext(), filter, 20, 0)
+			assert.ErrorIs(t, err, ErrValidation)
+			repo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
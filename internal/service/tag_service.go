@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage"
+)
+
+type TagService struct {
+	repo     storage.TagRepository
+	taskRepo storage.TaskRepository
+	listRepo storage.ListRepository
+}
+
+func NewTagService(repo storage.TagRepository, taskRepo storage.TaskRepository, listRepo storage.ListRepository) *TagService {
+	return &TagService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		listRepo: listRepo,
+	}
+}
+
+func (s *TagService) Create(ctx context.Context, name string) (domain.Tag, error) {
+	name = strings.TrimSpace(name)
+	if err := validateTagName(name); err != nil {
+		return domain.Tag{}, err
+	}
+	return s.repo.Create(ctx, name)
+}
+
+func (s *TagService) GetByID(ctx context.Context, id string) (domain.Tag, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *TagService) List(ctx context.Context, limit, offset int) ([]domain.Tag, int, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+func (s *TagService) Update(ctx context.Context, id string, name string) (domain.Tag, error) {
+	name = strings.TrimSpace(name)
+	if err := validateTagName(name); err != nil {
+		return domain.Tag{}, err
+	}
+	return s.repo.Update(ctx, id, name)
+}
+
+func (s *TagService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// AttachToTask назначает метку задаче, предварительно проверив существование обеих
+// и право пользователя запроса менять список задачи
+func (s *TagService) AttachToTask(ctx context.Context, taskID, tagID string) error {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleEditor); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetByID(ctx, tagID); err != nil {
+		return err
+	}
+	return s.repo.AttachToTask(ctx, taskID, tagID)
+}
+
+// DetachFromTask снимает метку с задачи из рабочего пространства запроса
+func (s *TagService) DetachFromTask(ctx context.Context, taskID, tagID string) error {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleEditor); err != nil {
+		return err
+	}
+	return s.repo.DetachFromTask(ctx, taskID, tagID)
+}
+
+func (s *TagService) ListByTask(ctx context.Context, taskID string) ([]domain.Tag, error) {
+	if _, err := getTaskWithRole(ctx, s.taskRepo, s.listRepo, taskID, domain.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByTask(ctx, taskID)
+}
+
+func validateTagName(name string) error {
+	if len(name) == 0 || len(name) > 50 {
+		return fmt.Errorf("%w: name must be 1..50 chars", ErrValidation)
+	}
+	return nil
+}
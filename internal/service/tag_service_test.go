@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, name string) (domain.Tag, error) {
+	args := m.Called(name)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByID(ctx context.Context, id string) (domain.Tag, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) List(ctx context.Context, limit, offset int) ([]domain.Tag, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]domain.Tag), args.Int(1), args.Error(2)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, id, name string) (domain.Tag, error) {
+	args := m.Called(id, name)
+	return args.Get(0).(domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) AttachToTask(ctx context.Context, taskID, tagID string) error {
+	args := m.Called(taskID, tagID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) DetachFromTask(ctx context.Context, taskID, tagID string) error {
+	args := m.Called(taskID, tagID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) ListByTask(ctx context.Context, taskID string) ([]domain.Tag, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+func TestTagService_Create(t *testing.T) {
+	t.Run("trims name", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		service := NewTagService(tagRepo, new(MockTaskRepository), new(MockListRepository))
+
+		tagRepo.On("Create", "backend").Return(domain.Tag{ID: "tag-1", Name: "backend"}, nil)
+
+		tag, err := service.Create(authDisabledContext(), "  backend ")
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", tag.Name)
+		tagRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects empty and too long names", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		service := NewTagService(tagRepo, new(MockTaskRepository), new(MockListRepository))
+
+		_, err := service.Create(authDisabledContext(), "   ")
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = service.Create(authDisabledContext(), strings.Repeat("a", 51))
+		assert.ErrorIs(t, err, ErrValidation)
+
+		tagRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTagService_AttachToTask(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewTagService(tagRepo, taskRepo, new(MockListRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		tagRepo.On("GetByID", "tag-1").Return(domain.Tag{ID: "tag-1"}, nil)
+		tagRepo.On("AttachToTask", "task-1", "tag-1").Return(nil)
+
+		assert.NoError(t, service.AttachToTask(authDisabledContext(), "task-1", "tag-1"))
+		tagRepo.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("task not found", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		taskRepo := new(MockTaskRepository)
+		service := NewTagService(tagRepo, taskRepo, new(MockListRepository))
+
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+
+		err := service.AttachToTask(authDisabledContext(), "missing", "tag-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		tagRepo.AssertNotCalled(t, "AttachToTask", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTagService_ListAccess(t *testing.T) {
+	newService := func() (*TagService, *MockTagRepository) {
+		tagRepo := new(MockTagRepository)
+		taskRepo := new(MockTaskRepository)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1"}, nil)
+		tagRepo.On("GetByID", "tag-1").Return(domain.Tag{ID: "tag-1"}, nil)
+		return NewTagService(tagRepo, taskRepo, memberRoles()), tagRepo
+	}
+
+	t.Run("viewer reads but cannot change task tags", func(t *testing.T) {
+		service, tagRepo := newService()
+		tagRepo.On("ListByTask", "task-1").Return([]domain.Tag{}, nil)
+
+		_, err := service.ListByTask(userContext("viewer"), "task-1")
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, service.AttachToTask(userContext("viewer"), "task-1", "tag-1"), ErrForbidden)
+		assert.ErrorIs(t, service.DetachFromTask(userContext("viewer"), "task-1", "tag-1"), ErrForbidden)
+		tagRepo.AssertNotCalled(t, "AttachToTask", mock.Anything, mock.Anything)
+		tagRepo.AssertNotCalled(t, "DetachFromTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("non-member does not see task tags", func(t *testing.T) {
+		service, _ := newService()
+
+		_, err := service.ListByTask(userContext("stranger"), "task-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+
+	t.Run("editor attaches tags", func(t *testing.T) {
+		service, tagRepo := newService()
+		tagRepo.On("AttachToTask", "task-1", "tag-1").Return(nil)
+
+		assert.NoError(t, service.AttachToTask(userContext("editor"), "task-1", "tag-1"))
+	})
+}
@@ -1,90 +1,1205 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"RestApi/internal/domain"
+	"RestApi/internal/rank"
+	"RestApi/internal/rrule"
 	"RestApi/internal/storage"
 	"RestApi/internal/storage/postgres"
 )
 
+// MaxTaskDepth — максимальная глубина вложенности подзадач.
+// Задача верхнего уровня имеет глубину 1.
+const MaxTaskDepth = 5
+
+// MaxBatchSize — максимальное количество задач в пакетном переносе или копировании
+const MaxBatchSize = 100
+
+// MaxTextContainsLength — максимальная длина подстроки в фильтре по тексту задачи
+const MaxTextContainsLength = 200
+
 type TaskService struct {
 	repo     storage.TaskRepository
 	listRepo storage.ListRepository
+	userRepo storage.UserRepository
+	// workflow — статусы задач и допустимые переходы между ними
+	workflow domain.TaskWorkflow
+	// now — источник текущего времени, подменяется в тестах
+	now func() time.Time
 }
 
-func NewTaskService(repo storage.TaskRepository, listRepo storage.ListRepository) *TaskService {
+func NewTaskService(repo storage.TaskRepository, listRepo storage.ListRepository, userRepo storage.UserRepository) *TaskService {
 	return &TaskService{
 		repo:     repo,
 		listRepo: listRepo,
+		userRepo: userRepo,
+		workflow: domain.DefaultTaskWorkflow(),
+		now:      time.Now,
 	}
 }
 
-func (l *TaskService) CreateTask(listID string, text string) (domain.Task, error) {
-	if err := validateText(text); err != nil {
+// WithWorkflow заменяет рабочий процесс по умолчанию
+func (l *TaskService) WithWorkflow(workflow domain.TaskWorkflow) *TaskService {
+	l.workflow = workflow
+	return l
+}
+
+// Workflow возвращает статусы задач и допустимые переходы
+func (l *TaskService) Workflow() domain.TaskWorkflow {
+	return l.workflow
+}
+
+func (l *TaskService) CreateTask(ctx context.Context, listID string, request domain.CreateTaskRequest) (domain.Task, error) {
+	if err := validateText(request.Text); err != nil {
 		return domain.Task{}, err
 	}
 
-	_, err := l.listRepo.GetByID(listID)
-	if err != nil {
-		if err == postgres.ErrNotFound {
-			return domain.Task{}, fmt.Errorf("%w: list not found", ErrValidation)
+	priority := request.Priority
+	if priority == "" {
+		priority = domain.PriorityNone
+	}
+	if err := validatePriority(priority); err != nil {
+		return domain.Task{}, err
+	}
+
+	status := request.Status
+	if status == "" {
+		status = l.workflow.Initial()
+	}
+	if err := l.validateStatus(status); err != nil {
+		return domain.Task{}, err
+	}
+
+	var recurrence *string
+	if request.RecurrenceRule != nil {
+		rule, err := normalizeRecurrence(*request.RecurrenceRule)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		recurrence = &rule
+	}
+
+	if err := l.checkListWritable(ctx, listID); err != nil {
+		return domain.Task{}, err
+	}
+
+	if request.AssigneeID != nil {
+		if err := l.checkAssignee(ctx, listID, *request.AssigneeID); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	if request.ParentTaskID != nil {
+		parent, err := l.resolveParent(ctx, listID, *request.ParentTaskID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		parentDepth, err := l.taskDepth(ctx, parent)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if parentDepth+1 > MaxTaskDepth {
+			return domain.Task{}, fmt.Errorf("%w: subtasks can be nested at most %d levels deep", ErrValidation, MaxTaskDepth)
 		}
-		return domain.Task{}, fmt.Errorf("failed to check list existence: %w", err)
 	}
 
 	task := domain.Task{
-		ListID:    listID,
-		Text:      text,
-		Completed: false,
+		ListID:         listID,
+		ParentTaskID:   request.ParentTaskID,
+		Text:           request.Text,
+		Priority:       priority,
+		DueAt:          request.DueAt,
+		RecurrenceRule: recurrence,
+		AssigneeID:     request.AssigneeID,
 	}
-	return l.repo.CreateTask(task)
+	task.SetStatus(status)
+	return l.repo.CreateTask(ctx, task)
+}
+
+func (l *TaskService) GetByIDTask(ctx context.Context, id string) (domain.Task, error) {
+	return l.getTask(ctx, id, domain.RoleViewer)
 }
 
-func (l *TaskService) GetByIDTask(id string) (domain.Task, error) {
-	return l.repo.GetByIDTask(id)
+func (l *TaskService) ListTasks(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	filter, err := l.normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkListRole(ctx, l.listRepo, listID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+	return l.repo.ListTasks(ctx, listID, filter, limit, offset)
 }
 
-func (l *TaskService) ListTasks(listID string, limit int, offset int) ([]domain.Task, int, error) {
-	return l.repo.ListTasks(listID, limit, offset)
+// ListTasksByCursor возвращает страницу задач списка после или перед курсором
+// (nil — первая страница) и курсоры соседних страниц. Курсор — позиция по времени
+// создания, поэтому другие порядки сортировки не поддерживаются
+func (l *TaskService) ListTasksByCursor(ctx context.Context, listID string, filter domain.TaskFilter, cursor *domain.Cursor, limit int) ([]domain.Task, domain.CursorPage, error) {
+	filter, err := l.normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, domain.CursorPage{}, err
+	}
+	if len(filter.Sort) > 1 || len(filter.Sort) == 1 && filter.Sort[0].Field != domain.TaskSortCreatedAt {
+		return nil, domain.CursorPage{}, fmt.Errorf("%w: cursor pagination supports only sort=created_at", ErrValidation)
+	}
+	if limit <= 0 {
+		return nil, domain.CursorPage{}, fmt.Errorf("%w: limit must be positive for cursor pagination", ErrValidation)
+	}
+	if err := checkListRole(ctx, l.listRepo, listID, domain.RoleViewer); err != nil {
+		return nil, domain.CursorPage{}, err
+	}
+
+	tasks, more, err := l.repo.ListTasksByCursor(ctx, listID, filter, cursor, limit)
+	if err != nil {
+		return nil, domain.CursorPage{}, err
+	}
+
+	var first, last *domain.Cursor
+	if len(tasks) > 0 {
+		first = &domain.Cursor{CreatedAt: tasks[0].CreatedAt, ID: tasks[0].ID}
+		last = &domain.Cursor{CreatedAt: tasks[len(tasks)-1].CreatedAt, ID: tasks[len(tasks)-1].ID}
+	}
+	return tasks, domain.NewCursorPage(cursor, first, last, more), nil
 }
 
-func (l *TaskService) UpdateTask(id string, text *string, completed *bool) (domain.Task, error) {
+// ListTaskGroups возвращает задачи списка, сгруппированные по статусу.
+// Группы идут в порядке статусов рабочего процесса, пагинация применяется
+// к каждой группе отдельно. total — количество задач во всех группах.
+func (l *TaskService) ListTaskGroups(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.TaskGroup, int, error) {
+	filter, err := l.normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkListRole(ctx, l.listRepo, listID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := l.repo.ListAllTasks(ctx, listID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return groupTasksByStatus(tasks, l.workflow, filter.Statuses, limit, offset), len(tasks), nil
+}
+
+// ListTaskTree возвращает задачи списка в виде дерева.
+// Пагинация применяется к задачам верхнего уровня; задачи, чей родитель
+// не попал под фильтр, считаются задачами верхнего уровня.
+func (l *TaskService) ListTaskTree(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.TaskNode, int, error) {
+	filter, err := l.normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkListRole(ctx, l.listRepo, listID, domain.RoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	tasks, err := l.repo.ListAllTasks(ctx, listID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	roots := buildTaskTree(tasks)
+	total := len(roots)
+
+	if offset >= total {
+		return []domain.TaskNode{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return roots[offset:end], total, nil
+}
+
+// ListSubtasks возвращает непосредственные подзадачи задачи
+func (l *TaskService) ListSubtasks(ctx context.Context, taskID string) ([]domain.Task, error) {
+	if _, err := l.getTask(ctx, taskID, domain.RoleViewer); err != nil {
+		return nil, err
+	}
+	return l.repo.ListSubtasks(ctx, taskID)
+}
+
+// ListAssignedTasks возвращает задачи, назначенные пользователю, во всех списках,
+// доступных пользователю запроса
+func (l *TaskService) ListAssignedTasks(ctx context.Context, userID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	if _, err := l.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	filter, err := l.normalizeTaskFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.MemberID = member
+	return l.repo.ListAssignedTasks(ctx, userID, filter, limit, offset)
+}
+
+// ListOverdueTasks возвращает незавершенные задачи с истекшим сроком во всех списках,
+// доступных пользователю запроса
+func (l *TaskService) ListOverdueTasks(ctx context.Context, limit int, offset int) ([]domain.Task, int, error) {
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return l.repo.ListOverdueTasks(ctx, member, limit, offset)
+}
+
+func (l *TaskService) UpdateTask(ctx context.Context, id string, request domain.UpdateTaskRequest) (domain.Task, error) {
 	fmt.Printf("=== DEBUG UpdateTask Service ===\n")
 	fmt.Printf("ID: %s\n", id)
-	fmt.Printf("Text pointer: %v\n", text)
-	if text != nil {
-		fmt.Printf("Text value: '%s'\n", *text)
-		fmt.Printf("Text length: %d\n", len(*text))
+	fmt.Printf("Text pointer: %v\n", request.Text)
+	if request.Text != nil {
+		fmt.Printf("Text value: '%s'\n", *request.Text)
+		fmt.Printf("Text length: %d\n", len(*request.Text))
 	}
-	fmt.Printf("Completed pointer: %v\n", completed)
+	fmt.Printf("Completed pointer: %v\n", request.Completed)
 	fmt.Printf("==============================\n")
 	// Получаем текущую задачу
-	currentTask, err := l.repo.GetByIDTask(id)
+	currentTask, err := l.getTask(ctx, id, domain.RoleEditor)
 	if err != nil {
 		return domain.Task{}, err
 	}
 
 	// Обновляем текст только если передан
-	newText := currentTask.Text
-	if text != nil {
-		if err := validateText(*text); err != nil {
+	if request.Text != nil {
+		if err := validateText(*request.Text); err != nil {
 			return domain.Task{}, err
 		}
-		newText = *text
+		currentTask.Text = *request.Text
+	}
+
+	wasCompleted := currentTask.Completed
+
+	// Обновляем статус только если передан status или completed
+	status, err := l.requestedStatus(currentTask, request)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !l.workflow.CanTransition(currentTask.Status, status) {
+		return domain.Task{}, fmt.Errorf("%w: status cannot change from %s to %s", ErrConflict, currentTask.Status, status)
+	}
+	currentTask.SetStatus(status)
+
+	if request.Priority != nil {
+		if err := validatePriority(*request.Priority); err != nil {
+			return domain.Task{}, err
+		}
+		currentTask.Priority = *request.Priority
+	}
+
+	// Родителя можно сменить или убрать, но не одновременно
+	if request.ParentTaskID != nil && request.ClearParent {
+		return domain.Task{}, fmt.Errorf("%w: parent_task_id and clear_parent are mutually exclusive", ErrValidation)
+	}
+	if request.ParentTaskID != nil {
+		if err := l.checkReparent(ctx, currentTask, *request.ParentTaskID); err != nil {
+			return domain.Task{}, err
+		}
+		currentTask.ParentTaskID = request.ParentTaskID
+	}
+	if request.ClearParent {
+		currentTask.ParentTaskID = nil
+	}
+
+	// Срок можно задать новый или сбросить, но не одновременно
+	if request.DueAt != nil && request.ClearDueAt {
+		return domain.Task{}, fmt.Errorf("%w: due_at and clear_due_at are mutually exclusive", ErrValidation)
+	}
+	if request.DueAt != nil {
+		currentTask.DueAt = request.DueAt
+	}
+	if request.ClearDueAt {
+		currentTask.DueAt = nil
+	}
+
+	// Правило повторения можно задать новое или убрать, но не одновременно
+	if request.RecurrenceRule != nil && request.ClearRecurrence {
+		return domain.Task{}, fmt.Errorf("%w: recurrence_rule and clear_recurrence are mutually exclusive", ErrValidation)
+	}
+	if request.RecurrenceRule != nil {
+		rule, err := normalizeRecurrence(*request.RecurrenceRule)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		currentTask.RecurrenceRule = &rule
+	}
+	if request.ClearRecurrence {
+		currentTask.RecurrenceRule = nil
+	}
+
+	// Исполнителя можно назначить или снять, но не одновременно
+	if request.AssigneeID != nil && request.ClearAssignee {
+		return domain.Task{}, fmt.Errorf("%w: assignee_id and clear_assignee are mutually exclusive", ErrValidation)
+	}
+	if request.AssigneeID != nil {
+		if err := l.checkAssignee(ctx, currentTask.ListID, *request.AssigneeID); err != nil {
+			return domain.Task{}, err
+		}
+		currentTask.AssigneeID = request.AssigneeID
+	}
+	if request.ClearAssignee {
+		currentTask.AssigneeID = nil
+	}
+
+	statusRequested := request.Status != nil || request.Completed != nil
+	cascade := request.Cascade && statusRequested && currentTask.Completed
+
+	// Подзадачи и блокирующие задачи проверяются в транзакции изменения после блокировки
+	// задачи, чтобы параллельные изменения не обошли проверки
+	check := func(ctx context.Context, before domain.Task, tasks storage.TaskReader) error {
+		// Каскад не обходит рабочий процесс: каждая подзадача должна мочь перейти в статус задачи
+		if cascade {
+			if err := l.checkCascadeTransitions(ctx, tasks, currentTask); err != nil {
+				return err
+			}
+		}
+
+		// Задачу нельзя завершить, пока не выполнены блокирующие ее задачи
+		if !request.Force && (cascade || !before.Completed && currentTask.Completed) {
+			return l.checkBlockers(ctx, tasks, currentTask, before.Completed, cascade)
+		}
+		return nil
+	}
+
+	// Завершение повторяющейся задачи создает следующее повторение.
+	// Правило переходит к новой задаче, поэтому повторное завершение
+	// этой задачи не создаст дубликат. У задачи может быть только одно
+	// следующее повторение, даже если правило задали снова.
+	if !wasCompleted && currentTask.Completed && currentTask.RecurrenceRule != nil {
+		next, ok, err := l.nextOccurrence(ctx, currentTask)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if ok {
+			currentTask.RecurrenceRule = nil
+			updated, _, err := l.repo.CompleteRecurringTask(ctx, currentTask, next, cascade, check)
+			if errors.Is(err, postgres.ErrAlreadyExists) {
+				return domain.Task{}, fmt.Errorf("%w: task already has a next occurrence", ErrConflict)
+			}
+			return updated, err
+		}
+	}
+
+	// Завершение с каскадом завершает и все подзадачи
+	if cascade {
+		return l.repo.UpdateTaskWithSubtasks(ctx, currentTask, check)
+	}
+
+	return l.repo.UpdateTask(ctx, currentTask, check)
+}
+
+// AddDependency делает задачу blockerID блокирующей для задачи taskID:
+// taskID нельзя завершить, пока не выполнена blockerID
+func (l *TaskService) AddDependency(ctx context.Context, taskID, blockerID string) error {
+	if taskID == blockerID {
+		return fmt.Errorf("%w: task cannot block itself", ErrValidation)
+	}
+	if _, err := l.getTask(ctx, taskID, domain.RoleEditor); err != nil {
+		return err
+	}
+	if _, err := l.getTask(ctx, blockerID, domain.RoleViewer); err != nil {
+		return err
+	}
+
+	cycle, err := l.dependsOn(ctx, blockerID, taskID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return fmt.Errorf("%w: dependency would create a cycle", ErrConflict)
+	}
+
+	return l.repo.AddDependency(ctx, taskID, blockerID)
+}
+
+// RemoveDependency убирает зависимость задачи taskID от задачи blockerID
+func (l *TaskService) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	if err := l.checkTaskRole(ctx, taskID, domain.RoleEditor); err != nil {
+		return err
+	}
+	return l.repo.RemoveDependency(ctx, taskID, blockerID)
+}
+
+// ListBlockers возвращает задачи, блокирующие задачу.
+// Блокирующие задачи из списков, недоступных пользователю запроса, не возвращаются.
+func (l *TaskService) ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error) {
+	if _, err := l.getTask(ctx, taskID, domain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	blockers, err := l.repo.ListBlockers(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if member == "" {
+		return blockers, nil
+	}
+
+	visible := make([]domain.Task, 0, len(blockers))
+	access := make(map[string]error)
+	for _, blocker := range blockers {
+		err, checked := access[blocker.ListID]
+		if !checked {
+			err = checkListRole(ctx, l.listRepo, blocker.ListID, domain.RoleViewer)
+			if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+				return nil, err
+			}
+			access[blocker.ListID] = err
+		}
+		if err == nil {
+			visible = append(visible, blocker)
+		}
+	}
+	return visible, nil
+}
+
+// dependsOn сообщает, зависит ли задача taskID от targetID напрямую или через цепочку зависимостей.
+// Учитываются и задачи в корзине: после восстановления их зависимости снова действуют.
+func (l *TaskService) dependsOn(ctx context.Context, taskID, targetID string) (bool, error) {
+	visited := map[string]bool{taskID: true}
+	queue := []string{taskID}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		blockerIDs, err := l.repo.ListBlockerIDs(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		for _, blockerID := range blockerIDs {
+			if blockerID == targetID {
+				return true, nil
+			}
+			if !visited[blockerID] {
+				visited[blockerID] = true
+				queue = append(queue, blockerID)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// checkCascadeTransitions проверяет, что все подзадачи могут перейти
+// в статус задачи по рабочему процессу
+func (l *TaskService) checkCascadeTransitions(ctx context.Context, tasks storage.TaskReader, task domain.Task) error {
+	descendants, err := tasks.ListDescendants(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	var forbidden []string
+	for _, descendant := range descendants {
+		if !l.workflow.CanTransition(descendant.Status, task.Status) {
+			forbidden = append(forbidden, fmt.Sprintf("%s (%s)", descendant.ID, descendant.Status))
+		}
+	}
+
+	if len(forbidden) > 0 {
+		return fmt.Errorf("%w: subtasks cannot change status to %s: %s", ErrConflict, task.Status, strings.Join(forbidden, ", "))
+	}
+	return nil
+}
+
+// checkBlockers проверяет, что у завершаемых задач нет невыполненных блокирующих задач.
+// При каскаде проверяются и незавершенные подзадачи; блокирующие задачи,
+// которые завершаются вместе с ними, не учитываются.
+func (l *TaskService) checkBlockers(ctx context.Context, tasks storage.TaskReader, task domain.Task, wasCompleted, cascade bool) error {
+	var completing []domain.Task
+	if !wasCompleted {
+		completing = append(completing, task)
+	}
+	if cascade {
+		descendants, err := tasks.ListDescendants(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		for _, descendant := range descendants {
+			if !descendant.Completed {
+				completing = append(completing, descendant)
+			}
+		}
+	}
+
+	completingIDs := map[string]bool{task.ID: true}
+	for _, t := range completing {
+		completingIDs[t.ID] = true
+	}
+
+	var open []string
+	for _, t := range completing {
+		blockers, err := tasks.ListBlockers(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+		for _, blocker := range blockers {
+			if !blocker.Completed && !completingIDs[blocker.ID] && !slices.Contains(open, blocker.ID) {
+				open = append(open, blocker.ID)
+			}
+		}
+	}
+
+	if len(open) > 0 {
+		return fmt.Errorf("%w: task is blocked by open tasks %s, pass force to complete anyway", ErrConflict, strings.Join(open, ", "))
+	}
+	return nil
+}
+
+// MoveTask переставляет задачу в ручном порядке списка.
+// Меняется только позиция самой задачи.
+func (l *TaskService) MoveTask(ctx context.Context, id string, request domain.MoveTaskRequest) (domain.Task, error) {
+	if request.ListID == nil && request.AfterTaskID == nil && request.BeforeTaskID == nil {
+		return domain.Task{}, fmt.Errorf("%w: list_id, after_task_id or before_task_id must be provided", ErrValidation)
+	}
+
+	task, err := l.getTask(ctx, id, domain.RoleEditor)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	// Перенос в другой список: задача встает в конец нового списка,
+	// после чего при необходимости ставится между указанными соседями
+	if request.ListID != nil && *request.ListID != task.ListID {
+		if err := l.checkListWritable(ctx, *request.ListID); err != nil {
+			return domain.Task{}, err
+		}
+		moved, err := l.moveToList(ctx, []domain.Task{task}, *request.ListID, request.ResetCompleted)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task = moved[0]
+		if request.AfterTaskID == nil && request.BeforeTaskID == nil {
+			return task, nil
+		}
+	} else if request.AfterTaskID == nil && request.BeforeTaskID == nil {
+		return task, nil
+	}
+
+	var lower, upper string
+	if request.AfterTaskID != nil {
+		after, err := l.resolveAnchor(ctx, task, *request.AfterTaskID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		lower = after.Position
+	}
+	if request.BeforeTaskID != nil {
+		before, err := l.resolveAnchor(ctx, task, *request.BeforeTaskID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		upper = before.Position
+	}
+
+	// Если указан только один сосед, второй — ближайшая к нему задача списка
+	switch {
+	case request.BeforeTaskID == nil:
+		if upper, err = l.repo.NextPosition(ctx, task.ListID, lower, task.ID); err != nil {
+			return domain.Task{}, err
+		}
+	case request.AfterTaskID == nil:
+		if lower, err = l.repo.PrevPosition(ctx, task.ListID, upper, task.ID); err != nil {
+			return domain.Task{}, err
+		}
+	case lower >= upper:
+		return domain.Task{}, fmt.Errorf("%w: after_task_id must precede before_task_id", ErrValidation)
+	}
+
+	position, err := rank.Between(lower, upper)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("compute position: %w", err)
+	}
+
+	return l.repo.SetPosition(ctx, task.ID, position)
+}
+
+// nextOccurrence готовит следующее повторение задачи. Дата считается от срока
+// задачи (или от текущего момента, если срока нет); уже прошедшие повторения
+// пропускаются. ok=false — повторения по правилу закончились.
+func (l *TaskService) nextOccurrence(ctx context.Context, task domain.Task) (domain.Task, bool, error) {
+	rule, err := rrule.Parse(*task.RecurrenceRule)
+	if err != nil {
+		return domain.Task{}, false, fmt.Errorf("parse recurrence rule: %w", err)
+	}
+
+	now := l.now()
+	from := now
+	if task.DueAt != nil {
+		from = *task.DueAt
+	}
+
+	next, rest, ok := rule.Next(from)
+	for ok && !next.After(now) {
+		next, rest, ok = rest.Next(next)
+	}
+	if !ok {
+		return domain.Task{}, false, nil
+	}
+
+	position, err := l.repo.LastPosition(ctx, task.ListID)
+	if err != nil {
+		return domain.Task{}, false, err
+	}
+	if position, err = rank.After(position); err != nil {
+		return domain.Task{}, false, fmt.Errorf("compute position: %w", err)
+	}
+
+	recurrence := rest.String()
+	occurrence := domain.Task{
+		ListID:         task.ListID,
+		ParentTaskID:   task.ParentTaskID,
+		Text:           task.Text,
+		Priority:       task.Priority,
+		DueAt:          &next,
+		Position:       position,
+		RecurrenceRule: &recurrence,
+		AssigneeID:     task.AssigneeID,
+	}
+	occurrence.SetStatus(l.workflow.Initial())
+	return occurrence, true, nil
+}
+
+// MoveTasks переносит задачи в другой список вместе с их подзадачами.
+// Задачи, уже находящиеся в целевом списке, остаются без изменений.
+func (l *TaskService) MoveTasks(ctx context.Context, request domain.TransferTasksRequest) ([]domain.Task, error) {
+	tasks, err := l.loadTransferTasks(ctx, request, domain.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.moveToList(ctx, tasks, request.ListID, request.ResetCompleted)
+}
+
+// CopyTask создает копию задачи без подзадач в том же или другом списке
+func (l *TaskService) CopyTask(ctx context.Context, id string, request domain.CopyTaskRequest) (domain.Task, error) {
+	task, err := l.getTask(ctx, id, domain.RoleViewer)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	listID := task.ListID
+	if request.ListID != nil {
+		listID = *request.ListID
+	}
+	if err := l.checkListWritable(ctx, listID); err != nil {
+		return domain.Task{}, err
+	}
+
+	copies, err := l.copyToList(ctx, []domain.Task{task}, listID, request.ResetCompleted)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return copies[0], nil
+}
+
+// CopyTasks создает копии задач без подзадач в указанном списке
+func (l *TaskService) CopyTasks(ctx context.Context, request domain.TransferTasksRequest) ([]domain.Task, error) {
+	tasks, err := l.loadTransferTasks(ctx, request, domain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.copyToList(ctx, tasks, request.ListID, request.ResetCompleted)
+}
+
+// loadTransferTasks проверяет пакетный запрос и загружает задачи в порядке запроса.
+// role — роль, нужная пользователю в исходных списках задач.
+func (l *TaskService) loadTransferTasks(ctx context.Context, request domain.TransferTasksRequest, role domain.ListRole) ([]domain.Task, error) {
+	ids := uniqueStrings(request.TaskIDs)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: task_ids must not be empty", ErrValidation)
+	}
+	if len(ids) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d tasks can be processed at once", ErrValidation, MaxBatchSize)
+	}
+	if request.ListID == "" {
+		return nil, fmt.Errorf("%w: list_id is required", ErrValidation)
+	}
+	if err := l.checkListWritable(ctx, request.ListID); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]domain.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := l.getTask(ctx, id, role)
+		if err != nil {
+			if err == postgres.ErrNotFound {
+				return nil, fmt.Errorf("%w: task %s not found", ErrValidation, id)
+			}
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// moveToList переносит задачи вместе с поддеревьями в конец списка listID.
+// Связь с родителем сохраняется, только если родитель переносится вместе с задачей.
+func (l *TaskService) moveToList(ctx context.Context, tasks []domain.Task, listID string, resetCompleted bool) ([]domain.Task, error) {
+	moving := make(map[string]bool)
+	var ordered []domain.Task
+	add := func(task domain.Task) {
+		if !moving[task.ID] {
+			moving[task.ID] = true
+			ordered = append(ordered, task)
+		}
 	}
 
-	// Обновляем статус только если передан
-	newCompleted := currentTask.Completed
-	if completed != nil {
-		newCompleted = *completed
+	for _, task := range tasks {
+		if task.ListID == listID {
+			continue
+		}
+		add(task)
+		descendants, err := l.repo.ListDescendants(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, descendant := range descendants {
+			add(descendant)
+		}
+	}
+	if len(ordered) == 0 {
+		return tasks, nil
 	}
 
-	return l.repo.UpdateTask(id, newText, newCompleted)
+	position, err := l.repo.LastPosition(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := make([]domain.TaskMove, 0, len(ordered))
+	for _, task := range ordered {
+		parentID := task.ParentTaskID
+		if parentID != nil && !moving[*parentID] {
+			parentID = nil
+		}
+		if position, err = rank.After(position); err != nil {
+			return nil, fmt.Errorf("compute position: %w", err)
+		}
+		moves = append(moves, domain.TaskMove{
+			ID:             task.ID,
+			ListID:         listID,
+			ParentTaskID:   parentID,
+			Position:       position,
+			ResetCompleted: resetCompleted,
+			ResetStatus:    l.workflow.Initial(),
+		})
+	}
+
+	return l.repo.MoveTasks(ctx, moves)
+}
+
+// copyToList создает копии задач верхнего уровня в конце списка listID
+func (l *TaskService) copyToList(ctx context.Context, tasks []domain.Task, listID string, resetCompleted bool) ([]domain.Task, error) {
+	position, err := l.repo.LastPosition(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := make([]domain.TaskCopy, 0, len(tasks))
+	for _, task := range tasks {
+		if position, err = rank.After(position); err != nil {
+			return nil, fmt.Errorf("compute position: %w", err)
+		}
+		copied := domain.Task{
+			ListID:         listID,
+			Text:           task.Text,
+			Priority:       task.Priority,
+			DueAt:          task.DueAt,
+			Position:       position,
+			RecurrenceRule: task.RecurrenceRule,
+			AssigneeID:     task.AssigneeID,
+		}
+		status := task.Status
+		if resetCompleted && task.Completed {
+			status = l.workflow.Initial()
+		}
+		copied.SetStatus(status)
+		copies = append(copies, domain.TaskCopy{SourceID: task.ID, Task: copied})
+	}
+
+	return l.repo.CopyTasks(ctx, copies)
 }
 
-func (l *TaskService) DeleteTask(id string) error {
-	return l.repo.DeleteTask(id)
+// checkAssignee проверяет, что исполнитель состоит в рабочем пространстве запроса
+// и участвует в списке задачи. Иначе это ошибка запроса, а не отсутствие задачи.
+func (l *TaskService) checkAssignee(ctx context.Context, listID, userID string) error {
+	if _, err := l.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return fmt.Errorf("%w: assignee %s not found", ErrValidation, userID)
+		}
+		return err
+	}
+	if _, err := l.listRepo.GetMemberRole(ctx, listID, userID); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return fmt.Errorf("%w: assignee %s is not a member of the list", ErrValidation, userID)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkListWritable проверяет, что список существует, пользователь запроса
+// может менять его задачи и список не в архиве: в архивный список нельзя добавлять задачи
+func (l *TaskService) checkListWritable(ctx context.Context, listID string) error {
+	list, err := l.listRepo.GetByID(ctx, listID)
+	if err == nil {
+		err = checkListRole(ctx, l.listRepo, listID, domain.RoleEditor)
+	}
+	if err != nil {
+		if err == postgres.ErrNotFound {
+			return fmt.Errorf("%w: list not found", ErrValidation)
+		}
+		if errors.Is(err, ErrForbidden) {
+			return err
+		}
+		return fmt.Errorf("failed to check list existence: %w", err)
+	}
+	if list.ArchivedAt != nil {
+		return fmt.Errorf("%w: list is archived", ErrConflict)
+	}
+	return nil
+}
+
+// resolveAnchor получает соседнюю задачу для перемещения и проверяет, что она из того же списка
+func (l *TaskService) resolveAnchor(ctx context.Context, task domain.Task, anchorID string) (domain.Task, error) {
+	if anchorID == task.ID {
+		return domain.Task{}, fmt.Errorf("%w: task cannot be positioned relative to itself", ErrValidation)
+	}
+
+	anchor, err := l.repo.GetByIDTask(ctx, anchorID)
+	if err != nil {
+		if err == postgres.ErrNotFound {
+			return domain.Task{}, fmt.Errorf("%w: task %s not found", ErrValidation, anchorID)
+		}
+		return domain.Task{}, err
+	}
+	if anchor.ListID != task.ListID {
+		return domain.Task{}, fmt.Errorf("%w: tasks must belong to the same list", ErrValidation)
+	}
+
+	return anchor, nil
+}
+
+// DeleteTask перемещает задачу вместе с подзадачами в корзину
+func (l *TaskService) DeleteTask(ctx context.Context, id string) error {
+	if err := l.checkTaskRole(ctx, id, domain.RoleEditor); err != nil {
+		return err
+	}
+	return l.repo.DeleteTask(ctx, id)
+}
+
+// RestoreTask возвращает задачу из корзины. Задачу удаленного списка можно
+// восстановить только вместе со списком; если удален родитель, задача
+// восстанавливается на верхнем уровне.
+func (l *TaskService) RestoreTask(ctx context.Context, id string) (domain.Task, error) {
+	task, err := l.repo.GetDeletedTask(ctx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkListRole(ctx, l.listRepo, task.ListID, domain.RoleEditor); err != nil {
+		return domain.Task{}, err
+	}
+
+	if _, err := l.listRepo.GetByID(ctx, task.ListID); err != nil {
+		if err == postgres.ErrNotFound {
+			return domain.Task{}, fmt.Errorf("%w: list of the task is in trash, restore the list first", ErrConflict)
+		}
+		return domain.Task{}, fmt.Errorf("failed to check list existence: %w", err)
+	}
+
+	detachParent := false
+	if task.ParentTaskID != nil {
+		if _, err := l.repo.GetByIDTask(ctx, *task.ParentTaskID); err != nil {
+			if err != postgres.ErrNotFound {
+				return domain.Task{}, fmt.Errorf("failed to get parent task: %w", err)
+			}
+			detachParent = true
+		}
+	}
+
+	return l.repo.RestoreTask(ctx, id, detachParent)
+}
+
+// getTask получает задачу и проверяет, что у пользователя запроса есть роль role в ее списке
+func (l *TaskService) getTask(ctx context.Context, id string, role domain.ListRole) (domain.Task, error) {
+	return getTaskWithRole(ctx, l.repo, l.listRepo, id, role)
+}
+
+// checkTaskRole проверяет роль пользователя запроса в списке задачи.
+// Задача загружается, только если запрос выполняется от имени пользователя.
+func (l *TaskService) checkTaskRole(ctx context.Context, id string, role domain.ListRole) error {
+	member, err := memberID(ctx)
+	if err != nil || member == "" {
+		return err
+	}
+	_, err = l.getTask(ctx, id, role)
+	return err
+}
+
+// resolveParent получает родительскую задачу и проверяет, что она из того же списка
+func (l *TaskService) resolveParent(ctx context.Context, listID string, parentID string) (domain.Task, error) {
+	parent, err := l.repo.GetByIDTask(ctx, parentID)
+	if err != nil {
+		if err == postgres.ErrNotFound {
+			return domain.Task{}, fmt.Errorf("%w: parent task not found", ErrValidation)
+		}
+		return domain.Task{}, fmt.Errorf("failed to get parent task: %w", err)
+	}
+	if parent.ListID != listID {
+		return domain.Task{}, fmt.Errorf("%w: parent task must belong to the same list", ErrValidation)
+	}
+	return parent, nil
+}
+
+// taskDepth возвращает глубину задачи, поднимаясь по цепочке родителей
+func (l *TaskService) taskDepth(ctx context.Context, task domain.Task) (int, error) {
+	depth := 1
+	for task.ParentTaskID != nil && depth <= MaxTaskDepth {
+		parent, err := l.repo.GetByIDTask(ctx, *task.ParentTaskID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		task = parent
+		depth++
+	}
+	return depth, nil
+}
+
+// checkReparent проверяет, что задачу можно сделать подзадачей parentID:
+// родитель из того же списка, не образуется цикл и не превышена глубина
+func (l *TaskService) checkReparent(ctx context.Context, task domain.Task, parentID string) error {
+	if parentID == task.ID {
+		return fmt.Errorf("%w: task cannot be its own parent", ErrValidation)
+	}
+
+	parent, err := l.resolveParent(ctx, task.ListID, parentID)
+	if err != nil {
+		return err
+	}
+
+	descendants, err := l.repo.ListDescendants(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	for _, descendant := range descendants {
+		if descendant.ID == parentID {
+			return fmt.Errorf("%w: task cannot be moved under its own subtask", ErrValidation)
+		}
+	}
+
+	parentDepth, err := l.taskDepth(ctx, parent)
+	if err != nil {
+		return err
+	}
+	if parentDepth+subtreeHeight(task.ID, descendants) > MaxTaskDepth {
+		return fmt.Errorf("%w: subtasks can be nested at most %d levels deep", ErrValidation, MaxTaskDepth)
+	}
+
+	return nil
+}
+
+// subtreeHeight возвращает число уровней в поддереве с корнем rootID
+func subtreeHeight(rootID string, descendants []domain.Task) int {
+	children := make(map[string][]string)
+	for _, task := range descendants {
+		if task.ParentTaskID != nil {
+			children[*task.ParentTaskID] = append(children[*task.ParentTaskID], task.ID)
+		}
+	}
+
+	var height func(id string) int
+	height = func(id string) int {
+		maxChild := 0
+		for _, childID := range children[id] {
+			if h := height(childID); h > maxChild {
+				maxChild = h
+			}
+		}
+		return maxChild + 1
+	}
+
+	return height(rootID)
+}
+
+// buildTaskTree собирает задачи в дерево, сохраняя исходный порядок
+func buildTaskTree(tasks []domain.Task) []domain.TaskNode {
+	present := make(map[string]struct{}, len(tasks))
+	for _, task := range tasks {
+		present[task.ID] = struct{}{}
+	}
+
+	children := make(map[string][]domain.Task)
+	roots := make([]domain.Task, 0)
+	for _, task := range tasks {
+		if task.ParentTaskID != nil {
+			if _, ok := present[*task.ParentTaskID]; ok {
+				children[*task.ParentTaskID] = append(children[*task.ParentTaskID], task)
+				continue
+			}
+		}
+		roots = append(roots, task)
+	}
+
+	var build func(tasks []domain.Task) []domain.TaskNode
+	build = func(tasks []domain.Task) []domain.TaskNode {
+		nodes := make([]domain.TaskNode, 0, len(tasks))
+		for _, task := range tasks {
+			nodes = append(nodes, domain.TaskNode{
+				Task:     task,
+				Subtasks: build(children[task.ID]),
+			})
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
+
+// normalizeTaskFilter проверяет фильтр задач и заполняет значения по умолчанию
+func (l *TaskService) normalizeTaskFilter(filter domain.TaskFilter) (domain.TaskFilter, error) {
+	if filter.DueBefore != nil && filter.DueAfter != nil && !filter.DueAfter.Before(*filter.DueBefore) {
+		return filter, fmt.Errorf("%w: due_after must be earlier than due_before", ErrValidation)
+	}
+	if filter.CreatedBefore != nil && filter.CreatedAfter != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return filter, fmt.Errorf("%w: created_after must be earlier than created_before", ErrValidation)
+	}
+	if filter.UpdatedBefore != nil && filter.UpdatedAfter != nil && !filter.UpdatedAfter.Before(*filter.UpdatedBefore) {
+		return filter, fmt.Errorf("%w: updated_after must be earlier than updated_before", ErrValidation)
+	}
+	if utf8.RuneCountInString(filter.TextContains) > MaxTextContainsLength {
+		return filter, fmt.Errorf("%w: text_contains must be at most %d chars", ErrValidation, MaxTextContainsLength)
+	}
+	seen := make(map[domain.TaskSortField]bool, len(filter.Sort))
+	for _, sort := range filter.Sort {
+		if !sort.Field.Valid() {
+			return filter, fmt.Errorf("%w: unsupported sort field %q", ErrValidation, sort.Field)
+		}
+		if seen[sort.Field] {
+			return filter, fmt.Errorf("%w: sort field %q is repeated", ErrValidation, sort.Field)
+		}
+		seen[sort.Field] = true
+	}
+	if len(filter.TagIDs) > 0 {
+		if filter.TagMode == "" {
+			filter.TagMode = domain.TagMatchAny
+		}
+		if !filter.TagMode.Valid() {
+			return filter, fmt.Errorf("%w: tag_mode must be any or all", ErrValidation)
+		}
+		filter.TagIDs = uniqueStrings(filter.TagIDs)
+	}
+	if len(filter.Statuses) > 0 {
+		for _, status := range filter.Statuses {
+			if err := l.validateStatus(status); err != nil {
+				return filter, err
+			}
+		}
+		filter.Statuses = uniqueStatuses(filter.Statuses)
+	}
+	return filter, nil
+}
+
+// validateStatus проверяет, что статус есть в рабочем процессе
+func (l *TaskService) validateStatus(status domain.TaskStatus) error {
+	if !l.workflow.Has(status) {
+		return fmt.Errorf("%w: status must be one of %s", ErrValidation, joinStatuses(l.workflow.Statuses))
+	}
+	return nil
+}
+
+// requestedStatus определяет новый статус задачи по полям status и completed.
+// completed=true означает статус done, completed=false снимает с выполненной
+// задачи статус done, возвращая ее в начальный статус.
+func (l *TaskService) requestedStatus(task domain.Task, request domain.UpdateTaskRequest) (domain.TaskStatus, error) {
+	status := task.Status
+	if request.Status != nil {
+		if err := l.validateStatus(*request.Status); err != nil {
+			return "", err
+		}
+		status = *request.Status
+	}
+
+	if request.Completed != nil {
+		switch {
+		case request.Status != nil:
+			if *request.Completed != (status == domain.StatusDone) {
+				return "", fmt.Errorf("%w: completed contradicts status %s", ErrValidation, status)
+			}
+		case *request.Completed:
+			status = domain.StatusDone
+		case status == domain.StatusDone:
+			status = l.workflow.Initial()
+		}
+	}
+
+	return status, nil
+}
+
+// groupTasksByStatus раскладывает задачи по статусам в порядке рабочего процесса.
+// Если фильтр задает статусы, группы строятся только для них. Статусы, которых
+// нет в рабочем процессе (например, после его изменения), идут в конце.
+func groupTasksByStatus(tasks []domain.Task, workflow domain.TaskWorkflow, statuses []domain.TaskStatus, limit int, offset int) []domain.TaskGroup {
+	order := slices.Clone(workflow.Statuses)
+	if len(statuses) > 0 {
+		order = slices.Clone(statuses)
+	}
+
+	byStatus := make(map[domain.TaskStatus][]domain.Task)
+	for _, task := range tasks {
+		if !slices.Contains(order, task.Status) {
+			order = append(order, task.Status)
+		}
+		byStatus[task.Status] = append(byStatus[task.Status], task)
+	}
+
+	groups := make([]domain.TaskGroup, 0, len(order))
+	for _, status := range order {
+		group := byStatus[status]
+		page := []domain.Task{}
+		if offset < len(group) {
+			page = group[offset:min(offset+limit, len(group))]
+		}
+		groups = append(groups, domain.TaskGroup{Status: status, Tasks: page, Total: len(group)})
+	}
+	return groups
+}
+
+// uniqueStatuses убирает повторы статусов, сохраняя порядок
+func uniqueStatuses(statuses []domain.TaskStatus) []domain.TaskStatus {
+	result := make([]domain.TaskStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if !slices.Contains(result, status) {
+			result = append(result, status)
+		}
+	}
+	return result
+}
+
+func joinStatuses(statuses []domain.TaskStatus) string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
 }
 
 func validateText(text string) error {
@@ -93,3 +1208,33 @@ func validateText(text string) error {
 	}
 	return nil
 }
+
+// normalizeRecurrence проверяет правило повторения и приводит его к каноничному виду
+func normalizeRecurrence(value string) (string, error) {
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return rule.String(), nil
+}
+
+func validatePriority(priority domain.TaskPriority) error {
+	if !priority.Valid() {
+		return fmt.Errorf("%w: priority must be one of none, low, medium, high, urgent", ErrValidation)
+	}
+	return nil
+}
+
+// uniqueStrings убирает повторы, сохраняя порядок
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
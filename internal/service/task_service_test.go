@@ -1,14 +1,19 @@
 package service
 
 import (
+	"context"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"RestApi/internal/domain"
+	"RestApi/internal/storage"
 	"RestApi/internal/storage/postgres"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock для TaskRepository
@@ -16,71 +21,238 @@ type MockTaskRepository struct {
 	mock.Mock
 }
 
-func (m *MockTaskRepository) CreateTask(task domain.Task) (domain.Task, error) {
+func (m *MockTaskRepository) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
 	args := m.Called(task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetByIDTask(id string) (domain.Task, error) {
+func (m *MockTaskRepository) GetByIDTask(ctx context.Context, id string) (domain.Task, error) {
 	args := m.Called(id)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) ListTasks(listID string, limit int, offset int) ([]domain.Task, int, error) {
-	args := m.Called(listID, limit, offset)
+func (m *MockTaskRepository) ListTasks(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	args := m.Called(listID, filter, limit, offset)
 	return args.Get(0).([]domain.Task), args.Int(1), args.Error(2)
 }
 
-func (m *MockTaskRepository) UpdateTask(id string, text string, completed bool) (domain.Task, error) {
-	args := m.Called(id, text, completed)
+func (m *MockTaskRepository) ListTasksByCursor(ctx context.Context, listID string, filter domain.TaskFilter, cursor *domain.Cursor, limit int) ([]domain.Task, bool, error) {
+	args := m.Called(listID, filter, cursor, limit)
+	return args.Get(0).([]domain.Task), args.Bool(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) ListAllTasks(ctx context.Context, listID string, filter domain.TaskFilter) ([]domain.Task, error) {
+	args := m.Called(listID, filter)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	args := m.Called(parentID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListDescendants(ctx context.Context, id string) ([]domain.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateTaskWithSubtasks(ctx context.Context, task domain.Task, check storage.TaskCheck) (domain.Task, error) {
+	if err := m.runCheck(ctx, task.ID, check); err != nil {
+		return domain.Task{}, err
+	}
+	args := m.Called(task)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) NextPosition(ctx context.Context, listID, position, excludeID string) (string, error) {
+	args := m.Called(listID, position, excludeID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) PrevPosition(ctx context.Context, listID, position, excludeID string) (string, error) {
+	args := m.Called(listID, position, excludeID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) SetPosition(ctx context.Context, id, position string) (domain.Task, error) {
+	args := m.Called(id, position)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CompleteRecurringTask(ctx context.Context, task domain.Task, next domain.Task, cascade bool, check storage.TaskCheck) (domain.Task, domain.Task, error) {
+	if err := m.runCheck(ctx, task.ID, check); err != nil {
+		return domain.Task{}, domain.Task{}, err
+	}
+	args := m.Called(task, next, cascade)
+	return args.Get(0).(domain.Task), args.Get(1).(domain.Task), args.Error(2)
+}
+
+func (m *MockTaskRepository) LastPosition(ctx context.Context, listID string) (string, error) {
+	args := m.Called(listID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) MoveTasks(ctx context.Context, moves []domain.TaskMove) ([]domain.Task, error) {
+	args := m.Called(moves)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) CopyTasks(ctx context.Context, copies []domain.TaskCopy) ([]domain.Task, error) {
+	args := m.Called(copies)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListOverdueTasks(ctx context.Context, memberID string, limit int, offset int) ([]domain.Task, int, error) {
+	args := m.Called(memberID, limit, offset)
+	return args.Get(0).([]domain.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) ListAssignedTasks(ctx context.Context, userID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	args := m.Called(userID, filter, limit, offset)
+	return args.Get(0).([]domain.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) UpdateTask(ctx context.Context, task domain.Task, check storage.TaskCheck) (domain.Task, error) {
+	if err := m.runCheck(ctx, task.ID, check); err != nil {
+		return domain.Task{}, err
+	}
+	args := m.Called(task)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) DeleteTask(id string) error {
+// runCheck выполняет проверку изменения так же, как репозиторий в транзакции:
+// задача до изменения берется из GetByIDTask, связанные задачи читает сам мок
+func (m *MockTaskRepository) runCheck(ctx context.Context, id string, check storage.TaskCheck) error {
+	if check == nil {
+		return nil
+	}
+	before, err := m.GetByIDTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	return check(ctx, before, m)
+}
+
+func (m *MockTaskRepository) DeleteTask(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetDeletedTask(ctx context.Context, id string) (domain.Task, error) {
 	args := m.Called(id)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) RestoreTask(ctx context.Context, id string, detachParent bool) (domain.Task, error) {
+	args := m.Called(id, detachParent)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddDependency(ctx context.Context, taskID, blockerID string) error {
+	args := m.Called(taskID, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	args := m.Called(taskID, blockerID)
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListBlockerIDs(ctx context.Context, taskID string) ([]string, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // Mock для ListRepository
 type MockListRepository struct {
 	mock.Mock
 }
 
-func (m *MockListRepository) Create(title string) (domain.List, error) {
-	args := m.Called(title)
+func (m *MockListRepository) Create(ctx context.Context, title, description string) (domain.List, error) {
+	args := m.Called(title, description)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *MockListRepository) GetByID(ctx context.Context, id string) (domain.List, error) {
+	args := m.Called(id)
 	return args.Get(0).(domain.List), args.Error(1)
 }
 
-func (m *MockListRepository) GetByID(id string) (domain.List, error) {
+func (m *MockListRepository) GetDeleted(ctx context.Context, id string) (domain.List, error) {
 	args := m.Called(id)
 	return args.Get(0).(domain.List), args.Error(1)
 }
 
-func (m *MockListRepository) SearchByTitle(query string) ([]domain.List, error) {
-	args := m.Called(query)
+func (m *MockListRepository) SearchByTitle(ctx context.Context, search domain.TitleSearch, filter domain.ListFilter) ([]domain.List, error) {
+	args := m.Called(search, filter)
 	return args.Get(0).([]domain.List), args.Error(1)
 }
 
-func (m *MockListRepository) Update(id string, title string) (domain.List, error) {
-	args := m.Called(id, title)
+func (m *MockListRepository) Update(ctx context.Context, list domain.List) (domain.List, error) {
+	args := m.Called(list)
 	return args.Get(0).(domain.List), args.Error(1)
 }
 
-func (m *MockListRepository) Delete(id string) error {
+func (m *MockListRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockListRepository) List(limit, offset int) ([]domain.List, int, error) {
-	args := m.Called(limit, offset)
+func (m *MockListRepository) Restore(ctx context.Context, id string) (domain.List, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *MockListRepository) List(ctx context.Context, filter domain.ListFilter, limit, offset int) ([]domain.List, int, error) {
+	args := m.Called(filter, limit, offset)
 	return args.Get(0).([]domain.List), args.Int(1), args.Error(2)
 }
 
+func (m *MockListRepository) ListByCursor(ctx context.Context, filter domain.ListFilter, cursor *domain.Cursor, limit int) ([]domain.List, bool, error) {
+	args := m.Called(filter, cursor, limit)
+	return args.Get(0).([]domain.List), args.Bool(1), args.Error(2)
+}
+
+func (m *MockListRepository) SetArchived(ctx context.Context, id string, archived bool) (domain.List, error) {
+	args := m.Called(id, archived)
+	return args.Get(0).(domain.List), args.Error(1)
+}
+
+func (m *MockListRepository) Stats(ctx context.Context, listID string) (domain.ListStats, error) {
+	args := m.Called(listID)
+	return args.Get(0).(domain.ListStats), args.Error(1)
+}
+
+func (m *MockListRepository) GetMemberRole(ctx context.Context, listID, userID string) (domain.ListRole, error) {
+	args := m.Called(listID, userID)
+	return args.Get(0).(domain.ListRole), args.Error(1)
+}
+
+func (m *MockListRepository) ListMembers(ctx context.Context, listID string) ([]domain.ListMember, error) {
+	args := m.Called(listID)
+	return args.Get(0).([]domain.ListMember), args.Error(1)
+}
+
+func (m *MockListRepository) SetMember(ctx context.Context, member domain.ListMember) (domain.ListMember, error) {
+	args := m.Called(member)
+	return args.Get(0).(domain.ListMember), args.Error(1)
+}
+
+func (m *MockListRepository) RemoveMember(ctx context.Context, listID, userID string) error {
+	args := m.Called(listID, userID)
+	return args.Error(0)
+}
+
 func TestTaskService_CreateTask_Success(t *testing.T) {
 	// Создаем моки
 	taskRepo := new(MockTaskRepository)
 	listRepo := new(MockListRepository)
-	service := NewTaskService(taskRepo, listRepo)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 	// Настраиваем ожидания:
 	// - При проверке списка вернуть успех
@@ -95,7 +267,7 @@ func TestTaskService_CreateTask_Success(t *testing.T) {
 		}, nil)
 
 	// Вызываем метод
-	result, err := service.CreateTask("list-123", "Test task")
+	result, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: "Test task"})
 
 	// Проверяем результат
 	assert.NoError(t, err)
@@ -111,12 +283,12 @@ func TestTaskService_CreateTask_Success(t *testing.T) {
 func TestTaskService_CreateTask_EmptyText(t *testing.T) {
 	taskRepo := new(MockTaskRepository)
 	listRepo := new(MockListRepository)
-	service := NewTaskService(taskRepo, listRepo)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 	// Не настраиваем вызовы к репозиториям - их не должно быть при ошибке валидации
 
 	// Вызываем метод с пустым текстом
-	_, err := service.CreateTask("list-123", "")
+	_, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: ""})
 
 	// Проверяем что получили ошибку валидации
 	assert.Error(t, err)
@@ -126,13 +298,13 @@ func TestTaskService_CreateTask_EmptyText(t *testing.T) {
 func TestTaskService_CreateTask_ListNotFound(t *testing.T) {
 	taskRepo := new(MockTaskRepository)
 	listRepo := new(MockListRepository)
-	service := NewTaskService(taskRepo, listRepo)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 	// Настраиваем что список не найден
 	listRepo.On("GetByID", "non-existent-list").Return(domain.List{}, postgres.ErrNotFound)
 
 	// Вызываем метод
-	_, err := service.CreateTask("non-existent-list", "Test task")
+	_, err := service.CreateTask(authDisabledContext(), "non-existent-list", domain.CreateTaskRequest{Text: "Test task"})
 
 	// Проверяем что получили ошибку
 	assert.Error(t, err)
@@ -146,7 +318,7 @@ func TestTaskService_CreateTask_ListNotFound(t *testing.T) {
 func TestTaskService_UpdateTask_Success(t *testing.T) {
 	taskRepo := new(MockTaskRepository)
 	listRepo := new(MockListRepository)
-	service := NewTaskService(taskRepo, listRepo)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 	// Настраиваем мок для получения текущей задачи
 	taskRepo.On("GetByIDTask", "task-123").
@@ -154,11 +326,20 @@ func TestTaskService_UpdateTask_Success(t *testing.T) {
 			ID:        "task-123",
 			ListID:    "list-123",
 			Text:      "Original text",
+			Status:    domain.StatusTodo,
 			Completed: false,
 		}, nil)
 
+	taskRepo.On("ListBlockers", "task-123").Return([]domain.Task{}, nil)
+
 	// Настраиваем успешное обновление
-	taskRepo.On("UpdateTask", "task-123", "Updated text", true).
+	taskRepo.On("UpdateTask", domain.Task{
+		ID:        "task-123",
+		ListID:    "list-123",
+		Text:      "Updated text",
+		Status:    domain.StatusDone,
+		Completed: true,
+	}).
 		Return(domain.Task{
 			ID:        "task-123",
 			ListID:    "list-123",
@@ -168,7 +349,7 @@ func TestTaskService_UpdateTask_Success(t *testing.T) {
 
 	text := "Updated text"
 	completed := true
-	result, err := service.UpdateTask("task-123", &text, &completed)
+	result, err := service.UpdateTask(authDisabledContext(), "task-123", domain.UpdateTaskRequest{Text: &text, Completed: &completed})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated text", result.Text)
@@ -179,12 +360,12 @@ func TestTaskService_UpdateTask_Success(t *testing.T) {
 func TestTaskService_DeleteTask_Success(t *testing.T) {
 	taskRepo := new(MockTaskRepository)
 	listRepo := new(MockListRepository)
-	service := NewTaskService(taskRepo, listRepo)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 	// Настраиваем успешное удаление
 	taskRepo.On("DeleteTask", "task-123").Return(nil)
 
-	err := service.DeleteTask("task-123")
+	err := service.DeleteTask(authDisabledContext(), "task-123")
 
 	assert.NoError(t, err)
 	taskRepo.AssertExpectations(t)
@@ -194,7 +375,7 @@ func TestTaskService_EdgeCases(t *testing.T) {
 	t.Run("text exactly 500 characters", func(t *testing.T) {
 		taskRepo := new(MockTaskRepository)
 		listRepo := new(MockListRepository)
-		service := NewTaskService(taskRepo, listRepo)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
 
 		// Текст ровно 500 символов - должен работать
 		maxText := strings.Repeat("a", 500)
@@ -202,14 +383,16 @@ func TestTaskService_EdgeCases(t *testing.T) {
 		listRepo.On("GetByID", "list-123").Return(domain.List{ID: "list-123"}, nil)
 		taskRepo.On("CreateTask", mock.Anything).Return(domain.Task{ID: "task-123"}, nil)
 
-		_, err := service.CreateTask("list-123", maxText)
+		_, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: maxText})
 		assert.NoError(t, err)
 	})
 
 	t.Run("update with only completed flag", func(t *testing.T) {
 		taskRepo := new(MockTaskRepository)
 		listRepo := new(MockListRepository)
-		service := NewTaskService(taskRepo, listRepo)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
 
 		// Настраиваем мок для получения текущей задачи
 		taskRepo.On("GetByIDTask", "task-123").
@@ -217,11 +400,18 @@ func TestTaskService_EdgeCases(t *testing.T) {
 				ID:        "task-123",
 				ListID:    "list-123",
 				Text:      "Original text",
+				Status:    domain.StatusTodo,
 				Completed: false,
 			}, nil)
 
 		// Обновляем только completed, text остается прежним
-		taskRepo.On("UpdateTask", "task-123", "Original text", true).
+		taskRepo.On("UpdateTask", domain.Task{
+			ID:        "task-123",
+			ListID:    "list-123",
+			Text:      "Original text",
+			Status:    domain.StatusDone,
+			Completed: true,
+		}).
 			Return(domain.Task{
 				ID:        "task-123",
 				Text:      "Original text",
@@ -229,7 +419,1265 @@ func TestTaskService_EdgeCases(t *testing.T) {
 			}, nil)
 
 		completed := true
-		_, err := service.UpdateTask("task-123", nil, &completed)
+		_, err := service.UpdateTask(authDisabledContext(), "task-123", domain.UpdateTaskRequest{Completed: &completed})
+		assert.NoError(t, err)
+	})
+}
+
+func TestTaskService_DueDates(t *testing.T) {
+	dueAt := time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("create task with due date", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-123").Return(domain.List{ID: "list-123"}, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.DueAt != nil && task.DueAt.Equal(dueAt)
+		})).Return(domain.Task{ID: "task-123", DueAt: &dueAt}, nil)
+
+		result, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: "Pay bills", DueAt: &dueAt})
+		assert.NoError(t, err)
+		assert.Equal(t, &dueAt, result.DueAt)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("clear due date", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		current := domain.Task{ID: "task-123", ListID: "list-123", Text: "Pay bills", DueAt: &dueAt}
+		taskRepo.On("GetByIDTask", "task-123").Return(current, nil)
+		taskRepo.On("UpdateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.DueAt == nil && task.Text == "Pay bills"
+		})).Return(domain.Task{ID: "task-123", Text: "Pay bills"}, nil)
+
+		result, err := service.UpdateTask(authDisabledContext(), "task-123", domain.UpdateTaskRequest{ClearDueAt: true})
+		assert.NoError(t, err)
+		assert.Nil(t, result.DueAt)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("due_at and clear_due_at together", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-123").Return(domain.Task{ID: "task-123", Text: "Pay bills"}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-123", domain.UpdateTaskRequest{DueAt: &dueAt, ClearDueAt: true})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("inverted due range", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		before := dueAt
+		after := dueAt.Add(time.Hour)
+		_, _, err := service.ListTasks(authDisabledContext(), "list-123", domain.TaskFilter{DueBefore: &before, DueAfter: &after}, 20, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskService_Priority(t *testing.T) {
+	t.Run("create task defaults to none", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-123").Return(domain.List{ID: "list-123"}, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.Priority == domain.PriorityNone
+		})).Return(domain.Task{ID: "task-123", Priority: domain.PriorityNone}, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: "Write report"})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("create task with unknown priority", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		_, err := service.CreateTask(authDisabledContext(), "list-123", domain.CreateTaskRequest{Text: "Write report", Priority: "critical"})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+	})
+
+	t.Run("update only priority", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-123").
+			Return(domain.Task{ID: "task-123", Text: "Write report", Priority: domain.PriorityLow}, nil)
+		taskRepo.On("UpdateTask", domain.Task{ID: "task-123", Text: "Write report", Priority: domain.PriorityUrgent}).
+			Return(domain.Task{ID: "task-123", Text: "Write report", Priority: domain.PriorityUrgent}, nil)
+
+		priority := domain.PriorityUrgent
+		result, err := service.UpdateTask(authDisabledContext(), "task-123", domain.UpdateTaskRequest{Priority: &priority})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PriorityUrgent, result.Priority)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("unsupported sort field", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		filter := domain.TaskFilter{Sort: []domain.TaskSort{{Field: "text; DROP TABLE tasks"}}}
+		_, _, err := service.ListTasks(authDisabledContext(), "list-123", filter, 20, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaskService_ListTasks_Query(t *testing.T) {
+	t.Run("passes filter and sort keys to repository", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		completed := false
+		createdAfter := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		filter := domain.TaskFilter{
+			Completed:    &completed,
+			CreatedAfter: &createdAfter,
+			TextContains: "отчет",
+			Sort: []domain.TaskSort{
+				{Field: domain.TaskSortUpdatedAt, Desc: true},
+				{Field: domain.TaskSortText},
+			},
+		}
+		taskRepo.On("ListTasks", "list-123", filter, 20, 0).Return([]domain.Task{}, 0, nil)
+
+		_, _, err := service.ListTasks(authDisabledContext(), "list-123", filter, 20, 0)
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	createdAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for name, filter := range map[string]domain.TaskFilter{
+		"repeated sort field":    {Sort: []domain.TaskSort{{Field: domain.TaskSortText}, {Field: domain.TaskSortText, Desc: true}}},
+		"empty created range":    {CreatedAfter: &createdAt, CreatedBefore: &createdAt},
+		"empty updated range":    {UpdatedAfter: &createdAt, UpdatedBefore: &createdAt},
+		"text_contains too long": {TextContains: strings.Repeat("а", MaxTextContainsLength+1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			taskRepo := new(MockTaskRepository)
+			listRepo := new(MockListRepository)
+			service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+			_, _, err := service.ListTasks(authDisabledContext(), "list-123", filter, 20, 0)
+			assert.ErrorIs(t, err, ErrValidation)
+			taskRepo.AssertNotCalled(t, "ListTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("cursor pagination with several sort keys", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		filter := domain.TaskFilter{Sort: []domain.TaskSort{{Field: domain.TaskSortCreatedAt}, {Field: domain.TaskSortText}}}
+		_, _, err := service.ListTasksByCursor(authDisabledContext(), "list-123", filter, nil, 20)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTaskService_ListTasks_TagFilter(t *testing.T) {
+	t.Run("defaults to any and removes duplicates", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		expected := domain.TaskFilter{TagIDs: []string{"tag-1", "tag-2"}, TagMode: domain.TagMatchAny}
+		taskRepo.On("ListTasks", "list-123", expected, 20, 0).Return([]domain.Task{}, 0, nil)
+
+		filter := domain.TaskFilter{TagIDs: []string{"tag-1", "tag-2", "tag-1"}}
+		_, _, err := service.ListTasks(authDisabledContext(), "list-123", filter, 20, 0)
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown tag mode", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		filter := domain.TaskFilter{TagIDs: []string{"tag-1"}, TagMode: "some"}
+		_, _, err := service.ListTasks(authDisabledContext(), "list-123", filter, 20, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTaskService_ListTasksByCursor(t *testing.T) {
+	t.Run("builds cursors of neighbour pages", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		cursor := &domain.Cursor{CreatedAt: createdAt.Add(time.Hour), ID: "00000000-0000-0000-0000-000000000001"}
+		tasks := []domain.Task{
+			{ID: "00000000-0000-0000-0000-000000000002", CreatedAt: createdAt},
+			{ID: "00000000-0000-0000-0000-000000000003", CreatedAt: createdAt.Add(-time.Hour)},
+		}
+		taskRepo.On("ListTasksByCursor", "list-123", domain.TaskFilter{}, cursor, 2).Return(tasks, true, nil)
+
+		result, page, err := service.ListTasksByCursor(authDisabledContext(), "list-123", domain.TaskFilter{}, cursor, 2)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		next, err := domain.ParseCursor(page.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, tasks[1].ID, next.ID)
+		assert.False(t, next.Before)
+
+		prev, err := domain.ParseCursor(page.Prev)
+		assert.NoError(t, err)
+		assert.Equal(t, tasks[0].ID, prev.ID)
+		assert.True(t, prev.Before)
+	})
+
+	for name, tc := range map[string]struct {
+		filter domain.TaskFilter
+		limit  int
+	}{
+		"sort by priority": {filter: domain.TaskFilter{Sort: []domain.TaskSort{{Field: domain.TaskSortPriority}}}, limit: 20},
+		"zero limit":       {limit: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			taskRepo := new(MockTaskRepository)
+			listRepo := new(MockListRepository)
+			service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+			_, _, err := service.ListTasksByCursor(authDisabledContext(), "list-123", tc.filter, nil, tc.limit)
+			assert.ErrorIs(t, err, ErrValidation)
+			taskRepo.AssertNotCalled(t, "ListTasksByCursor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestTaskService_Subtasks(t *testing.T) {
+	t.Run("create subtask in the same list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("GetByIDTask", "parent").Return(domain.Task{ID: "parent", ListID: "list-1"}, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.ParentTaskID != nil && *task.ParentTaskID == "parent"
+		})).Return(domain.Task{ID: "child", ListID: "list-1", ParentTaskID: strPtr("parent")}, nil)
+
+		result, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Child", ParentTaskID: strPtr("parent")})
+		assert.NoError(t, err)
+		assert.Equal(t, "parent", *result.ParentTaskID)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("parent from another list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("GetByIDTask", "parent").Return(domain.Task{ID: "parent", ListID: "list-2"}, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Child", ParentTaskID: strPtr("parent")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+	})
+
+	t.Run("maximum depth exceeded", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		// Цепочка t5 -> t4 -> t3 -> t2 -> t1: t5 уже на максимальной глубине
+		taskRepo.On("GetByIDTask", "t1").Return(domain.Task{ID: "t1", ListID: "list-1"}, nil)
+		for i := 2; i <= MaxTaskDepth; i++ {
+			id := "t" + strconv.Itoa(i)
+			taskRepo.On("GetByIDTask", id).
+				Return(domain.Task{ID: id, ListID: "list-1", ParentTaskID: strPtr("t" + strconv.Itoa(i-1))}, nil)
+		}
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Too deep", ParentTaskID: strPtr("t5")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+	})
+
+	t.Run("reparent under own descendant", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "root").Return(domain.Task{ID: "root", ListID: "list-1", Text: "Root"}, nil)
+		taskRepo.On("GetByIDTask", "grandchild").
+			Return(domain.Task{ID: "grandchild", ListID: "list-1", ParentTaskID: strPtr("child")}, nil)
+		taskRepo.On("ListDescendants", "root").Return([]domain.Task{
+			{ID: "child", ListID: "list-1", ParentTaskID: strPtr("root")},
+			{ID: "grandchild", ListID: "list-1", ParentTaskID: strPtr("child")},
+		}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "root", domain.UpdateTaskRequest{ParentTaskID: strPtr("grandchild")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("task cannot be its own parent", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1", Text: "Task"}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{ParentTaskID: strPtr("task-1")})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("cascade completion", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
+		taskRepo.On("ListDescendants", mock.Anything).Return([]domain.Task{}, nil)
+
+		taskRepo.On("GetByIDTask", "parent").Return(domain.Task{ID: "parent", ListID: "list-1", Text: "Parent"}, nil)
+		taskRepo.On("UpdateTaskWithSubtasks", domain.Task{ID: "parent", ListID: "list-1", Text: "Parent", Status: domain.StatusDone, Completed: true}).
+			Return(domain.Task{ID: "parent", Completed: true}, nil)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "parent", domain.UpdateTaskRequest{Completed: &completed, Cascade: true})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+}
+
+func TestTaskService_ListTaskTree(t *testing.T) {
+	taskRepo := new(MockTaskRepository)
+	listRepo := new(MockListRepository)
+	service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+	taskRepo.On("ListAllTasks", "list-1", domain.TaskFilter{}).Return([]domain.Task{
+		{ID: "a", ListID: "list-1"},
+		{ID: "a1", ListID: "list-1", ParentTaskID: strPtr("a")},
+		{ID: "b", ListID: "list-1"},
+		{ID: "a1x", ListID: "list-1", ParentTaskID: strPtr("a1")},
+		// Родитель не попал в выборку — задача становится корнем
+		{ID: "orphan", ListID: "list-1", ParentTaskID: strPtr("filtered-out")},
+	}, nil)
+
+	roots, total, err := service.ListTaskTree(authDisabledContext(), "list-1", domain.TaskFilter{}, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, roots, 2)
+	assert.Equal(t, "a", roots[0].ID)
+	assert.Len(t, roots[0].Subtasks, 1)
+	assert.Equal(t, "a1", roots[0].Subtasks[0].ID)
+	assert.Equal(t, "a1x", roots[0].Subtasks[0].Subtasks[0].ID)
+	assert.Equal(t, "b", roots[1].ID)
+	assert.Empty(t, roots[1].Subtasks)
+
+	roots, _, err = service.ListTaskTree(authDisabledContext(), "list-1", domain.TaskFilter{}, 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, roots, 1)
+	assert.Equal(t, "orphan", roots[0].ID)
+}
+
+func TestTaskService_MoveTask(t *testing.T) {
+	t.Run("after a task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "moved").Return(domain.Task{ID: "moved", ListID: "list-1", Position: "x"}, nil)
+		taskRepo.On("GetByIDTask", "anchor").Return(domain.Task{ID: "anchor", ListID: "list-1", Position: "a"}, nil)
+		taskRepo.On("NextPosition", "list-1", "a", "moved").Return("c", nil)
+		taskRepo.On("SetPosition", "moved", "b").Return(domain.Task{ID: "moved", Position: "b"}, nil)
+
+		result, err := service.MoveTask(authDisabledContext(), "moved", domain.MoveTaskRequest{AfterTaskID: strPtr("anchor")})
+		assert.NoError(t, err)
+		assert.Equal(t, "b", result.Position)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("before the first task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "moved").Return(domain.Task{ID: "moved", ListID: "list-1", Position: "x"}, nil)
+		taskRepo.On("GetByIDTask", "first").Return(domain.Task{ID: "first", ListID: "list-1", Position: "i"}, nil)
+		taskRepo.On("PrevPosition", "list-1", "i", "moved").Return("", nil)
+		taskRepo.On("SetPosition", "moved", mock.MatchedBy(func(position string) bool {
+			return position < "i"
+		})).Return(domain.Task{ID: "moved"}, nil)
+
+		_, err := service.MoveTask(authDisabledContext(), "moved", domain.MoveTaskRequest{BeforeTaskID: strPtr("first")})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("neighbours in wrong order", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "moved").Return(domain.Task{ID: "moved", ListID: "list-1", Position: "x"}, nil)
+		taskRepo.On("GetByIDTask", "a").Return(domain.Task{ID: "a", ListID: "list-1", Position: "m"}, nil)
+		taskRepo.On("GetByIDTask", "b").Return(domain.Task{ID: "b", ListID: "list-1", Position: "c"}, nil)
+
+		_, err := service.MoveTask(authDisabledContext(), "moved", domain.MoveTaskRequest{AfterTaskID: strPtr("a"), BeforeTaskID: strPtr("b")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "SetPosition", mock.Anything, mock.Anything)
+	})
+
+	t.Run("anchor from another list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "moved").Return(domain.Task{ID: "moved", ListID: "list-1", Position: "x"}, nil)
+		taskRepo.On("GetByIDTask", "other").Return(domain.Task{ID: "other", ListID: "list-2", Position: "a"}, nil)
+
+		_, err := service.MoveTask(authDisabledContext(), "moved", domain.MoveTaskRequest{AfterTaskID: strPtr("other")})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("no neighbours given", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		_, err := service.MoveTask(authDisabledContext(), "moved", domain.MoveTaskRequest{})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "GetByIDTask", mock.Anything)
+	})
+}
+
+func TestTaskService_MoveTasksBetweenLists(t *testing.T) {
+	t.Run("subtree moves and root detaches from parent", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-2").Return(domain.List{ID: "list-2"}, nil)
+		taskRepo.On("GetByIDTask", "root").Return(domain.Task{ID: "root", ListID: "list-1", ParentTaskID: strPtr("parent"), Completed: true}, nil)
+		taskRepo.On("ListDescendants", "root").Return([]domain.Task{
+			{ID: "child", ListID: "list-1", ParentTaskID: strPtr("root")},
+		}, nil)
+		taskRepo.On("LastPosition", "list-2").Return("m", nil)
+		taskRepo.On("MoveTasks", mock.MatchedBy(func(moves []domain.TaskMove) bool {
+			return len(moves) == 2 &&
+				moves[0].ID == "root" && moves[0].ParentTaskID == nil &&
+				moves[1].ID == "child" && *moves[1].ParentTaskID == "root" &&
+				moves[0].ListID == "list-2" && moves[1].ListID == "list-2" &&
+				"m" < moves[0].Position && moves[0].Position < moves[1].Position &&
+				moves[0].ResetCompleted
+		})).Return([]domain.Task{{ID: "root", ListID: "list-2"}, {ID: "child", ListID: "list-2"}}, nil)
+
+		result, err := service.MoveTask(authDisabledContext(), "root", domain.MoveTaskRequest{ListID: strPtr("list-2"), ResetCompleted: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "list-2", result.ListID)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("target list not found", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task").Return(domain.Task{ID: "task", ListID: "list-1"}, nil)
+		listRepo.On("GetByID", "missing").Return(domain.List{}, postgres.ErrNotFound)
+
+		_, err := service.MoveTask(authDisabledContext(), "task", domain.MoveTaskRequest{ListID: strPtr("missing")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "MoveTasks", mock.Anything)
+	})
+
+	t.Run("batch skips tasks already in target list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-2").Return(domain.List{ID: "list-2"}, nil)
+		taskRepo.On("GetByIDTask", "a").Return(domain.Task{ID: "a", ListID: "list-1"}, nil)
+		taskRepo.On("GetByIDTask", "b").Return(domain.Task{ID: "b", ListID: "list-2"}, nil)
+		taskRepo.On("ListDescendants", "a").Return([]domain.Task{}, nil)
+		taskRepo.On("LastPosition", "list-2").Return("", nil)
+		taskRepo.On("MoveTasks", mock.MatchedBy(func(moves []domain.TaskMove) bool {
+			return len(moves) == 1 && moves[0].ID == "a" && !moves[0].ResetCompleted
+		})).Return([]domain.Task{{ID: "a", ListID: "list-2"}}, nil)
+
+		result, err := service.MoveTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: []string{"a", "b", "a"}, ListID: "list-2"})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("batch validation", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		_, err := service.MoveTasks(authDisabledContext(), domain.TransferTasksRequest{ListID: "list-2"})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = service.MoveTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: []string{"a"}})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		ids := make([]string, MaxBatchSize+1)
+		for i := range ids {
+			ids[i] = strconv.Itoa(i)
+		}
+		_, err = service.CopyTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: ids, ListID: "list-2"})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		listRepo.On("GetByID", "list-2").Return(domain.List{ID: "list-2"}, nil)
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+		_, err = service.MoveTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: []string{"missing"}, ListID: "list-2"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestTaskService_CopyTasks(t *testing.T) {
+	t.Run("copy into same list keeps completion", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		due := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		source := domain.Task{ID: "src", ListID: "list-1", ParentTaskID: strPtr("parent"), Text: "Buy milk", Status: domain.StatusDone, Completed: true, Priority: domain.PriorityHigh, DueAt: &due, Position: "a"}
+		taskRepo.On("GetByIDTask", "src").Return(source, nil)
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("LastPosition", "list-1").Return("z", nil)
+		taskRepo.On("CopyTasks", mock.MatchedBy(func(copies []domain.TaskCopy) bool {
+			c := copies[0]
+			return len(copies) == 1 && c.SourceID == "src" &&
+				c.Task.ListID == "list-1" && c.Task.ParentTaskID == nil &&
+				c.Task.Text == "Buy milk" && c.Task.Completed &&
+				c.Task.Priority == domain.PriorityHigh && c.Task.DueAt == &due &&
+				c.Task.Position > "z"
+		})).Return([]domain.Task{{ID: "copy", ListID: "list-1"}}, nil)
+
+		result, err := service.CopyTask(authDisabledContext(), "src", domain.CopyTaskRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "copy", result.ID)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("batch copy resets completion", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-2").Return(domain.List{ID: "list-2"}, nil)
+		taskRepo.On("GetByIDTask", "a").Return(domain.Task{ID: "a", ListID: "list-1", Status: domain.StatusDone, Completed: true}, nil)
+		taskRepo.On("GetByIDTask", "b").Return(domain.Task{ID: "b", ListID: "list-1", Status: domain.StatusDone, Completed: true}, nil)
+		taskRepo.On("LastPosition", "list-2").Return("", nil)
+		taskRepo.On("CopyTasks", mock.MatchedBy(func(copies []domain.TaskCopy) bool {
+			return len(copies) == 2 &&
+				copies[0].SourceID == "a" && copies[1].SourceID == "b" &&
+				!copies[0].Task.Completed && !copies[1].Task.Completed &&
+				copies[0].Task.Position < copies[1].Task.Position
+		})).Return([]domain.Task{{ID: "a2"}, {ID: "b2"}}, nil)
+
+		result, err := service.CopyTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: []string{"a", "b"}, ListID: "list-2", ResetCompleted: true})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		taskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskService_Recurrence(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("create normalizes rule", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.RecurrenceRule != nil && *task.RecurrenceRule == "FREQ=WEEKLY;BYDAY=MO,FR"
+		})).Return(domain.Task{ID: "task-1"}, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Stand-up", RecurrenceRule: strPtr("rrule:freq=weekly;byday=fr,mo")})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Stand-up", RecurrenceRule: strPtr("FREQ=HOURLY")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		_, err = service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{RecurrenceRule: strPtr("daily"), ClearRecurrence: true})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("completion spawns next occurrence", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+		service.now = func() time.Time { return now }
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
+		taskRepo.On("ListDescendants", mock.Anything).Return([]domain.Task{}, nil)
+
+		due := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{
+			ID: "task-1", ListID: "list-1", ParentTaskID: strPtr("parent"), Text: "Take out trash",
+			Priority: domain.PriorityLow, DueAt: &due, RecurrenceRule: strPtr("FREQ=DAILY;COUNT=3"),
+		}, nil)
+		taskRepo.On("LastPosition", "list-1").Return("m", nil)
+		taskRepo.On("CompleteRecurringTask",
+			mock.MatchedBy(func(task domain.Task) bool {
+				return task.Completed && task.RecurrenceRule == nil
+			}),
+			mock.MatchedBy(func(next domain.Task) bool {
+				return next.ListID == "list-1" && *next.ParentTaskID == "parent" &&
+					next.Text == "Take out trash" && next.Priority == domain.PriorityLow &&
+					next.Status == domain.StatusTodo && !next.Completed &&
+					next.DueAt.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)) &&
+					*next.RecurrenceRule == "FREQ=DAILY;COUNT=2" && next.Position > "m"
+			}),
+			true,
+		).Return(domain.Task{ID: "task-1", Completed: true}, domain.Task{ID: "task-2"}, nil)
+
+		completed := true
+		result, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed, Cascade: true})
+		assert.NoError(t, err)
+		assert.True(t, result.Completed)
+		taskRepo.AssertExpectations(t)
+		taskRepo.AssertNotCalled(t, "UpdateTaskWithSubtasks", mock.Anything)
+	})
+
+	t.Run("past occurrences are skipped", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+		service.now = func() time.Time { return now }
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
+
+		due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1", DueAt: &due, RecurrenceRule: strPtr("FREQ=WEEKLY")}, nil)
+		taskRepo.On("LastPosition", "list-1").Return("", nil)
+		taskRepo.On("CompleteRecurringTask", mock.Anything, mock.MatchedBy(func(next domain.Task) bool {
+			return next.DueAt.Equal(time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC))
+		}), false).Return(domain.Task{}, domain.Task{}, nil)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("last occurrence completes without spawning", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+		service.now = func() time.Time { return now }
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
+
+		task := domain.Task{ID: "task-1", ListID: "list-1", Status: domain.StatusTodo, RecurrenceRule: strPtr("FREQ=DAILY;COUNT=1")}
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		task.SetStatus(domain.StatusDone)
+		taskRepo.On("UpdateTask", task).Return(task, nil)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.NoError(t, err)
+		taskRepo.AssertNotCalled(t, "CompleteRecurringTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already completed task does not spawn again", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		task := domain.Task{ID: "task-1", ListID: "list-1", Status: domain.StatusDone, Completed: true, RecurrenceRule: strPtr("FREQ=DAILY")}
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		taskRepo.On("UpdateTask", task).Return(task, nil)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.NoError(t, err)
+		taskRepo.AssertNotCalled(t, "CompleteRecurringTask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("task already has next occurrence", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+		service.now = func() time.Time { return now }
+
+		taskRepo.On("ListBlockers", mock.Anything).Return([]domain.Task{}, nil)
+
+		due := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1", DueAt: &due, RecurrenceRule: strPtr("FREQ=DAILY")}, nil)
+		taskRepo.On("LastPosition", "list-1").Return("m", nil)
+		taskRepo.On("CompleteRecurringTask", mock.Anything, mock.Anything, false).
+			Return(domain.Task{}, domain.Task{}, postgres.ErrAlreadyExists)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+func TestTaskService_RestoreTask(t *testing.T) {
+	t.Run("restores in place", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1", ParentTaskID: strPtr("parent")}, nil)
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("GetByIDTask", "parent").Return(domain.Task{ID: "parent", ListID: "list-1"}, nil)
+		taskRepo.On("RestoreTask", "task-1", false).Return(domain.Task{ID: "task-1"}, nil)
+
+		_, err := service.RestoreTask(authDisabledContext(), "task-1")
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("deleted parent detaches task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1", ParentTaskID: strPtr("parent")}, nil)
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("GetByIDTask", "parent").Return(domain.Task{}, postgres.ErrNotFound)
+		taskRepo.On("RestoreTask", "task-1", true).Return(domain.Task{ID: "task-1"}, nil)
+
+		_, err := service.RestoreTask(authDisabledContext(), "task-1")
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("deleted list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-1"}, nil)
+		listRepo.On("GetByID", "list-1").Return(domain.List{}, postgres.ErrNotFound)
+
+		_, err := service.RestoreTask(authDisabledContext(), "task-1")
+		assert.ErrorIs(t, err, ErrConflict)
+		taskRepo.AssertNotCalled(t, "RestoreTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not in trash", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		taskRepo.On("GetDeletedTask", "task-1").Return(domain.Task{}, postgres.ErrNotFound)
+
+		_, err := service.RestoreTask(authDisabledContext(), "task-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestTaskService_ArchivedList(t *testing.T) {
+	archivedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	archived := domain.List{ID: "list-1", ArchivedAt: &archivedAt}
+
+	t.Run("create task rejected", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(archived, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Новая задача"})
+		assert.ErrorIs(t, err, ErrConflict)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+	})
+
+	t.Run("move and copy into archived list rejected", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(archived, nil)
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", ListID: "list-2"}, nil)
+
+		_, err := service.MoveTask(authDisabledContext(), "task-1", domain.MoveTaskRequest{ListID: strPtr("list-1")})
+		assert.ErrorIs(t, err, ErrConflict)
+
+		_, err = service.CopyTasks(authDisabledContext(), domain.TransferTasksRequest{TaskIDs: []string{"task-1"}, ListID: "list-1"})
+		assert.ErrorIs(t, err, ErrConflict)
+		taskRepo.AssertNotCalled(t, "MoveTasks", mock.Anything)
+		taskRepo.AssertNotCalled(t, "CopyTasks", mock.Anything)
+	})
+}
+
+func TestTaskService_Status(t *testing.T) {
+	t.Run("new task gets initial status", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		service := NewTaskService(taskRepo, listRepo, new(MockUserRepository))
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.Status == domain.StatusTodo && !task.Completed
+		})).Return(domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Новая"})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+
+		_, err = service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Новая", Status: "review"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("allowed transition", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+		taskRepo.On("UpdateTask", domain.Task{ID: "task-1", Status: domain.StatusInProgress}).
+			Return(domain.Task{ID: "task-1", Status: domain.StatusInProgress}, nil)
+
+		status := domain.StatusInProgress
+		result, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &status})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, result.Status)
+	})
+
+	t.Run("forbidden transition", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", Status: domain.StatusBlocked}, nil)
+
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.ErrorIs(t, err, ErrConflict)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("completed false reopens done task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", Status: domain.StatusDone, Completed: true}, nil)
+		taskRepo.On("UpdateTask", domain.Task{ID: "task-1", Status: domain.StatusTodo}).
+			Return(domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+
+		completed := false
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Completed: &completed})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("completed contradicts status", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+
+		status := domain.StatusInProgress
+		completed := true
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &status, Completed: &completed})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("custom workflow", func(t *testing.T) {
+		workflow, err := domain.ParseTaskWorkflow("backlog>done;done>backlog")
+		require.NoError(t, err)
+
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository)).WithWorkflow(workflow)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1", Status: "backlog"}, nil)
+
+		status := domain.StatusInProgress
+		_, err = service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &status})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("filter by unknown status", func(t *testing.T) {
+		service := NewTaskService(new(MockTaskRepository), new(MockListRepository), new(MockUserRepository))
+
+		_, _, err := service.ListTasks(authDisabledContext(), "list-1", domain.TaskFilter{Statuses: []domain.TaskStatus{"review"}}, 20, 0)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("group by status", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("ListAllTasks", "list-1", domain.TaskFilter{}).Return([]domain.Task{
+			{ID: "a", Status: domain.StatusDone},
+			{ID: "b", Status: domain.StatusTodo},
+			{ID: "c", Status: domain.StatusTodo},
+			{ID: "d", Status: "review"},
+		}, nil)
+
+		groups, total, err := service.ListTaskGroups(authDisabledContext(), "list-1", domain.TaskFilter{}, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		require.Len(t, groups, 5)
+
+		assert.Equal(t, domain.StatusTodo, groups[0].Status)
+		assert.Equal(t, 2, groups[0].Total)
+		require.Len(t, groups[0].Tasks, 1)
+		assert.Equal(t, "b", groups[0].Tasks[0].ID)
+		assert.Equal(t, domain.StatusInProgress, groups[1].Status)
+		assert.Empty(t, groups[1].Tasks)
+		assert.Equal(t, domain.StatusDone, groups[3].Status)
+		// Статусы вне рабочего процесса идут последними
+		assert.Equal(t, domain.TaskStatus("review"), groups[4].Status)
+	})
+}
+
+func TestTaskService_Dependencies(t *testing.T) {
+	t.Run("task cannot block itself", func(t *testing.T) {
+		service := NewTaskService(new(MockTaskRepository), new(MockListRepository), new(MockUserRepository))
+
+		err := service.AddDependency(authDisabledContext(), "task-1", "task-1")
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("cycle is rejected", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		// task-1 ждет task-2, task-2 ждет task-3: task-3 не может ждать task-1
+		taskRepo.On("GetByIDTask", mock.Anything).Return(domain.Task{}, nil)
+		taskRepo.On("ListBlockerIDs", "task-1").Return([]string{"task-2"}, nil)
+		taskRepo.On("ListBlockerIDs", "task-2").Return([]string{"task-3"}, nil)
+
+		err := service.AddDependency(authDisabledContext(), "task-3", "task-1")
+		assert.ErrorIs(t, err, ErrConflict)
+		taskRepo.AssertNotCalled(t, "AddDependency", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dependency is added", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", mock.Anything).Return(domain.Task{}, nil)
+		taskRepo.On("ListBlockerIDs", "task-2").Return([]string{"task-3"}, nil)
+		taskRepo.On("ListBlockerIDs", "task-3").Return([]string{}, nil)
+		taskRepo.On("AddDependency", "task-1", "task-2").Return(nil)
+
+		err := service.AddDependency(authDisabledContext(), "task-1", "task-2")
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing blocker", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(domain.Task{ID: "task-1"}, nil)
+		taskRepo.On("GetByIDTask", "missing").Return(domain.Task{}, postgres.ErrNotFound)
+
+		err := service.AddDependency(authDisabledContext(), "task-1", "missing")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+
+	task := domain.Task{ID: "task-1", ListID: "list-1", Text: "Выпустить релиз", Status: domain.StatusTodo}
+	done := domain.StatusDone
+
+	t.Run("open blocker prevents completion", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		taskRepo.On("ListBlockers", "task-1").Return([]domain.Task{
+			{ID: "task-2", Status: domain.StatusDone, Completed: true},
+			{ID: "task-3", Status: domain.StatusInProgress},
+		}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &done})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Contains(t, err.Error(), "task-3")
+		assert.NotContains(t, err.Error(), "task-2")
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("force completes blocked task", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		completed := task
+		completed.SetStatus(domain.StatusDone)
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		taskRepo.On("UpdateTask", completed).Return(completed, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &done, Force: true})
+		assert.NoError(t, err)
+		taskRepo.AssertNotCalled(t, "ListBlockers", mock.Anything)
+	})
+
+	t.Run("blockers checked against state in transaction", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		// Сервис прочитал уже завершенную задачу, но до изменения ее вернули в работу:
+		// проверка в транзакции видит незавершенную задачу и открытую блокирующую
+		completed := task
+		completed.SetStatus(domain.StatusDone)
+		taskRepo.On("GetByIDTask", "task-1").Return(completed, nil).Once()
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil).Once()
+		taskRepo.On("ListBlockers", "task-1").Return([]domain.Task{{ID: "task-3", Status: domain.StatusTodo}}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &done})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Contains(t, err.Error(), "task-3")
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("cascade checks subtasks", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		// Подзадача ждет другую подзадачу, которая завершается тем же каскадом,
+		// и внешнюю задачу, которая еще не выполнена
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		taskRepo.On("ListDescendants", "task-1").Return([]domain.Task{
+			{ID: "sub-1", ParentTaskID: strPtr("task-1")},
+			{ID: "sub-2", ParentTaskID: strPtr("task-1")},
+		}, nil)
+		taskRepo.On("ListBlockers", "task-1").Return([]domain.Task{}, nil)
+		taskRepo.On("ListBlockers", "sub-1").Return([]domain.Task{{ID: "sub-2"}, {ID: "other"}}, nil)
+		taskRepo.On("ListBlockers", "sub-2").Return([]domain.Task{}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &done, Cascade: true})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Contains(t, err.Error(), "other")
+		assert.NotContains(t, err.Error(), "sub-2")
+	})
+
+	t.Run("cascade respects workflow of subtasks", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		// Приостановленную подзадачу нельзя сразу завершить: blocked → done запрещен
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		taskRepo.On("ListDescendants", "task-1").Return([]domain.Task{
+			{ID: "sub-1", ParentTaskID: strPtr("task-1"), Status: domain.StatusInProgress},
+			{ID: "sub-2", ParentTaskID: strPtr("task-1"), Status: domain.StatusBlocked},
+		}, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{Status: &done, Cascade: true, Force: true})
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Contains(t, err.Error(), "sub-2")
+		assert.NotContains(t, err.Error(), "sub-1")
+		taskRepo.AssertNotCalled(t, "UpdateTaskWithSubtasks", mock.Anything)
+	})
+}
+
+func TestTaskService_Assignee(t *testing.T) {
+	task := domain.Task{ID: "task-1", ListID: "list-1", Text: "Подготовить отчет", Status: domain.StatusTodo}
+
+	t.Run("create with assignee", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		userRepo := new(MockUserRepository)
+		service := NewTaskService(taskRepo, listRepo, userRepo)
+
+		listRepo.On("GetByID", "list-1").Return(domain.List{ID: "list-1"}, nil)
+		userRepo.On("GetByID", "user-1").Return(domain.User{ID: "user-1"}, nil)
+		listRepo.On("GetMemberRole", "list-1", "user-1").Return(domain.RoleEditor, nil)
+		taskRepo.On("CreateTask", mock.MatchedBy(func(task domain.Task) bool {
+			return task.AssigneeID != nil && *task.AssigneeID == "user-1"
+		})).Return(domain.Task{ID: "task-1", AssigneeID: strPtr("user-1")}, nil)
+
+		_, err := service.CreateTask(authDisabledContext(), "list-1", domain.CreateTaskRequest{Text: "Отчет", AssigneeID: strPtr("user-1")})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown assignee", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		userRepo := new(MockUserRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), userRepo)
+
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		userRepo.On("GetByID", "missing").Return(domain.User{}, postgres.ErrNotFound)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{AssigneeID: strPtr("missing")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("assignee outside the list", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		userRepo := new(MockUserRepository)
+		service := NewTaskService(taskRepo, listRepo, userRepo)
+
+		// Пользователь есть в рабочем пространстве, но не участвует в списке задачи
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		userRepo.On("GetByID", "user-2").Return(domain.User{ID: "user-2"}, nil)
+		listRepo.On("GetMemberRole", "list-1", "user-2").Return(domain.ListRole(""), postgres.ErrNotFound)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{AssigneeID: strPtr("user-2")})
+		assert.ErrorIs(t, err, ErrValidation)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+	})
+
+	t.Run("clear assignee", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		assigned := task
+		assigned.AssigneeID = strPtr("user-1")
+		taskRepo.On("GetByIDTask", "task-1").Return(assigned, nil)
+		taskRepo.On("UpdateTask", task).Return(task, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{ClearAssignee: true})
+		assert.NoError(t, err)
+		taskRepo.AssertExpectations(t)
+	})
+
+	t.Run("assignee and clear_assignee together", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), new(MockUserRepository))
+
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+
+		_, err := service.UpdateTask(authDisabledContext(), "task-1", domain.UpdateTaskRequest{AssigneeID: strPtr("user-1"), ClearAssignee: true})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("tasks of missing user", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		userRepo := new(MockUserRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), userRepo)
+
+		userRepo.On("GetByID", "missing").Return(domain.User{}, postgres.ErrNotFound)
+
+		_, _, err := service.ListAssignedTasks(authDisabledContext(), "missing", domain.TaskFilter{}, 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+		taskRepo.AssertNotCalled(t, "ListAssignedTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("tasks of user", func(t *testing.T) {
+		taskRepo := new(MockTaskRepository)
+		userRepo := new(MockUserRepository)
+		service := NewTaskService(taskRepo, new(MockListRepository), userRepo)
+
+		userRepo.On("GetByID", "user-1").Return(domain.User{ID: "user-1"}, nil)
+		filter := domain.TaskFilter{Statuses: []domain.TaskStatus{domain.StatusTodo, domain.StatusTodo}}
+		taskRepo.On("ListAssignedTasks", "user-1", domain.TaskFilter{Statuses: []domain.TaskStatus{domain.StatusTodo}}, 20, 0).
+			Return([]domain.Task{task}, 1, nil)
+
+		tasks, total, err := service.ListAssignedTasks(authDisabledContext(), "user-1", filter, 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, tasks, 1)
+	})
+}
+
+func TestTaskService_ListAccess(t *testing.T) {
+	task := domain.Task{ID: "task-1", ListID: "list-1", Text: "Купить молоко", Status: domain.StatusTodo}
+	list := domain.List{ID: "list-1", Title: "Покупки"}
+
+	newService := func() (*TaskService, *MockTaskRepository, *MockListRepository) {
+		taskRepo := new(MockTaskRepository)
+		listRepo := new(MockListRepository)
+		taskRepo.On("GetByIDTask", "task-1").Return(task, nil)
+		listRepo.On("GetByID", "list-1").Return(list, nil)
+		listRepo.On("GetMemberRole", "list-1", "viewer").Return(domain.RoleViewer, nil)
+		listRepo.On("GetMemberRole", "list-1", "editor").Return(domain.RoleEditor, nil)
+		listRepo.On("GetMemberRole", mock.Anything, mock.Anything).Return(domain.ListRole(""), postgres.ErrNotFound)
+		return NewTaskService(taskRepo, listRepo, new(MockUserRepository)), taskRepo, listRepo
+	}
+
+	t.Run("viewer reads but cannot change tasks", func(t *testing.T) {
+		service, taskRepo, _ := newService()
+
+		_, err := service.GetByIDTask(userContext("viewer"), "task-1")
+		assert.NoError(t, err)
+
+		text := "Купить кефир"
+		_, err = service.UpdateTask(userContext("viewer"), "task-1", domain.UpdateTaskRequest{Text: &text})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		_, err = service.CreateTask(userContext("viewer"), "list-1", domain.CreateTaskRequest{Text: "Купить хлеб"})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		assert.ErrorIs(t, service.DeleteTask(userContext("viewer"), "task-1"), ErrForbidden)
+		taskRepo.AssertNotCalled(t, "UpdateTask", mock.Anything)
+		taskRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+		taskRepo.AssertNotCalled(t, "DeleteTask", mock.Anything)
+	})
+
+	t.Run("non-member does not see tasks", func(t *testing.T) {
+		service, _, _ := newService()
+
+		_, err := service.GetByIDTask(userContext("stranger"), "task-1")
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+
+		_, _, err = service.ListTasks(userContext("stranger"), "list-1", domain.TaskFilter{}, 20, 0)
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+
+		_, err = service.CreateTask(userContext("stranger"), "list-1", domain.CreateTaskRequest{Text: "Купить хлеб"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("editor changes tasks", func(t *testing.T) {
+		service, taskRepo, _ := newService()
+		taskRepo.On("DeleteTask", "task-1").Return(nil)
+
+		assert.NoError(t, service.DeleteTask(userContext("editor"), "task-1"))
+	})
+
+	t.Run("overdue tasks are filtered by membership", func(t *testing.T) {
+		service, taskRepo, _ := newService()
+		taskRepo.On("ListOverdueTasks", "viewer", 20, 0).Return([]domain.Task{task}, 1, nil)
+
+		tasks, _, err := service.ListOverdueTasks(userContext("viewer"), 20, 0)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+	})
+
+	t.Run("blockers from hidden lists are skipped", func(t *testing.T) {
+		service, taskRepo, _ := newService()
+		taskRepo.On("ListBlockers", "task-1").Return([]domain.Task{
+			{ID: "task-2", ListID: "list-1"},
+			{ID: "task-3", ListID: "list-2"},
+		}, nil)
+
+		blockers, err := service.ListBlockers(userContext("viewer"), "task-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Task{{ID: "task-2", ListID: "list-1"}}, blockers)
+
+		blockers, err = service.ListBlockers(authDisabledContext(), "task-1")
 		assert.NoError(t, err)
+		assert.Len(t, blockers, 2)
 	})
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/storage"
+)
+
+type TrashService struct {
+	repo storage.TrashRepository
+	// retention — сколько удаленные объекты хранятся в корзине до очистки
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewTrashService(repo storage.TrashRepository, retention time.Duration) *TrashService {
+	return &TrashService{
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// List получает содержимое корзины из списков, доступных пользователю запроса;
+// пустой itemType — списки и задачи вместе
+func (s *TrashService) List(ctx context.Context, itemType domain.TrashItemType, limit, offset int) ([]domain.TrashItem, int, error) {
+	if itemType != "" && !itemType.Valid() {
+		return nil, 0, fmt.Errorf("%w: type must be one of list, task", ErrValidation)
+	}
+	member, err := memberID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.List(ctx, domain.TrashFilter{Type: itemType, MemberID: member}, limit, offset)
+}
+
+// Purge окончательно удаляет объекты, пролежавшие в корзине дольше срока хранения
+func (s *TrashService) Purge() (int, error) {
+	return s.repo.Purge(s.now().Add(-s.retention))
+}
+
+// RunPurge периодически очищает корзину, пока не отменен ctx
+func (s *TrashService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.Purge()
+		if err != nil {
+			log.Printf("Trash purge failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("Trash purge removed %d items", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
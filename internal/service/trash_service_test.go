@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"RestApi/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для TrashRepository
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) List(ctx context.Context, filter domain.TrashFilter, limit, offset int) ([]domain.TrashItem, int, error) {
+	args := m.Called(filter, limit, offset)
+	return args.Get(0).([]domain.TrashItem), args.Int(1), args.Error(2)
+}
+
+func (m *MockTrashRepository) Purge(before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTrashService_List(t *testing.T) {
+	repo := new(MockTrashRepository)
+	service := NewTrashService(repo, 24*time.Hour)
+
+	repo.On("List", domain.TrashFilter{Type: domain.TrashItemTask}, 20, 0).Return([]domain.TrashItem{{Type: domain.TrashItemTask, ID: "task-1"}}, 1, nil)
+
+	items, total, err := service.List(authDisabledContext(), domain.TrashItemTask, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, items, 1)
+
+	_, _, err = service.List(authDisabledContext(), "item", 20, 0)
+	assert.ErrorIs(t, err, ErrValidation)
+	repo.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestTrashService_ListByMember(t *testing.T) {
+	repo := new(MockTrashRepository)
+	service := NewTrashService(repo, 24*time.Hour)
+
+	repo.On("List", domain.TrashFilter{MemberID: "user-1"}, 20, 0).Return([]domain.TrashItem{}, 0, nil)
+
+	_, _, err := service.List(userContext("user-1"), "", 20, 0)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	repo := new(MockTrashRepository)
+	service := NewTrashService(repo, 30*24*time.Hour)
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	repo.On("Purge", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)).Return(3, nil)
+
+	purged, err := service.Purge()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, purged)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MaxUserNameLength — максимальная длина имени пользователя в символах
+const MaxUserNameLength = 100
+
+// MaxEmailLength — максимальная длина email
+const MaxEmailLength = 254
+
+const (
+	// MinPasswordLength — минимальная длина пароля в символах
+	MinPasswordLength = 8
+	// MaxPasswordBytes — максимальная длина пароля в байтах: bcrypt учитывает только первые 72
+	MaxPasswordBytes = 72
+)
+
+// passwordCost — стоимость bcrypt; в тестах снижается для скорости
+var passwordCost = bcrypt.DefaultCost
+
+type UserService struct {
+	repo storage.UserRepository
+	// tokens — токены обновления, отзываемые после смены пароля или email
+	tokens storage.RefreshTokenRepository
+}
+
+func NewUserService(repo storage.UserRepository, tokens storage.RefreshTokenRepository) *UserService {
+	return &UserService{
+		repo:   repo,
+		tokens: tokens,
+	}
+}
+
+// Create создает пользователя. Email должен быть уникален без учета регистра
+func (s *UserService) Create(ctx context.Context, request domain.CreateUserRequest) (domain.User, error) {
+	name := strings.TrimSpace(request.Name)
+	if err := validateUserName(name); err != nil {
+		return domain.User{}, err
+	}
+	email, err := normalizeEmail(request.Email)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user := domain.User{Name: name, Email: email}
+	if request.Password != "" {
+		if user.PasswordHash, err = hashPassword(request.Password); err != nil {
+			return domain.User{}, err
+		}
+	}
+
+	return s.repo.Create(ctx, user)
+}
+
+// GetByID получает пользователя рабочего пространства запроса
+func (s *UserService) GetByID(ctx context.Context, id string) (domain.User, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List получает пользователей рабочего пространства запроса
+func (s *UserService) List(ctx context.Context, limit, offset int) ([]domain.User, int, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+// Update меняет только переданные поля пользователя. Менять пользователя может он сам
+// или субъект с правом admin; свой пароль меняется только с текущим паролем.
+// После смены пароля или email все токены обновления пользователя отзываются
+func (s *UserService) Update(ctx context.Context, id string, request domain.UpdateUserRequest) (domain.User, error) {
+	if request.Name == nil && request.Email == nil && request.Password == nil {
+		return domain.User{}, fmt.Errorf("%w: name, email or password must be provided", ErrValidation)
+	}
+	self, err := checkUserAccess(ctx, id)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		if err := validateUserName(name); err != nil {
+			return domain.User{}, err
+		}
+		user.Name = name
+	}
+	if request.Email != nil {
+		email, err := normalizeEmail(*request.Email)
+		if err != nil {
+			return domain.User{}, err
+		}
+		user.Email = email
+	}
+	if request.Password != nil {
+		if self && user.PasswordHash != "" {
+			if request.CurrentPassword == "" {
+				return domain.User{}, fmt.Errorf("%w: current_password is required to change password", ErrValidation)
+			}
+			if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.CurrentPassword)) != nil {
+				return domain.User{}, fmt.Errorf("%w: current password is incorrect", ErrForbidden)
+			}
+		}
+		if user.PasswordHash, err = hashPassword(*request.Password); err != nil {
+			return domain.User{}, err
+		}
+	}
+
+	updated, err := s.repo.Update(ctx, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if request.Password != nil || request.Email != nil {
+		if err := s.tokens.RevokeAllForUser(ctx, id); err != nil {
+			return domain.User{}, fmt.Errorf("revoke refresh tokens: %w", err)
+		}
+	}
+	return updated, nil
+}
+
+// Delete удаляет пользователя; назначенные ему задачи остаются без исполнителя.
+// Удалить пользователя может он сам или субъект с правом admin
+func (s *UserService) Delete(ctx context.Context, id string) error {
+	if _, err := checkUserAccess(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// checkUserAccess проверяет, что субъект запроса может менять пользователя id:
+// это он сам или субъект с правом admin. self — запрос от имени самого пользователя.
+// Запросы без субъекта (аутентификация выключена) не ограничиваются
+func checkUserAccess(ctx context.Context, id string) (self bool, err error) {
+	principal, ok := requestctx.Principal(ctx)
+	if !ok {
+		return false, nil
+	}
+	if principal.Type == domain.PrincipalUser && principal.ID == id {
+		return true, nil
+	}
+	if principal.Allows(domain.ScopeAdmin) {
+		return false, nil
+	}
+	return false, fmt.Errorf("%w: only the user or an admin can change this user", ErrForbidden)
+}
+
+func validateUserName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > MaxUserNameLength {
+		return fmt.Errorf("%w: name must be 1..%d chars", ErrValidation, MaxUserNameLength)
+	}
+	return nil
+}
+
+// hashPassword проверяет длину пароля и возвращает его хэш bcrypt
+func hashPassword(password string) (string, error) {
+	if utf8.RuneCountInString(password) < MinPasswordLength || len(password) > MaxPasswordBytes {
+		return "", fmt.Errorf("%w: password must be at least %d chars and at most %d bytes", ErrValidation, MinPasswordLength, MaxPasswordBytes)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// normalizeEmail проверяет email и возвращает его без пробелов по краям.
+// Допускается только адрес без отображаемого имени.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if len(email) > MaxEmailLength {
+		return "", fmt.Errorf("%w: email must be at most %d chars", ErrValidation, MaxEmailLength)
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return "", fmt.Errorf("%w: email is invalid", ErrValidation)
+	}
+	return email, nil
+}
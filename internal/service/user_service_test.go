@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Mock для UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user domain.User) (domain.User, error) {
+	args := m.Called(user)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (domain.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(email string) (domain.User, error) {
+	args := m.Called(email)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]domain.User, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user domain.User) (domain.User, error) {
+	args := m.Called(user)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestUserService_Create(t *testing.T) {
+	t.Run("trimmed fields", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := NewUserService(userRepo, new(MockRefreshTokenRepository))
+
+		userRepo.On("Create", domain.User{Name: "Алиса", Email: "alice@example.com"}).
+			Return(domain.User{ID: "user-1", Name: "Алиса", Email: "alice@example.com"}, nil)
+
+		user, err := service.Create(context.Background(), domain.CreateUserRequest{Name: " Алиса ", Email: " alice@example.com "})
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", user.ID)
+		userRepo.AssertExpectations(t)
+	})
+
+	for name, request := range map[string]domain.CreateUserRequest{
+		"empty name":         {Name: " ", Email: "alice@example.com"},
+		"long name":          {Name: strings.Repeat("я", MaxUserNameLength+1), Email: "alice@example.com"},
+		"invalid email":      {Name: "Алиса", Email: "alice"},
+		"email with name":    {Name: "Алиса", Email: "Alice <alice@example.com>"},
+		"long email":         {Name: "Алиса", Email: strings.Repeat("a", MaxEmailLength) + "@example.com"},
+		"missing email user": {Name: "Алиса", Email: "@example.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			service := NewUserService(userRepo, new(MockRefreshTokenRepository))
+
+			_, err := service.Create(context.Background(), request)
+			assert.ErrorIs(t, err, ErrValidation)
+			userRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestUserService_Update(t *testing.T) {
+	current := domain.User{ID: "user-1", Name: "Алиса", Email: "alice@example.com"}
+
+	t.Run("only email", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		userRepo.On("GetByID", "user-1").Return(current, nil)
+		userRepo.On("Update", domain.User{ID: "user-1", Name: "Алиса", Email: "alice@corp.example"}).
+			Return(domain.User{ID: "user-1", Name: "Алиса", Email: "alice@corp.example"}, nil)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		email := "alice@corp.example"
+		_, err := service.Update(context.Background(), "user-1", domain.UpdateUserRequest{Email: &email})
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		service := NewUserService(new(MockUserRepository), new(MockRefreshTokenRepository))
+
+		_, err := service.Update(context.Background(), "user-1", domain.UpdateUserRequest{})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("password is stored hashed", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		userRepo.On("GetByID", "user-1").Return(current, nil)
+		userRepo.On("Update", mock.MatchedBy(func(user domain.User) bool {
+			return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse")) == nil
+		})).Return(current, nil)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		password := "correct horse"
+		_, err := service.Update(context.Background(), "user-1", domain.UpdateUserRequest{Password: &password})
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	for name, password := range map[string]string{
+		"short password": "short",
+		"long password":  strings.Repeat("я", MaxPasswordBytes/2+1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			service := NewUserService(userRepo, new(MockRefreshTokenRepository))
+
+			userRepo.On("GetByID", "user-1").Return(current, nil)
+
+			_, err := service.Update(context.Background(), "user-1", domain.UpdateUserRequest{Password: &password})
+			assert.ErrorIs(t, err, ErrValidation)
+			userRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := NewUserService(userRepo, new(MockRefreshTokenRepository))
+
+		userRepo.On("GetByID", "missing").Return(domain.User{}, postgres.ErrNotFound)
+
+		name := "Боб"
+		_, err := service.Update(context.Background(), "missing", domain.UpdateUserRequest{Name: &name})
+		assert.ErrorIs(t, err, postgres.ErrNotFound)
+	})
+}
+
+func TestUserService_UpdateAccess(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	alice := domain.User{ID: "user-1", Name: "Алиса", Email: "alice@example.com", PasswordHash: string(hash)}
+	aliceCtx := requestctx.WithPrincipal(context.Background(), domain.Principal{Type: domain.PrincipalUser, ID: "user-1", Scopes: userScopes})
+	bobCtx := requestctx.WithPrincipal(context.Background(), domain.Principal{Type: domain.PrincipalUser, ID: "user-2", Scopes: userScopes})
+	adminCtx := requestctx.WithPrincipal(context.Background(), domain.Principal{Type: domain.PrincipalAPIKey, ID: "key-1", Scopes: []domain.APIKeyScope{domain.ScopeAdmin}})
+
+	t.Run("another user cannot change password or email", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		password := "new password"
+		_, err := service.Update(bobCtx, "user-1", domain.UpdateUserRequest{Password: &password, CurrentPassword: "correct horse"})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		email := "bob@example.com"
+		_, err = service.Update(bobCtx, "user-1", domain.UpdateUserRequest{Email: &email})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		assert.ErrorIs(t, service.Delete(bobCtx, "user-1"), ErrForbidden)
+		userRepo.AssertNotCalled(t, "Update", mock.Anything)
+		userRepo.AssertNotCalled(t, "Delete", mock.Anything)
+		tokenRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything)
+	})
+
+	t.Run("own password requires current password", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		service := NewUserService(userRepo, new(MockRefreshTokenRepository))
+
+		userRepo.On("GetByID", "user-1").Return(alice, nil)
+
+		password := "new password"
+		_, err := service.Update(aliceCtx, "user-1", domain.UpdateUserRequest{Password: &password})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = service.Update(aliceCtx, "user-1", domain.UpdateUserRequest{Password: &password, CurrentPassword: "wrong horse"})
+		assert.ErrorIs(t, err, ErrForbidden)
+		userRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("own password with current password revokes sessions", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		userRepo.On("GetByID", "user-1").Return(alice, nil)
+		userRepo.On("Update", mock.MatchedBy(func(user domain.User) bool {
+			return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("new password")) == nil
+		})).Return(alice, nil)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		password := "new password"
+		_, err := service.Update(aliceCtx, "user-1", domain.UpdateUserRequest{Password: &password, CurrentPassword: "correct horse"})
+		assert.NoError(t, err)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin resets password without current password", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		userRepo.On("GetByID", "user-1").Return(alice, nil)
+		userRepo.On("Update", mock.Anything).Return(alice, nil)
+		tokenRepo.On("RevokeAllForUser", "user-1").Return(nil)
+
+		password := "new password"
+		_, err := service.Update(adminCtx, "user-1", domain.UpdateUserRequest{Password: &password})
+		assert.NoError(t, err)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("renaming keeps sessions", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+		service := NewUserService(userRepo, tokenRepo)
+
+		userRepo.On("GetByID", "user-1").Return(alice, nil)
+		userRepo.On("Update", mock.Anything).Return(alice, nil)
+
+		name := "Алиса Петровна"
+		_, err := service.Update(aliceCtx, "user-1", domain.UpdateUserRequest{Name: &name})
+		assert.NoError(t, err)
+		tokenRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything)
+	})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// MaxWorkspaceNameLength — максимальная длина названия рабочего пространства в символах
+const MaxWorkspaceNameLength = 100
+
+type WorkspaceService struct {
+	repo storage.WorkspaceRepository
+}
+
+func NewWorkspaceService(repo storage.WorkspaceRepository) *WorkspaceService {
+	return &WorkspaceService{
+		repo: repo,
+	}
+}
+
+// Create создает рабочее пространство
+func (s *WorkspaceService) Create(ctx context.Context, request domain.CreateWorkspaceRequest) (domain.Workspace, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || utf8.RuneCountInString(name) > MaxWorkspaceNameLength {
+		return domain.Workspace{}, fmt.Errorf("%w: name must be 1..%d chars", ErrValidation, MaxWorkspaceNameLength)
+	}
+	return s.repo.Create(ctx, name)
+}
+
+func (s *WorkspaceService) GetByID(id string) (domain.Workspace, error) {
+	return s.repo.GetByID(id)
+}
+
+// normalizeWorkspaceID проверяет ID рабочего пространства, к которому привязываются
+// учетные данные, и приводит его к каноническому виду. Пустой ID — без привязки
+func normalizeWorkspaceID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("%w: workspace_id must be a UUID", ErrValidation)
+	}
+	return parsed.String(), nil
+}
+
+func (s *WorkspaceService) List(limit, offset int) ([]domain.Workspace, int, error) {
+	return s.repo.List(limit, offset)
+}
+
+// AddMember добавляет пользователя в рабочее пространство: после этого он может
+// входить в него по паролю. Ключ, привязанный к рабочему пространству,
+// управляет участниками только этого рабочего пространства
+func (s *WorkspaceService) AddMember(ctx context.Context, workspaceID, userID string) error {
+	workspaceID, userID, err := s.memberIDs(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddMember(ctx, workspaceID, userID)
+}
+
+// RemoveMember исключает пользователя из рабочего пространства.
+// Уже выданные токены действуют до истечения срока
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	workspaceID, userID, err := s.memberIDs(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.RemoveMember(ctx, workspaceID, userID)
+}
+
+// memberIDs проверяет ID рабочего пространства и пользователя и право субъекта
+// управлять участниками этого рабочего пространства
+func (s *WorkspaceService) memberIDs(ctx context.Context, workspaceID, userID string) (string, string, error) {
+	workspace, err := uuid.Parse(strings.TrimSpace(workspaceID))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: workspace id must be a UUID", ErrValidation)
+	}
+	user, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: user id must be a UUID", ErrValidation)
+	}
+	if principal, ok := requestctx.Principal(ctx); ok && principal.WorkspaceID != "" && principal.WorkspaceID != workspace.String() {
+		return "", "", fmt.Errorf("%w: credentials are bound to workspace %s", ErrForbidden, principal.WorkspaceID)
+	}
+	return workspace.String(), user.String(), nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock для WorkspaceRepository
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceRepository) Create(ctx context.Context, name string) (domain.Workspace, error) {
+	args := m.Called(name)
+	return args.Get(0).(domain.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetByID(id string) (domain.Workspace, error) {
+	args := m.Called(id)
+	return args.Get(0).(domain.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) List(limit, offset int) ([]domain.Workspace, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]domain.Workspace), args.Int(1), args.Error(2)
+}
+
+func (m *MockWorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID string) error {
+	args := m.Called(workspaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	args := m.Called(workspaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) HasMember(ctx context.Context, workspaceID, userID string) (bool, error) {
+	args := m.Called(workspaceID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestWorkspaceService_Create(t *testing.T) {
+	t.Run("trimmed name", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo)
+
+		workspaceRepo.On("Create", "Команда А").Return(domain.Workspace{ID: "ws-1", Name: "Команда А"}, nil)
+
+		workspace, err := service.Create(context.Background(), domain.CreateWorkspaceRequest{Name: "  Команда А "})
+		assert.NoError(t, err)
+		assert.Equal(t, "ws-1", workspace.ID)
+		workspaceRepo.AssertExpectations(t)
+	})
+
+	for name, request := range map[string]domain.CreateWorkspaceRequest{
+		"empty name": {Name: "   "},
+		"long name":  {Name: strings.Repeat("я", MaxWorkspaceNameLength+1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			workspaceRepo := new(MockWorkspaceRepository)
+			service := NewWorkspaceService(workspaceRepo)
+
+			_, err := service.Create(context.Background(), request)
+			assert.ErrorIs(t, err, ErrValidation)
+			workspaceRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestWorkspaceService_AddMember(t *testing.T) {
+	const (
+		workspaceID = "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+		userID      = "0b6e5f4d-3c2b-4a19-8e7d-6c5b4a392817"
+	)
+
+	t.Run("adds member", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo)
+
+		workspaceRepo.On("AddMember", workspaceID, userID).Return(nil)
+
+		err := service.AddMember(context.Background(), strings.ToUpper(workspaceID), userID)
+		assert.NoError(t, err)
+		workspaceRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo)
+
+		err := service.AddMember(context.Background(), workspaceID, "alice")
+		assert.ErrorIs(t, err, ErrValidation)
+		workspaceRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("key bound to another workspace", func(t *testing.T) {
+		workspaceRepo := new(MockWorkspaceRepository)
+		service := NewWorkspaceService(workspaceRepo)
+
+		ctx := requestctx.WithPrincipal(context.Background(), domain.Principal{
+			Type:        domain.PrincipalAPIKey,
+			ID:          "key-1",
+			Scopes:      []domain.APIKeyScope{domain.ScopeAdmin},
+			WorkspaceID: domain.DefaultWorkspaceID,
+		})
+		err := service.AddMember(ctx, workspaceID, userID)
+		assert.ErrorIs(t, err, ErrForbidden)
+		workspaceRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+}
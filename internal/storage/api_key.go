@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// APIKeyRepository — интерфейс для работы с API-ключами.
+// Ключи хранятся в виде хэша: поиск идет по хэшу, а не по самому ключу.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key domain.APIKey, keyHash string) (domain.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (domain.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]domain.APIKey, int, error)
+	Revoke(ctx context.Context, id string) error
+	TouchLastUsed(ctx context.Context, id string) error
+}
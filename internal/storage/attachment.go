@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// AttachmentRepository — интерфейс для работы с метаданными вложений
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error)
+	GetByID(ctx context.Context, id string) (domain.Attachment, error)
+	ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Attachment, int, error)
+	Delete(ctx context.Context, id string) error
+	// PendingBlobDeletions возвращает ключи содержимого удаленных вложений
+	PendingBlobDeletions(ctx context.Context, limit int) ([]string, error)
+	// ConfirmBlobDeletion убирает ключ из очереди после удаления содержимого
+	ConfirmBlobDeletion(ctx context.Context, key string) error
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// CommentRepository — интерфейс для работы с комментариями к задачам
+type CommentRepository interface {
+	Create(ctx context.Context, comment domain.Comment) (domain.Comment, error)
+	GetByID(ctx context.Context, id string) (domain.Comment, error)
+	ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Comment, int, error)
+	Update(ctx context.Context, id, text string) (domain.Comment, error)
+	Delete(ctx context.Context, id string) error
+}
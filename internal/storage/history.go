@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// HistoryRepository — интерфейс для чтения истории изменений.
+// Записи добавляются репозиториями списков и задач в транзакции изменения.
+type HistoryRepository interface {
+	ListByEntity(ctx context.Context, entityType domain.HistoryEntityType, entityID string, limit, offset int) ([]domain.HistoryEntry, int, error)
+}
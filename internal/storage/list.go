@@ -1,13 +1,33 @@
 package storage
 
-import "RestApi/internal/domain"
+import (
+	"context"
 
-// ListRepository — интерфейс для работы со списками
+	"RestApi/internal/domain"
+)
+
+// ListRepository — интерфейс для работы со списками.
+// Все методы работают в рабочем пространстве из контекста запроса
 type ListRepository interface {
-	Create(title string) (domain.List, error)
-	GetByID(id string) (domain.List, error)
-	SearchByTitle(title string) ([]domain.List, error)
-	Update(id, title string) (domain.List, error)
-	Delete(id string) error
-	List(limit, offset int) ([]domain.List, int, error)
+	Create(ctx context.Context, title, description string) (domain.List, error)
+	GetByID(ctx context.Context, id string) (domain.List, error)
+	// GetDeleted получает список из корзины
+	GetDeleted(ctx context.Context, id string) (domain.List, error)
+	SearchByTitle(ctx context.Context, search domain.TitleSearch, filter domain.ListFilter) ([]domain.List, error)
+	Update(ctx context.Context, list domain.List) (domain.List, error)
+	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (domain.List, error)
+	List(ctx context.Context, filter domain.ListFilter, limit, offset int) ([]domain.List, int, error)
+	// ListByCursor получает страницу списков после или перед курсором (nil — первая страница)
+	// без подсчета общего количества; more — за страницей есть еще списки
+	ListByCursor(ctx context.Context, filter domain.ListFilter, cursor *domain.Cursor, limit int) ([]domain.List, bool, error)
+	SetArchived(ctx context.Context, id string, archived bool) (domain.List, error)
+	// Stats считает задачи списка по признаку выполнения
+	Stats(ctx context.Context, listID string) (domain.ListStats, error)
+	// GetMemberRole возвращает роль пользователя в списке, в том числе в списке из корзины
+	GetMemberRole(ctx context.Context, listID, userID string) (domain.ListRole, error)
+	ListMembers(ctx context.Context, listID string) ([]domain.ListMember, error)
+	// SetMember добавляет участника списка или меняет его роль
+	SetMember(ctx context.Context, member domain.ListMember) (domain.ListMember, error)
+	RemoveMember(ctx context.Context, listID, userID string) error
 }
@@ -0,0 +1,108 @@
+package mem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"RestApi/internal/domain"
+)
+
+type CommentRepo struct {
+	comments map[string]*domain.Comment
+	mtx      sync.RWMutex
+}
+
+func NewCommentRepo() *CommentRepo {
+	return &CommentRepo{
+		comments: make(map[string]*domain.Comment),
+	}
+}
+
+func (c *CommentRepo) Create(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now().UTC()
+	created := &domain.Comment{
+		ID:        uuid.NewString(),
+		TaskID:    comment.TaskID,
+		Author:    comment.Author,
+		Text:      comment.Text,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	c.comments[created.ID] = created
+	return *created, nil
+}
+
+func (c *CommentRepo) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	comment, ok := c.comments[id]
+	if !ok {
+		return domain.Comment{}, ErrNotFound
+	}
+	return *comment, nil
+}
+
+// ListByTask возвращает комментарии задачи в порядке добавления
+func (c *CommentRepo) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Comment, int, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	all := make([]domain.Comment, 0)
+	for _, comment := range c.comments {
+		if comment.TaskID == taskID {
+			all = append(all, *comment)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+
+	total := len(all)
+	if offset >= total {
+		return []domain.Comment{}, total, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], total, nil
+}
+
+func (c *CommentRepo) Update(ctx context.Context, id, text string) (domain.Comment, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	comment, ok := c.comments[id]
+	if !ok {
+		return domain.Comment{}, ErrNotFound
+	}
+
+	comment.Text = text
+	comment.UpdatedAt = time.Now().UTC()
+	return *comment, nil
+}
+
+func (c *CommentRepo) Delete(ctx context.Context, id string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.comments[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(c.comments, id)
+	return nil
+}
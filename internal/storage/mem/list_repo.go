@@ -1,6 +1,9 @@
 package mem
 import (
+	"context"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 	"sync"
 
@@ -11,12 +14,15 @@ import (
 
 type ListRepo struct {
 	lists map[string]*domain.List
+	// trash — удаленные списки, которые еще можно восстановить
+	trash map[string]*domain.List
 	mtx   sync.RWMutex
 }
 
 func NewListRepo() *ListRepo {
 	return &ListRepo {
 		lists: make(map[string]*domain.List),
+		trash: make(map[string]*domain.List),
 	}
 }
 
@@ -25,7 +31,7 @@ var (
 	ErrNotFound = errors.New("NOT_FOUND")
 )
 
-func (l *ListRepo) Create(title string) (domain.List, error) {
+func (l *ListRepo) Create(ctx context.Context, title, description string) (domain.List, error) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
@@ -38,6 +44,7 @@ func (l *ListRepo) Create(title string) (domain.List, error) {
 	list := &domain.List {
 		ID: 		id,
 		Title: 		title,
+		Description:	description,
 		CreatedAt: 	time.Now().UTC(),
 	}
 
@@ -45,7 +52,7 @@ func (l *ListRepo) Create(title string) (domain.List, error) {
 	return *list, nil
 }
 
-func (l *ListRepo) GetByID(id string) (domain.List, error) {
+func (l *ListRepo) GetByID(ctx context.Context, id string) (domain.List, error) {
 	l.mtx.RLock()
 	defer l.mtx.RUnlock()
 
@@ -56,46 +63,85 @@ func (l *ListRepo) GetByID(id string) (domain.List, error) {
 	return *list, nil
 }
 
-func (l *ListRepo) Update(id string, title string) (domain.List, error) {
+func (l *ListRepo) Update(ctx context.Context, updated domain.List) (domain.List, error) {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 	
-	list, ok := l.lists[id] 
+	list, ok := l.lists[updated.ID] 
 	if !ok {
 		return domain.List{}, ErrNotFound
 	}
 
-	list.Title = title
+	list.Title = updated.Title
+	list.Description = updated.Description
 	return *list, nil
 }
 
 
-func (l *ListRepo) Delete(id string) error {
+func (l *ListRepo) Delete(ctx context.Context, id string) error {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
-	if _, ok := l.lists[id]; !ok {
+	list, ok := l.lists[id]
+	if !ok {
 		return ErrNotFound
 	}
 
+	l.trash[id] = list
 	delete(l.lists, id)
 	return nil
 }
 
-func (l *ListRepo) List(limit, offset int) ([]domain.List, int, error) {
-	l.mtx.RLock()
-	defer l.mtx.RUnlock()
+func (l *ListRepo) SetArchived(ctx context.Context, id string, archived bool) (domain.List, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
 
-	total := len(l.lists)
-	if offset > total {
-		return []domain.List{}, total, nil
+	list, ok := l.lists[id]
+	if !ok {
+		return domain.List{}, ErrNotFound
+	}
+
+	switch {
+	case !archived:
+		list.ArchivedAt = nil
+	case list.ArchivedAt == nil:
+		now := time.Now().UTC()
+		list.ArchivedAt = &now
+	}
+	return *list, nil
+}
+
+func (l *ListRepo) Restore(ctx context.Context, id string) (domain.List, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	list, ok := l.trash[id]
+	if !ok {
+		return domain.List{}, ErrNotFound
 	}
 
-	all := make([]domain.List, 0, total)
+	l.lists[id] = list
+	delete(l.trash, id)
+	return *list, nil
+}
+
+func (l *ListRepo) List(ctx context.Context, filter domain.ListFilter, limit, offset int) ([]domain.List, int, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	all := make([]domain.List, 0, len(l.lists))
 	for _, list := range l.lists {
+		if !matchesArchiveFilter(list, filter) {
+			continue
+		}
 		all = append(all, *list)
 	}
 
+	total := len(all)
+	if offset > total {
+		return []domain.List{}, total, nil
+	}
+
 	start := offset
 	end := total
 	if limit > 0 && start+limit < end {
@@ -109,3 +155,47 @@ func (l *ListRepo) List(limit, offset int) ([]domain.List, int, error) {
 	}
 	return all[start:end], total, nil
 }
+
+// SearchByTitle ищет списки по названию так же, как postgres.ListRepo: подстрока ищется
+// без учета регистра, результаты — от новых к старым; нечеткий поиск сравнивает триграммы
+// и упорядочивает результаты по сходству
+func (l *ListRepo) SearchByTitle(ctx context.Context, search domain.TitleSearch, filter domain.ListFilter) ([]domain.List, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	query := strings.ToLower(search.Query)
+	scores := make(map[string]float64)
+	found := make([]domain.List, 0)
+	for _, list := range l.lists {
+		if !matchesArchiveFilter(list, filter) {
+			continue
+		}
+		if search.Mode == domain.TitleSearchFuzzy {
+			score := similarity(list.Title, search.Query)
+			if score < search.Threshold {
+				continue
+			}
+			scores[list.ID] = score
+		} else if !strings.Contains(strings.ToLower(list.Title), query) {
+			continue
+		}
+		found = append(found, *list)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if scores[found[i].ID] != scores[found[j].ID] {
+			return scores[found[i].ID] > scores[found[j].ID]
+		}
+		return found[i].CreatedAt.After(found[j].CreatedAt)
+	})
+	return found, nil
+}
+
+// matchesArchiveFilter сообщает, попадает ли список в выдачу по признаку архивации
+func matchesArchiveFilter(list *domain.List, filter domain.ListFilter) bool {
+	archived := list.ArchivedAt != nil
+	if filter.ArchivedOnly {
+		return archived
+	}
+	return filter.IncludeArchived || !archived
+}
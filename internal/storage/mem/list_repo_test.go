@@ -0,0 +1,73 @@
+package mem
+
+import (
+	"context"
+	"testing"
+
+	"RestApi/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarity(t *testing.T) {
+	// Значения совпадают с similarity() из pg_trgm
+	assert.InDelta(t, 0.363636, similarity("word", "two words"), 1e-6)
+	assert.InDelta(t, 5.0/11.0, similarity("Покупки", "покупик"), 1e-9)
+	assert.Equal(t, 1.0, similarity("Покупки!", "покупки"))
+	assert.Zero(t, similarity("Покупки", "Работа"))
+	assert.Zero(t, similarity("", "Работа"))
+}
+
+func TestListRepo_SearchByTitle(t *testing.T) {
+	ctx := context.Background()
+	repo := NewListRepo()
+
+	groceries, err := repo.Create(ctx, "Покупки", "")
+	require.NoError(t, err)
+	weekly, err := repo.Create(ctx, "Покупки на неделю", "")
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, "Работа", "")
+	require.NoError(t, err)
+
+	t.Run("substring", func(t *testing.T) {
+		lists, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покуп"}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Len(t, lists, 2)
+
+		lists, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик"}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, lists)
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		lists, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{})
+		require.NoError(t, err)
+		require.Len(t, lists, 1)
+		assert.Equal(t, groceries.ID, lists[0].ID)
+
+		// Более похожие названия идут первыми
+		lists, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупки неделя", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{})
+		require.NoError(t, err)
+		require.Len(t, lists, 2)
+		assert.Equal(t, weekly.ID, lists[0].ID)
+		assert.Equal(t, groceries.ID, lists[1].ID)
+
+		lists, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.5}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, lists)
+	})
+
+	t.Run("archived lists", func(t *testing.T) {
+		_, err := repo.SetArchived(ctx, groceries.ID, true)
+		require.NoError(t, err)
+
+		lists, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, lists)
+
+		lists, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{ArchivedOnly: true})
+		require.NoError(t, err)
+		assert.Len(t, lists, 1)
+	})
+}
@@ -0,0 +1,40 @@
+package mem
+
+import (
+	"strings"
+	"unicode"
+)
+
+// trigrams разбивает строку на триграммы так же, как pg_trgm: строка приводится
+// к нижнему регистру и делится на слова из букв и цифр, каждое слово дополняется
+// двумя пробелами в начале и одним в конце. Повторы не учитываются
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// similarity повторяет similarity() из pg_trgm: доля общих триграмм
+// среди всех триграмм двух строк, от 0 до 1
+func similarity(a, b string) float64 {
+	left, right := trigrams(a), trigrams(b)
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range left {
+		if _, ok := right[trigram]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(left)+len(right)-common)
+}
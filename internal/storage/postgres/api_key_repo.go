@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// apiKeyColumns — колонки API-ключа в порядке, ожидаемом scanAPIKey
+const apiKeyColumns = "id, name, prefix, scopes, COALESCE(workspace_id::text, ''), COALESCE(user_id::text, ''), created_by, created_at, expires_at, last_used_at, revoked_at"
+
+// apiKeyWorkspaceCondition отбирает ключи рабочего пространства $2; ключи без привязки
+// относятся к рабочему пространству по умолчанию $1
+const apiKeyWorkspaceCondition = "COALESCE(workspace_id, $1) = $2"
+
+// lastUsedResolution — как часто обновляется время последнего использования ключа.
+// Запись при каждом запросе не нужна и только нагружает БД.
+const lastUsedResolution = time.Minute
+
+type APIKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepo(pool *pgxpool.Pool) *APIKeyRepo {
+	return &APIKeyRepo{
+		pool: pool,
+	}
+}
+
+func scanAPIKey(row pgx.Row, key *domain.APIKey) error {
+	var scopes []string
+	var createdBy *string
+	if err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&scopes,
+		&key.WorkspaceID,
+		&key.UserID,
+		&createdBy,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	); err != nil {
+		return err
+	}
+
+	key.Scopes = make([]domain.APIKeyScope, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = domain.APIKeyScope(scope)
+	}
+	if createdBy != nil {
+		key.CreatedBy = *createdBy
+	}
+	return nil
+}
+
+// Create сохраняет ключ по его хэшу. Автор берется из контекста запроса.
+// Несуществующее рабочее пространство или пользователь ключа — ErrNotFound
+func (r *APIKeyRepo) Create(ctx context.Context, key domain.APIKey, keyHash string) (domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, expires_at, workspace_id, user_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, '')::uuid, NULLIF($9, '')::uuid)
+		RETURNING ` + apiKeyColumns
+
+	var created domain.APIKey
+	row := r.pool.QueryRow(ctx, query, uuid.New(), key.Name, key.Prefix, keyHash, scopes, requestctx.Actor(ctx), key.ExpiresAt, key.WorkspaceID, key.UserID)
+	if err := scanAPIKey(row, &created); err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return domain.APIKey{}, ErrAlreadyExists
+		}
+		if isPgError(err, pgForeignKeyViolation) {
+			return domain.APIKey{}, ErrNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("create api key: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByHash находит ключ по хэшу, в том числе отозванный
+func (r *APIKeyRepo) GetByHash(ctx context.Context, keyHash string) (domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var key domain.APIKey
+	if err := scanAPIKey(r.pool.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash), &key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.APIKey{}, ErrNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("get api key by hash: %w", err)
+	}
+
+	return key, nil
+}
+
+// List получает ключи рабочего пространства запроса с пагинацией, новые первыми
+func (r *APIKeyRepo) List(ctx context.Context, limit, offset int) ([]domain.APIKey, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workspaceID := requestctx.Workspace(ctx)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM api_keys WHERE `+apiKeyWorkspaceCondition, domain.DefaultWorkspaceID, workspaceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count api keys: %w", err)
+	}
+
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE ` + apiKeyWorkspaceCondition + `
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.pool.Query(ctx, query, domain.DefaultWorkspaceID, workspaceID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		var key domain.APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, 0, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return keys, total, nil
+}
+
+// Revoke отзывает ключ рабочего пространства запроса. Повторный отзыв сохраняет время первого
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE ` + apiKeyWorkspaceCondition + ` AND id = $3`
+	result, err := r.pool.Exec(ctx, query, domain.DefaultWorkspaceID, requestctx.Workspace(ctx), id)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed отмечает использование ключа не чаще раза в lastUsedResolution
+func (r *APIKeyRepo) TouchLastUsed(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $2))
+	`
+	if _, err := r.pool.Exec(ctx, query, id, lastUsedResolution.Seconds()); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+
+	return nil
+}
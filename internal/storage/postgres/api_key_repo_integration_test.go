@@ -0,0 +1,128 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	repo := NewAPIKeyRepo(pool)
+	ctx := requestctx.WithActor(context.Background(), "bootstrap")
+	keyHash := strings.Repeat("a", 64)
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		key, err := repo.Create(ctx, domain.APIKey{
+			Name:   "CI",
+			Prefix: "tk_abcdefgh",
+			Scopes: []domain.APIKeyScope{domain.ScopeRead, domain.ScopeWrite},
+		}, keyHash)
+		require.NoError(t, err)
+		assert.NotEmpty(t, key.ID)
+		assert.Equal(t, []domain.APIKeyScope{domain.ScopeRead, domain.ScopeWrite}, key.Scopes)
+		assert.Equal(t, "bootstrap", key.CreatedBy)
+		assert.Nil(t, key.LastUsedAt)
+
+		// Хэш ключа уникален
+		_, err = repo.Create(ctx, domain.APIKey{Name: "Копия", Prefix: "tk_abcdefgh", Scopes: []domain.APIKeyScope{domain.ScopeRead}}, keyHash)
+		assert.ErrorIs(t, err, ErrAlreadyExists)
+
+		fetched, err := repo.GetByHash(ctx, keyHash)
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, fetched.ID)
+		_, err = repo.GetByHash(ctx, strings.Repeat("b", 64))
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, repo.TouchLastUsed(ctx, key.ID))
+		fetched, err = repo.GetByHash(ctx, keyHash)
+		require.NoError(t, err)
+		require.NotNil(t, fetched.LastUsedAt)
+		lastUsed := *fetched.LastUsedAt
+
+		// Повторное использование в пределах минуты не пишется в БД
+		require.NoError(t, repo.TouchLastUsed(ctx, key.ID))
+		fetched, err = repo.GetByHash(ctx, keyHash)
+		require.NoError(t, err)
+		assert.Equal(t, lastUsed, *fetched.LastUsedAt)
+
+		require.NoError(t, repo.Revoke(ctx, key.ID))
+		fetched, err = repo.GetByHash(ctx, keyHash)
+		require.NoError(t, err)
+		require.NotNil(t, fetched.RevokedAt)
+
+		// Повторный отзыв сохраняет время первого
+		require.NoError(t, repo.Revoke(ctx, key.ID))
+		again, err := repo.GetByHash(ctx, keyHash)
+		require.NoError(t, err)
+		assert.Equal(t, *fetched.RevokedAt, *again.RevokedAt)
+
+		keys, total, err := repo.List(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, key.ID, keys[0].ID)
+	})
+
+	t.Run("Workspace Keys", func(t *testing.T) {
+		workspace, err := NewWorkspaceRepo(pool).Create(ctx, "Ключи")
+		require.NoError(t, err)
+		wsCtx := requestctx.WithWorkspace(ctx, workspace.ID)
+
+		bound, err := repo.Create(ctx, domain.APIKey{Name: "Team", Prefix: "tk_teamteam", Scopes: []domain.APIKeyScope{domain.ScopeRead}, WorkspaceID: workspace.ID}, strings.Repeat("d", 64))
+		require.NoError(t, err)
+
+		keys, total, err := repo.List(wsCtx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, bound.ID, keys[0].ID)
+
+		// Ключ без привязки относится к рабочему пространству по умолчанию
+		_, total, err = repo.List(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		assert.ErrorIs(t, repo.Revoke(ctx, bound.ID), ErrNotFound)
+		require.NoError(t, repo.Revoke(wsCtx, bound.ID))
+	})
+
+	t.Run("Key User", func(t *testing.T) {
+		user, err := NewUserRepo(pool).Create(ctx, domain.User{Name: "Владелец ключа", Email: "key-owner@example.com"})
+		require.NoError(t, err)
+
+		key, err := repo.Create(ctx, domain.APIKey{Name: "Личный", Prefix: "tk_personal", Scopes: []domain.APIKeyScope{domain.ScopeWrite}, UserID: user.ID}, strings.Repeat("e", 64))
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, key.UserID)
+		fetched, err := repo.GetByHash(ctx, strings.Repeat("e", 64))
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, fetched.Principal().UserID)
+
+		// Ключ удаляется вместе с пользователем
+		require.NoError(t, NewUserRepo(pool).Delete(ctx, user.ID))
+		_, err = repo.GetByHash(ctx, strings.Repeat("e", 64))
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = repo.Create(ctx, domain.APIKey{Name: "Чужой", Prefix: "tk_unknownu", Scopes: []domain.APIKeyScope{domain.ScopeWrite}, UserID: user.ID}, strings.Repeat("f", 64))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Revoke Missing", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Revoke(ctx, "00000000-0000-0000-0000-000000000000"), ErrNotFound)
+	})
+
+	t.Run("Unknown Scope", func(t *testing.T) {
+		_, err := repo.Create(ctx, domain.APIKey{Name: "Root", Prefix: "tk_rootroot", Scopes: []domain.APIKeyScope{"root"}}, strings.Repeat("c", 64))
+		assert.Error(t, err)
+	})
+}
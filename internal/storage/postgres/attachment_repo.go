@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// attachmentColumns — колонки вложения в порядке, ожидаемом scanAttachment
+const attachmentColumns = "id, task_id, file_name, content_type, size, storage_key, COALESCE(uploaded_by, ''), created_at"
+
+// liveAttachmentCondition отсекает вложения задач, находящихся в корзине
+const liveAttachmentCondition = "task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)"
+
+type AttachmentRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttachmentRepo(pool *pgxpool.Pool) *AttachmentRepo {
+	return &AttachmentRepo{
+		pool: pool,
+	}
+}
+
+func scanAttachment(row pgx.Row, attachment *domain.Attachment) error {
+	return row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	)
+}
+
+// Create сохраняет метаданные загруженного вложения
+func (r *AttachmentRepo) Create(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO attachments (id, task_id, file_name, content_type, size, storage_key, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+		RETURNING ` + attachmentColumns
+
+	var created domain.Attachment
+	err := scanAttachment(r.pool.QueryRow(ctx, query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		attachment.UploadedBy,
+	), &created)
+	if err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return domain.Attachment{}, ErrNotFound
+		}
+		return domain.Attachment{}, fmt.Errorf("create attachment: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID получает метаданные вложения по ID
+func (r *AttachmentRepo) GetByID(ctx context.Context, id string) (domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1 AND ` + liveAttachmentCondition
+
+	var attachment domain.Attachment
+	if err := scanAttachment(r.pool.QueryRow(ctx, query, id), &attachment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Attachment{}, ErrNotFound
+		}
+		return domain.Attachment{}, fmt.Errorf("get attachment by id: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListByTask получает вложения задачи в порядке загрузки с пагинацией
+func (r *AttachmentRepo) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Attachment, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM attachments WHERE task_id = $1 AND ` + liveAttachmentCondition
+	if err := r.pool.QueryRow(ctx, countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count attachments: %w", err)
+	}
+
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE task_id = $1 AND ` + liveAttachmentCondition + `
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := make([]domain.Attachment, 0)
+	for rows.Next() {
+		var attachment domain.Attachment
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, 0, fmt.Errorf("scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return attachments, total, nil
+}
+
+// Delete удаляет метаданные вложения. Ключ содержимого попадает
+// в очередь удаления триггером
+func (r *AttachmentRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM attachments WHERE id = $1 AND `+liveAttachmentCondition, id)
+	if err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// PendingBlobDeletions возвращает ключи содержимого, ожидающие удаления, начиная с давних
+func (r *AttachmentRepo) PendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT storage_key FROM attachment_blob_deletions ORDER BY created_at LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list blob deletions: %w", err)
+	}
+
+	return collectIDs(rows)
+}
+
+// ConfirmBlobDeletion убирает ключ из очереди удаления
+func (r *AttachmentRepo) ConfirmBlobDeletion(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM attachment_blob_deletions WHERE storage_key = $1`, key); err != nil {
+		return fmt.Errorf("confirm blob deletion: %w", err)
+	}
+	return nil
+}
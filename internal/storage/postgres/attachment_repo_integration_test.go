@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	trashRepo := NewTrashRepo(pool)
+	repo := NewAttachmentRepo(pool)
+	ctx := context.Background()
+
+	list, err := listRepo.Create(ctx, "Документы", "")
+	require.NoError(t, err)
+
+	newAttachment := func(taskID, fileName string) domain.Attachment {
+		id := uuid.NewString()
+		return domain.Attachment{
+			ID:          id,
+			TaskID:      taskID,
+			FileName:    fileName,
+			ContentType: "text/plain",
+			Size:        5,
+			StorageKey:  "tasks/" + taskID + "/" + id,
+		}
+	}
+
+	t.Run("CRUD", func(t *testing.T) {
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Приложить счет"})
+		require.NoError(t, err)
+
+		first := newAttachment(task.ID, "счет.txt")
+		first.UploadedBy = "alice"
+		created, err := repo.Create(ctx, first)
+		require.NoError(t, err)
+		assert.Equal(t, first.StorageKey, created.StorageKey)
+		assert.Equal(t, "alice", created.UploadedBy)
+		_, err = repo.Create(ctx, newAttachment(task.ID, "акт.txt"))
+		require.NoError(t, err)
+
+		attachments, total, err := repo.ListByTask(ctx, task.ID, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "акт.txt", attachments[0].FileName)
+		assert.Empty(t, attachments[0].UploadedBy)
+
+		// Удаление ставит содержимое в очередь на удаление
+		require.NoError(t, repo.Delete(ctx, created.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, created.ID), ErrNotFound)
+
+		keys, err := repo.PendingBlobDeletions(ctx, 10)
+		require.NoError(t, err)
+		assert.Contains(t, keys, first.StorageKey)
+
+		require.NoError(t, repo.ConfirmBlobDeletion(ctx, first.StorageKey))
+		keys, err = repo.PendingBlobDeletions(ctx, 10)
+		require.NoError(t, err)
+		assert.NotContains(t, keys, first.StorageKey)
+	})
+
+	t.Run("Missing Task", func(t *testing.T) {
+		_, err := repo.Create(ctx, newAttachment("00000000-0000-0000-0000-000000000000", "нет.txt"))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Task Deletion", func(t *testing.T) {
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Удаляемая"})
+		require.NoError(t, err)
+		attachment, err := repo.Create(ctx, newAttachment(task.ID, "файл.txt"))
+		require.NoError(t, err)
+
+		// Вложения задачи в корзине скрыты
+		require.NoError(t, taskRepo.DeleteTask(ctx, task.ID))
+		_, err = repo.GetByID(ctx, attachment.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// Очистка корзины удаляет метаданные и ставит содержимое в очередь
+		_, err = trashRepo.Purge(time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		keys, err := repo.PendingBlobDeletions(ctx, 10)
+		require.NoError(t, err)
+		assert.Contains(t, keys, attachment.StorageKey)
+	})
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// commentColumns — колонки комментария в порядке, ожидаемом scanComment
+const commentColumns = "id, task_id, COALESCE(author, ''), text, created_at, updated_at"
+
+// liveCommentCondition отсекает комментарии задач, находящихся в корзине.
+// Они возвращаются вместе с восстановленной задачей.
+const liveCommentCondition = "task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)"
+
+type CommentRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCommentRepo(pool *pgxpool.Pool) *CommentRepo {
+	return &CommentRepo{
+		pool: pool,
+	}
+}
+
+func scanComment(row pgx.Row, comment *domain.Comment) error {
+	return row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.Author,
+		&comment.Text,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+}
+
+// Create добавляет комментарий к задаче. Пустой автор сохраняется как NULL
+func (r *CommentRepo) Create(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO comments (id, task_id, author, text)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING ` + commentColumns
+
+	var created domain.Comment
+	err := scanComment(r.pool.QueryRow(ctx, query, uuid.New(), comment.TaskID, comment.Author, comment.Text), &created)
+	if err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return domain.Comment{}, ErrNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("create comment: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID получает комментарий по ID
+func (r *CommentRepo) GetByID(ctx context.Context, id string) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = $1 AND ` + liveCommentCondition
+
+	var comment domain.Comment
+	if err := scanComment(r.pool.QueryRow(ctx, query, id), &comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Comment{}, ErrNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("get comment by id: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListByTask получает комментарии задачи в порядке добавления с пагинацией
+func (r *CommentRepo) ListByTask(ctx context.Context, taskID string, limit, offset int) ([]domain.Comment, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM comments WHERE task_id = $1 AND ` + liveCommentCondition
+	if err := r.pool.QueryRow(ctx, countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count comments: %w", err)
+	}
+
+	query := `
+		SELECT ` + commentColumns + `
+		FROM comments
+		WHERE task_id = $1 AND ` + liveCommentCondition + `
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]domain.Comment, 0)
+	for rows.Next() {
+		var comment domain.Comment
+		if err := scanComment(rows, &comment); err != nil {
+			return nil, 0, fmt.Errorf("scan comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+// Update меняет текст комментария
+func (r *CommentRepo) Update(ctx context.Context, id, text string) (domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE comments
+		SET text = $2, updated_at = NOW()
+		WHERE id = $1 AND ` + liveCommentCondition + `
+		RETURNING ` + commentColumns
+
+	var comment domain.Comment
+	if err := scanComment(r.pool.QueryRow(ctx, query, id, text), &comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Comment{}, ErrNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("update comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// Delete удаляет комментарий
+func (r *CommentRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM comments WHERE id = $1 AND `+liveCommentCondition, id)
+	if err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
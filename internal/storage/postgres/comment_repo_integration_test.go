@@ -0,0 +1,86 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	trashRepo := NewTrashRepo(pool)
+	repo := NewCommentRepo(pool)
+	ctx := context.Background()
+
+	list, err := listRepo.Create(ctx, "Обсуждения", "")
+	require.NoError(t, err)
+
+	t.Run("CRUD", func(t *testing.T) {
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Обсудить"})
+		require.NoError(t, err)
+
+		first, err := repo.Create(ctx, domain.Comment{TaskID: task.ID, Author: "alice", Text: "Первый"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", first.Author)
+		_, err = repo.Create(ctx, domain.Comment{TaskID: task.ID, Text: "Второй"})
+		require.NoError(t, err)
+
+		comments, total, err := repo.ListByTask(ctx, task.ID, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, comments, 1)
+		assert.Equal(t, "Второй", comments[0].Text)
+		assert.Empty(t, comments[0].Author)
+
+		updated, err := repo.Update(ctx, first.ID, "Исправленный")
+		require.NoError(t, err)
+		assert.Equal(t, "Исправленный", updated.Text)
+
+		require.NoError(t, repo.Delete(ctx, first.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, first.ID), ErrNotFound)
+	})
+
+	t.Run("Missing Task", func(t *testing.T) {
+		_, err := repo.Create(ctx, domain.Comment{TaskID: "00000000-0000-0000-0000-000000000000", Text: "Нет"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Task Deletion", func(t *testing.T) {
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Удаляемая"})
+		require.NoError(t, err)
+		comment, err := repo.Create(ctx, domain.Comment{TaskID: task.ID, Text: "Комментарий"})
+		require.NoError(t, err)
+
+		// Комментарии задачи в корзине скрыты и возвращаются вместе с ней
+		require.NoError(t, taskRepo.DeleteTask(ctx, task.ID))
+		_, err = repo.GetByID(ctx, comment.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = taskRepo.RestoreTask(ctx, task.ID, false)
+		require.NoError(t, err)
+		_, err = repo.GetByID(ctx, comment.ID)
+		require.NoError(t, err)
+
+		// Очистка корзины удаляет комментарии вместе с задачей
+		require.NoError(t, taskRepo.DeleteTask(ctx, task.ID))
+		_, err = trashRepo.Purge(time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		var count int
+		require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM comments WHERE id = $1", comment.ID).Scan(&count))
+		assert.Zero(t, count)
+	})
+}
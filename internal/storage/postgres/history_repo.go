@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HistoryRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewHistoryRepo(pool *pgxpool.Pool) *HistoryRepo {
+	return &HistoryRepo{
+		pool: pool,
+	}
+}
+
+// ListByEntity получает историю изменений объекта, начиная с последних
+func (r *HistoryRepo) ListByEntity(ctx context.Context, entityType domain.HistoryEntityType, entityID string, limit, offset int) ([]domain.HistoryEntry, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM history WHERE entity_type = $1 AND entity_id = $2`
+	if err := r.pool.QueryRow(ctx, countQuery, entityType, entityID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count history: %w", err)
+	}
+
+	query := `
+		SELECT id, entity_type, entity_id, action, changes, COALESCE(actor, ''), COALESCE(request_id, ''), created_at
+		FROM history
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.pool.Query(ctx, query, entityType, entityID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.HistoryEntry, 0)
+	for rows.Next() {
+		var entry domain.HistoryEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.EntityType,
+			&entry.EntityID,
+			&entry.Action,
+			&entry.Changes,
+			&entry.Actor,
+			&entry.RequestID,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan history entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// recordHistory добавляет запись истории в транзакции изменения объекта.
+// Автор и идентификатор запроса берутся из контекста.
+func recordHistory(ctx context.Context, tx pgx.Tx, entityType domain.HistoryEntityType, entityID string, action domain.HistoryAction, changes domain.Changes) error {
+	if changes == nil {
+		changes = domain.Changes{}
+	}
+
+	query := `
+		INSERT INTO history (id, entity_type, entity_id, action, changes, actor, request_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''))
+	`
+	_, err := tx.Exec(ctx, query,
+		uuid.New().String(),
+		entityType,
+		entityID,
+		action,
+		changes,
+		requestctx.Actor(ctx),
+		requestctx.RequestID(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("record history: %w", err)
+	}
+
+	return nil
+}
+
+// recordTaskHistory записывает одно и то же действие для нескольких задач
+func recordTaskHistory(ctx context.Context, tx pgx.Tx, taskIDs []string, action domain.HistoryAction, changes domain.Changes) error {
+	for _, id := range taskIDs {
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, id, action, changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectIDs читает идентификаторы из результата запроса с RETURNING id
+func collectIDs(rows pgx.Rows) ([]string, error) {
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	historyRepo := NewHistoryRepo(pool)
+	ctx := requestctx.WithActor(requestctx.WithRequestID(context.Background(), "req-1"), "alice")
+
+	t.Run("Task Changes", func(t *testing.T) {
+		list, err := listRepo.Create(ctx, "История", "")
+		require.NoError(t, err)
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Черновик"})
+		require.NoError(t, err)
+		// Автор из X-Actor без пользователя запроса не становится автором списка и задачи
+		assert.Empty(t, list.CreatedBy)
+		assert.Empty(t, task.CreatedBy)
+
+		task.Text = "Готово"
+		task.SetStatus(domain.StatusDone)
+		_, err = taskRepo.UpdateTask(ctx, task, nil)
+		require.NoError(t, err)
+
+		// Обновление без изменений не попадает в историю
+		_, err = taskRepo.UpdateTask(ctx, task, nil)
+		require.NoError(t, err)
+
+		entries, total, err := historyRepo.ListByEntity(ctx, domain.HistoryEntityTask, task.ID, 20, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+
+		updated := entries[0]
+		assert.Equal(t, domain.HistoryUpdated, updated.Action)
+		assert.Equal(t, "alice", updated.Actor)
+		assert.Equal(t, "req-1", updated.RequestID)
+		assert.Equal(t, domain.Changes{
+			"text":      {From: "Черновик", To: "Готово"},
+			"status":    {From: "todo", To: "done"},
+			"completed": {From: false, To: true},
+		}, updated.Changes)
+
+		created := entries[1]
+		assert.Equal(t, domain.HistoryCreated, created.Action)
+		assert.Equal(t, "Черновик", created.Changes["text"].To)
+	})
+
+	t.Run("List Delete Records Tasks", func(t *testing.T) {
+		list, err := listRepo.Create(context.Background(), "Удаляемый", "")
+		require.NoError(t, err)
+		task, err := taskRepo.CreateTask(context.Background(), domain.Task{ListID: list.ID, Text: "Задача"})
+		require.NoError(t, err)
+
+		require.NoError(t, listRepo.Delete(ctx, list.ID))
+
+		entries, _, err := historyRepo.ListByEntity(ctx, domain.HistoryEntityTask, task.ID, 20, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.Equal(t, domain.HistoryDeleted, entries[0].Action)
+		assert.Equal(t, "alice", entries[0].Actor)
+
+		entries, _, err = historyRepo.ListByEntity(ctx, domain.HistoryEntityList, list.ID, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, domain.HistoryDeleted, entries[0].Action)
+		// Изменение без автора в контексте сохраняется без него
+		assert.Empty(t, entries[1].Actor)
+	})
+
+	t.Run("Append Only", func(t *testing.T) {
+		_, err := pool.Exec(context.Background(), "DELETE FROM history")
+		assert.Error(t, err)
+	})
+}
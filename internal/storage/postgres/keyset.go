@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"fmt"
+)
+
+// keysetConditions строит условие и порядок выборки страницы по ключу (created_at, id)
+// и добавляет значения курсора к args. desc — выдача от новых к старым; при равном
+// created_at записи идут по возрастанию id, как в выдаче с offset. Страница перед курсором
+// выбирается в обратном порядке: прочитанные строки нужно перевернуть.
+// Без курсора условие пустое
+func keysetConditions(cursor *domain.Cursor, desc bool, args []any) (string, string, []any) {
+	forward := cursor == nil || !cursor.Before
+	newestFirst := desc == forward
+
+	createdOrder, createdCmp := "ASC", ">"
+	if newestFirst {
+		createdOrder, createdCmp = "DESC", "<"
+	}
+	idOrder, idCmp := "DESC", "<"
+	if forward {
+		idOrder, idCmp = "ASC", ">"
+	}
+	orderBy := fmt.Sprintf("created_at %s, id %s", createdOrder, idOrder)
+
+	if cursor == nil {
+		return "", orderBy, args
+	}
+
+	args = append(args, cursor.CreatedAt, cursor.ID)
+	condition := fmt.Sprintf("(created_at %s $%d OR (created_at = $%d AND id %s $%d))",
+		createdCmp, len(args)-1, len(args)-1, idCmp, len(args))
+	return condition, orderBy, args
+}
@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,7 +15,25 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// listColumns — колонки списка в порядке, ожидаемом scanList.
+// Отсутствующие описание и автор читаются как пустая строка.
+const listColumns = "id, title, COALESCE(description, ''), archived_at, COALESCE(created_by::text, ''), created_at"
+
+func scanList(row pgx.Row, list *domain.List) error {
+	return row.Scan(
+		&list.ID,
+		&list.Title,
+		&list.Description,
+		&list.ArchivedAt,
+		&list.CreatedBy,
+		&list.CreatedAt,
+	)
+}
 
 type ListRepo struct {
 	pool    *pgxpool.Pool
@@ -22,47 +43,60 @@ type ListRepo struct {
 func NewListRepo(pool *pgxpool.Pool) *ListRepo {
 	return &ListRepo{
 		pool:    pool,
-		getByID: "SELECT id, title, created_at FROM lists WHERE id = $1",
+		getByID: "SELECT " + listColumns + " FROM lists WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL",
 	}
 }
 
-// Create создает новый список
-func (r *ListRepo) Create(title string) (domain.List, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Create создает новый список в рабочем пространстве запроса. Пустое описание сохраняется как NULL,
+// автором и владельцем становится пользователь запроса (в том числе пользователь API-ключа)
+func (r *ListRepo) Create(ctx context.Context, title, description string) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	id := uuid.New()
 	query := `
-        INSERT INTO lists (id, title)
-        VALUES ($1, $2)
-        RETURNING id, title, created_at
-    `
+        INSERT INTO lists (id, title, description, created_by, workspace_id)
+        VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, '')::uuid, $5)
+        RETURNING ` + listColumns
 	var list domain.List
-	err := r.pool.QueryRow(ctx, query, id, title).Scan(
-		&list.ID,
-		&list.Title,
-		&list.CreatedAt,
-	)
+	err = scanList(tx.QueryRow(ctx, query, id, title, description, requestctx.UserID(ctx), requestctx.Workspace(ctx)), &list)
 
 	if err != nil {
 		return domain.List{}, fmt.Errorf("create list: %w", err)
 	}
 
+	if err := recordHistory(ctx, tx, domain.HistoryEntityList, list.ID, domain.HistoryCreated, domain.ListChanges(nil, list)); err != nil {
+		return domain.List{}, err
+	}
+
+	if userID := requestctx.UserID(ctx); userID != "" {
+		_, err := tx.Exec(ctx, `INSERT INTO list_members (list_id, user_id, role) VALUES ($1, $2, $3)`, list.ID, userID, domain.RoleOwner)
+		if err != nil {
+			return domain.List{}, fmt.Errorf("add list owner: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.List{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return list, nil
 }
 
 // GetByID получает список по ID
-func (r *ListRepo) GetByID(id string) (domain.List, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *ListRepo) GetByID(ctx context.Context, id string) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var list domain.List
 
-	err := r.pool.QueryRow(ctx, r.getByID, id).Scan(
-		&list.ID,
-		&list.Title,
-		&list.CreatedAt,
-	)
+	err := scanList(r.pool.QueryRow(ctx, r.getByID, id, requestctx.Workspace(ctx)), &list)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -74,17 +108,72 @@ func (r *ListRepo) GetByID(id string) (domain.List, error) {
 	return list, nil
 }
 
-func (r *ListRepo) SearchByTitle(query string) ([]domain.List, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetDeleted получает список из корзины
+func (r *ListRepo) GetDeleted(ctx context.Context, id string) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + listColumns + ` FROM lists WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`
+
+	var list domain.List
+	if err := scanList(r.pool.QueryRow(ctx, query, id, requestctx.Workspace(ctx)), &list); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.List{}, ErrNotFound
+		}
+		return domain.List{}, fmt.Errorf("get deleted list: %w", err)
+	}
+
+	return list, nil
+}
+
+// listArchiveCondition возвращает условие выборки списков по признаку архивации
+func listArchiveCondition(filter domain.ListFilter) string {
+	switch {
+	case filter.ArchivedOnly:
+		return "archived_at IS NOT NULL"
+	case filter.IncludeArchived:
+		return "TRUE"
+	default:
+		return "archived_at IS NULL"
+	}
+}
+
+// listFilterConditions строит условие WHERE для выборки списков рабочего пространства workspaceID
+// и добавляет его аргументы к args
+func listFilterConditions(workspaceID string, filter domain.ListFilter, args []any) (string, []any) {
+	args = append(args, workspaceID)
+	where := fmt.Sprintf("workspace_id = $%d AND deleted_at IS NULL AND %s", len(args), listArchiveCondition(filter))
+	if filter.MemberID != "" {
+		args = append(args, filter.MemberID)
+		where += " AND " + memberListsCondition("id", len(args))
+	}
+	return where, args
+}
+
+// memberListsCondition возвращает условие "колонка column — список, в котором
+// состоит пользователь из аргумента с номером arg"
+func memberListsCondition(column string, arg int) string {
+	return fmt.Sprintf("%s IN (SELECT list_id FROM list_members WHERE user_id = $%d)", column, arg)
+}
+
+// SearchByTitle ищет списки по названию. Подстрока ищется без учета регистра,
+// результаты — от новых к старым; нечеткий поиск упорядочивает результаты по сходству
+func (r *ListRepo) SearchByTitle(ctx context.Context, search domain.TitleSearch, filter domain.ListFilter) ([]domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	where, args := listFilterConditions(requestctx.Workspace(ctx), filter, []any{search.Query})
+	if search.Mode == domain.TitleSearchFuzzy {
+		return r.searchBySimilarity(ctx, search.Threshold, where, args)
+	}
+
 	searchQuery := `
-        SELECT id, title, created_at 
+        SELECT ` + listColumns + `
         FROM lists 
-        WHERE title ILIKE '%' || $1 || '%'
+        WHERE title ILIKE '%' || $1 || '%' AND ` + where + `
         ORDER BY created_at DESC
 		`
-	rows, err := r.pool.Query(ctx, searchQuery, query)
+	rows, err := r.pool.Query(ctx, searchQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("search lists by title: %w", err)
 	}
@@ -93,7 +182,51 @@ func (r *ListRepo) SearchByTitle(query string) ([]domain.List, error) {
 	lists := make([]domain.List, 0)
 	for rows.Next() {
 		var list domain.List
-		err := rows.Scan(&list.ID, &list.Title, &list.CreatedAt)
+		err := scanList(rows, &list)
+		if err != nil {
+			return nil, fmt.Errorf("scan list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return lists, nil
+}
+
+// searchBySimilarity ищет списки, название которых похоже на запрос ($1) не меньше чем на threshold.
+// Порог передается оператору % через pg_trgm.similarity_threshold: в отличие от сравнения
+// с similarity() оператор использует триграммный индекс idx_lists_title_trgm
+func (r *ListRepo) searchBySimilarity(ctx context.Context, threshold float64, where string, args []any) ([]domain.List, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Порог действует до конца транзакции
+	if _, err := tx.Exec(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`, strconv.FormatFloat(threshold, 'f', -1, 64)); err != nil {
+		return nil, fmt.Errorf("set similarity threshold: %w", err)
+	}
+
+	searchQuery := `
+		SELECT ` + listColumns + `
+		FROM lists
+		WHERE title % $1 AND ` + where + `
+		ORDER BY similarity(title, $1) DESC, created_at DESC
+	`
+	rows, err := tx.Query(ctx, searchQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search lists by similarity: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]domain.List, 0)
+	for rows.Next() {
+		var list domain.List
+		err := scanList(rows, &list)
 		if err != nil {
 			return nil, fmt.Errorf("scan list: %w", err)
 		}
@@ -104,45 +237,82 @@ func (r *ListRepo) SearchByTitle(query string) ([]domain.List, error) {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return lists, nil
 }
 
-// Update обновляет название списка
-func (r *ListRepo) Update(id, title string) (domain.List, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Update обновляет название и описание списка
+func (r *ListRepo) Update(ctx context.Context, list domain.List) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockList(ctx, tx, list.ID)
+	if err != nil {
+		return domain.List{}, err
+	}
+
 	query := `
         UPDATE lists
-        SET title = $2
+        SET title = $2, description = NULLIF($3, '')
         WHERE id = $1
-        RETURNING id, title, created_at
-    `
+        RETURNING ` + listColumns
 
-	var list domain.List
-	err := r.pool.QueryRow(ctx, query, id, title).Scan(
-		&list.ID,
-		&list.Title,
-		&list.CreatedAt,
-	)
+	var updated domain.List
+	err = scanList(tx.QueryRow(ctx, query, list.ID, list.Title, list.Description), &updated)
 	if err != nil {
+		return domain.List{}, fmt.Errorf("update list: %w", err)
+	}
+
+	if changes := domain.ListChanges(&before, updated); len(changes) > 0 {
+		if err := recordHistory(ctx, tx, domain.HistoryEntityList, updated.ID, domain.HistoryUpdated, changes); err != nil {
+			return domain.List{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.List{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// lockList получает список рабочего пространства запроса, не находящийся в корзине,
+// и блокирует его до конца транзакции
+func lockList(ctx context.Context, tx pgx.Tx, id string) (domain.List, error) {
+	var list domain.List
+	query := `SELECT ` + listColumns + ` FROM lists WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL FOR UPDATE`
+	if err := scanList(tx.QueryRow(ctx, query, id, requestctx.Workspace(ctx)), &list); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.List{}, ErrNotFound
 		}
-		return domain.List{}, fmt.Errorf("update list title: %w", err)
+		return domain.List{}, fmt.Errorf("get list by id: %w", err)
 	}
-
 	return list, nil
 }
 
-// Delete удаляет список
-func (r *ListRepo) Delete(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Delete перемещает список в корзину вместе с его задачами.
+// Задачи получают то же время удаления, что и список, и восстанавливаются вместе с ним.
+func (r *ListRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `DELETE FROM lists WHERE id = $1`
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	result, err := r.pool.Exec(ctx, query, id)
+	// NOW() постоянно в пределах транзакции
+	result, err := tx.Exec(ctx, `UPDATE lists SET deleted_at = NOW() WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL`, id, requestctx.Workspace(ctx))
 	if err != nil {
 		return fmt.Errorf("delete list: %w", err)
 	}
@@ -151,31 +321,148 @@ func (r *ListRepo) Delete(id string) error {
 		return ErrNotFound
 	}
 
+	rows, err := tx.Query(ctx, `UPDATE tasks SET deleted_at = NOW() WHERE list_id = $1 AND deleted_at IS NULL RETURNING id`, id)
+	if err != nil {
+		return fmt.Errorf("delete list tasks: %w", err)
+	}
+	taskIDs, err := collectIDs(rows)
+	if err != nil {
+		return fmt.Errorf("delete list tasks: %w", err)
+	}
+
+	if err := recordHistory(ctx, tx, domain.HistoryEntityList, id, domain.HistoryDeleted, nil); err != nil {
+		return err
+	}
+	if err := recordTaskHistory(ctx, tx, taskIDs, domain.HistoryDeleted, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+// Restore возвращает список из корзины вместе с задачами, удаленными вместе с ним
+func (r *ListRepo) Restore(ctx context.Context, id string) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	query := `SELECT deleted_at FROM lists WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL FOR UPDATE`
+	err = tx.QueryRow(ctx, query, id, requestctx.Workspace(ctx)).Scan(&deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.List{}, ErrNotFound
+		}
+		return domain.List{}, fmt.Errorf("get deleted list: %w", err)
+	}
+
+	var list domain.List
+	query = `UPDATE lists SET deleted_at = NULL WHERE id = $1 RETURNING ` + listColumns
+	if err := scanList(tx.QueryRow(ctx, query, id), &list); err != nil {
+		return domain.List{}, fmt.Errorf("restore list: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `UPDATE tasks SET deleted_at = NULL WHERE list_id = $1 AND deleted_at = $2 RETURNING id`, id, deletedAt)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("restore list tasks: %w", err)
+	}
+	taskIDs, err := collectIDs(rows)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("restore list tasks: %w", err)
+	}
+
+	if err := recordHistory(ctx, tx, domain.HistoryEntityList, id, domain.HistoryRestored, nil); err != nil {
+		return domain.List{}, err
+	}
+	if err := recordTaskHistory(ctx, tx, taskIDs, domain.HistoryRestored, nil); err != nil {
+		return domain.List{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.List{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return list, nil
+}
+
+// SetArchived архивирует список или возвращает его из архива.
+// Повторная архивация не меняет время архивации.
+func (r *ListRepo) SetArchived(ctx context.Context, id string, archived bool) (domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.List{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockList(ctx, tx, id)
+	if err != nil {
+		return domain.List{}, err
+	}
+
+	query := `
+        UPDATE lists
+        SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, NOW()) END
+        WHERE id = $1
+        RETURNING ` + listColumns
+
+	var list domain.List
+	if err := scanList(tx.QueryRow(ctx, query, id, archived), &list); err != nil {
+		return domain.List{}, fmt.Errorf("set list archived: %w", err)
+	}
+
+	if changes := domain.ListChanges(&before, list); len(changes) > 0 {
+		action := domain.HistoryUnarchived
+		if archived {
+			action = domain.HistoryArchived
+		}
+		if err := recordHistory(ctx, tx, domain.HistoryEntityList, id, action, changes); err != nil {
+			return domain.List{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.List{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return list, nil
+}
+
 // List получает список с пагинацией
-func (r *ListRepo) List(limit, offset int) ([]domain.List, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *ListRepo) List(ctx context.Context, filter domain.ListFilter, limit, offset int) ([]domain.List, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Получаем общее количество
 	var total int
-	countQuery := `SELECT COUNT(*) FROM lists`
-	err := r.pool.QueryRow(ctx, countQuery).Scan(&total)
+	where, args := listFilterConditions(requestctx.Workspace(ctx), filter, nil)
+	countQuery := `SELECT COUNT(*) FROM lists WHERE ` + where
+	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("count lists: %w", err)
 	}
 
 	// Получаем списки с пагинацией
-	query := `
-        SELECT id, title, created_at
+	query := fmt.Sprintf(`
+        SELECT %s
         FROM lists
-        ORDER BY created_at DESC
-        LIMIT $1 OFFSET $2
-    `
+        WHERE %s
+        ORDER BY created_at DESC, id
+        LIMIT $%d OFFSET $%d
+    `, listColumns, where, len(args)+1, len(args)+2)
 
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := r.pool.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list lists: %w", err)
 	}
@@ -184,7 +471,7 @@ func (r *ListRepo) List(limit, offset int) ([]domain.List, int, error) {
 	lists := make([]domain.List, 0)
 	for rows.Next() {
 		var list domain.List
-		err := rows.Scan(&list.ID, &list.Title, &list.CreatedAt)
+		err := scanList(rows, &list)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scan list: %w", err)
 		}
@@ -198,6 +485,189 @@ func (r *ListRepo) List(limit, offset int) ([]domain.List, int, error) {
 	return lists, total, nil
 }
 
+// ListByCursor получает страницу списков после или перед курсором, от новых к старым.
+// Вместо OFFSET и COUNT(*) выборка продолжается с позиции курсора по индексу
+// (workspace_id, created_at, id); лишняя строка показывает, есть ли следующая страница
+func (r *ListRepo) ListByCursor(ctx context.Context, filter domain.ListFilter, cursor *domain.Cursor, limit int) ([]domain.List, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	where, args := listFilterConditions(requestctx.Workspace(ctx), filter, nil)
+	condition, orderBy, args := keysetConditions(cursor, true, args)
+	if condition != "" {
+		where += " AND " + condition
+	}
+
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM lists
+        WHERE %s
+        ORDER BY %s
+        LIMIT $%d
+    `, listColumns, where, orderBy, len(args)+1)
+
+	rows, err := r.pool.Query(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		return nil, false, fmt.Errorf("list lists by cursor: %w", err)
+	}
+	defer rows.Close()
+
+	lists := make([]domain.List, 0)
+	for rows.Next() {
+		var list domain.List
+		err := scanList(rows, &list)
+		if err != nil {
+			return nil, false, fmt.Errorf("scan list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("rows error: %w", err)
+	}
+
+	more := len(lists) > limit
+	if more {
+		lists = lists[:limit]
+	}
+	if cursor != nil && cursor.Before {
+		slices.Reverse(lists)
+	}
+
+	return lists, more, nil
+}
+
+// Stats считает задачи списка, не находящиеся в корзине, и медиану
+// времени выполнения в секундах по задачам с известным completed_at
+func (r *ListRepo) Stats(ctx context.Context, listID string) (domain.ListStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE completed),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - created_at))
+				FILTER (WHERE completed AND completed_at IS NOT NULL)
+		FROM tasks
+		WHERE list_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+	`
+
+	stats := domain.ListStats{ListID: listID}
+	err := r.pool.QueryRow(ctx, query, listID, requestctx.Workspace(ctx)).Scan(&stats.Total, &stats.Completed, &stats.MedianTimeToCompleteSeconds)
+	if err != nil {
+		return domain.ListStats{}, fmt.Errorf("list stats: %w", err)
+	}
+	stats.Open = stats.Total - stats.Completed
+
+	return stats, nil
+}
+
+// GetMemberRole возвращает роль пользователя в списке
+func (r *ListRepo) GetMemberRole(ctx context.Context, listID, userID string) (domain.ListRole, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        SELECT m.role
+        FROM list_members m
+        JOIN lists l ON l.id = m.list_id
+        WHERE m.list_id = $1 AND m.user_id = $2 AND l.workspace_id = $3`
+
+	var role domain.ListRole
+	err := r.pool.QueryRow(ctx, query, listID, userID, requestctx.Workspace(ctx)).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("get list member role: %w", err)
+	}
+
+	return role, nil
+}
+
+// ListMembers получает участников списка в порядке добавления
+func (r *ListRepo) ListMembers(ctx context.Context, listID string) ([]domain.ListMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        SELECT m.list_id, m.user_id, m.role, m.created_at
+        FROM list_members m
+        JOIN lists l ON l.id = m.list_id
+        WHERE m.list_id = $1 AND l.workspace_id = $2
+        ORDER BY m.created_at, m.user_id`
+	rows, err := r.pool.Query(ctx, query, listID, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]domain.ListMember, 0)
+	for rows.Next() {
+		var member domain.ListMember
+		if err := rows.Scan(&member.ListID, &member.UserID, &member.Role, &member.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan list member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetMember добавляет участника списка или меняет роль существующего.
+// Несуществующий список, список другого рабочего пространства или пользователь,
+// не состоящий в рабочем пространстве, — ErrNotFound.
+func (r *ListRepo) SetMember(ctx context.Context, member domain.ListMember) (domain.ListMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        INSERT INTO list_members (list_id, user_id, role)
+        SELECT id, $2, $3 FROM lists
+        WHERE id = $1 AND workspace_id = $4
+            AND EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = $4 AND user_id = $2)
+        ON CONFLICT (list_id, user_id) DO UPDATE SET role = EXCLUDED.role
+        RETURNING list_id, user_id, role, created_at`
+
+	var saved domain.ListMember
+	err := r.pool.QueryRow(ctx, query, member.ListID, member.UserID, member.Role, requestctx.Workspace(ctx)).
+		Scan(&saved.ListID, &saved.UserID, &saved.Role, &saved.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isPgError(err, pgForeignKeyViolation) {
+			return domain.ListMember{}, ErrNotFound
+		}
+		return domain.ListMember{}, fmt.Errorf("set list member: %w", err)
+	}
+
+	return saved, nil
+}
+
+// RemoveMember исключает пользователя из участников списка
+func (r *ListRepo) RemoveMember(ctx context.Context, listID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        DELETE FROM list_members
+        WHERE list_id = $1 AND user_id = $2
+            AND list_id IN (SELECT id FROM lists WHERE workspace_id = $3)`
+	result, err := r.pool.Exec(ctx, query, listID, userID, requestctx.Workspace(ctx))
+	if err != nil {
+		return fmt.Errorf("remove list member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *ListRepo) CreateWithItems(title string, items []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -212,8 +682,8 @@ func (r *ListRepo) CreateWithItems(title string, items []string) error {
 	// Создаем список
 	listID := uuid.New()
 	_, err = tx.Exec(ctx,
-		"INSERT INTO lists (id, title) VALUES ($1, $2)",
-		listID, title,
+		"INSERT INTO lists (id, title, workspace_id) VALUES ($1, $2, $3)",
+		listID, title, domain.DefaultWorkspaceID,
 	)
 	if err != nil {
 		return fmt.Errorf("create list: %w", err)
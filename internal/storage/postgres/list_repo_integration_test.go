@@ -0,0 +1,296 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	repo := NewListRepo(pool)
+	ctx := context.Background()
+
+	t.Run("Description", func(t *testing.T) {
+		list, err := repo.Create(ctx, "С описанием", "Продукты на неделю")
+		require.NoError(t, err)
+		assert.Equal(t, "Продукты на неделю", list.Description)
+
+		fetched, err := repo.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Продукты на неделю", fetched.Description)
+
+		// Пустое описание хранится как NULL и читается как пустая строка
+		fetched.Description = ""
+		updated, err := repo.Update(ctx, fetched)
+		require.NoError(t, err)
+		assert.Equal(t, "", updated.Description)
+		assert.Equal(t, "С описанием", updated.Title)
+
+		var isNull bool
+		err = pool.QueryRow(ctx, "SELECT description IS NULL FROM lists WHERE id = $1", list.ID).Scan(&isNull)
+		require.NoError(t, err)
+		assert.True(t, isNull)
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		list, err := repo.Create(ctx, "Архивный проект", "")
+		require.NoError(t, err)
+
+		archived, err := repo.SetArchived(ctx, list.ID, true)
+		require.NoError(t, err)
+		require.NotNil(t, archived.ArchivedAt)
+
+		// Повторная архивация не меняет время
+		again, err := repo.SetArchived(ctx, list.ID, true)
+		require.NoError(t, err)
+		assert.True(t, archived.ArchivedAt.Equal(*again.ArchivedAt))
+
+		active, _, err := repo.List(ctx, domain.ListFilter{}, 100, 0)
+		require.NoError(t, err)
+		assert.NotContains(t, listIDs(active), list.ID)
+
+		all, _, err := repo.List(ctx, domain.ListFilter{IncludeArchived: true}, 100, 0)
+		require.NoError(t, err)
+		assert.Contains(t, listIDs(all), list.ID)
+
+		found, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "Архивный"}, domain.ListFilter{ArchivedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{list.ID}, listIDs(found))
+
+		unarchived, err := repo.SetArchived(ctx, list.ID, false)
+		require.NoError(t, err)
+		assert.Nil(t, unarchived.ArchivedAt)
+	})
+
+	t.Run("Fuzzy Search", func(t *testing.T) {
+		groceries, err := repo.Create(ctx, "Покупки", "")
+		require.NoError(t, err)
+		weekly, err := repo.Create(ctx, "Покупки на неделю", "")
+		require.NoError(t, err)
+
+		// Опечатка не мешает нечеткому поиску, но мешает поиску подстроки
+		found, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик"}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{groceries.ID}, listIDs(found))
+
+		found, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупки неделя", Mode: domain.TitleSearchFuzzy, Threshold: 0.3}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{weekly.ID, groceries.ID}, listIDs(found))
+
+		found, err = repo.SearchByTitle(ctx, domain.TitleSearch{Query: "покупик", Mode: domain.TitleSearchFuzzy, Threshold: 0.5}, domain.ListFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		// Сходство совпадает с реализацией в памяти (mem.ListRepo)
+		var score float64
+		require.NoError(t, pool.QueryRow(ctx, `SELECT similarity('Покупки', 'покупик')`).Scan(&score))
+		assert.InDelta(t, 5.0/11.0, score, 1e-6)
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		taskRepo := NewTaskRepo(pool)
+		list, err := repo.Create(ctx, "Статистика", "")
+		require.NoError(t, err)
+
+		stats, err := repo.Stats(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Zero(t, stats.Total)
+		assert.Nil(t, stats.MedianTimeToCompleteSeconds)
+
+		var tasks []domain.Task
+		for _, text := range []string{"Первая", "Вторая", "Третья", "В корзине"} {
+			task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: text})
+			require.NoError(t, err)
+			assert.Nil(t, task.CompletedAt)
+			tasks = append(tasks, task)
+		}
+
+		for _, task := range tasks[:2] {
+			task.SetStatus(domain.StatusDone)
+			done, err := taskRepo.UpdateTask(ctx, task, nil)
+			require.NoError(t, err)
+			require.NotNil(t, done.CompletedAt)
+		}
+		require.NoError(t, taskRepo.DeleteTask(ctx, tasks[3].ID))
+
+		// Выполнены через час и через три часа после создания
+		_, err = pool.Exec(ctx, "UPDATE tasks SET created_at = completed_at - INTERVAL '1 hour' WHERE id = $1", tasks[0].ID)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE tasks SET created_at = completed_at - INTERVAL '3 hours' WHERE id = $1", tasks[1].ID)
+		require.NoError(t, err)
+
+		stats, err = repo.Stats(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.Total)
+		assert.Equal(t, 2, stats.Completed)
+		assert.Equal(t, 1, stats.Open)
+		require.NotNil(t, stats.MedianTimeToCompleteSeconds)
+		assert.InDelta(t, 7200, *stats.MedianTimeToCompleteSeconds, 0.001)
+
+		// Возврат в работу очищает completed_at
+		reopened, err := taskRepo.GetByIDTask(ctx, tasks[0].ID)
+		require.NoError(t, err)
+		reopened.SetStatus(domain.StatusTodo)
+		reopened, err = taskRepo.UpdateTask(ctx, reopened, nil)
+		require.NoError(t, err)
+		assert.Nil(t, reopened.CompletedAt)
+
+		stats, err = repo.Stats(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Completed)
+		require.NotNil(t, stats.MedianTimeToCompleteSeconds)
+		assert.InDelta(t, 10800, *stats.MedianTimeToCompleteSeconds, 0.001)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		userRepo := NewUserRepo(pool)
+		taskRepo := NewTaskRepo(pool)
+		alice, err := userRepo.Create(ctx, domain.User{Name: "Алиса", Email: "alice.members@example.com"})
+		require.NoError(t, err)
+		bob, err := userRepo.Create(ctx, domain.User{Name: "Боб", Email: "bob.members@example.com"})
+		require.NoError(t, err)
+
+		// Пользователь, создавший список, становится владельцем
+		aliceCtx := requestctx.WithPrincipal(ctx, domain.Principal{Type: domain.PrincipalUser, ID: alice.ID})
+		list, err := repo.Create(aliceCtx, "Общий список", "")
+		require.NoError(t, err)
+		role, err := repo.GetMemberRole(ctx, list.ID, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleOwner, role)
+
+		_, err = repo.GetMemberRole(ctx, list.ID, bob.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		visible, _, err := repo.List(ctx, domain.ListFilter{MemberID: bob.ID}, 100, 0)
+		require.NoError(t, err)
+		assert.NotContains(t, listIDs(visible), list.ID)
+
+		member, err := repo.SetMember(ctx, domain.ListMember{ListID: list.ID, UserID: bob.ID, Role: domain.RoleViewer})
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleViewer, member.Role)
+		member, err = repo.SetMember(ctx, domain.ListMember{ListID: list.ID, UserID: bob.ID, Role: domain.RoleEditor})
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleEditor, member.Role)
+
+		visible, total, err := repo.List(ctx, domain.ListFilter{MemberID: bob.ID}, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{list.ID}, listIDs(visible))
+		assert.Equal(t, 1, total)
+		found, err := repo.SearchByTitle(ctx, domain.TitleSearch{Query: "Общий"}, domain.ListFilter{MemberID: bob.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{list.ID}, listIDs(found))
+
+		due := time.Now().Add(-time.Hour)
+		_, err = taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Просрочено", Priority: domain.PriorityNone, Status: domain.StatusTodo, DueAt: &due})
+		require.NoError(t, err)
+		_, total, err = taskRepo.ListOverdueTasks(ctx, bob.ID, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		members, err := repo.ListMembers(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+
+		_, err = repo.SetMember(ctx, domain.ListMember{ListID: list.ID, UserID: "00000000-0000-0000-0000-000000000000", Role: domain.RoleViewer})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, repo.RemoveMember(ctx, list.ID, bob.ID))
+		assert.ErrorIs(t, repo.RemoveMember(ctx, list.ID, bob.ID), ErrNotFound)
+		_, total, err = taskRepo.ListOverdueTasks(ctx, bob.ID, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("Creator", func(t *testing.T) {
+		userRepo := NewUserRepo(pool)
+		taskRepo := NewTaskRepo(pool)
+		carol, err := userRepo.Create(ctx, domain.User{Name: "Карина", Email: "carol.creator@example.com"})
+		require.NoError(t, err)
+
+		// Автор — пользователь запроса, а не X-Actor
+		carolCtx := requestctx.WithActor(requestctx.WithPrincipal(ctx, domain.Principal{Type: domain.PrincipalUser, ID: carol.ID}), "mallory")
+		list, err := repo.Create(carolCtx, "Список Карины", "")
+		require.NoError(t, err)
+		assert.Equal(t, carol.ID, list.CreatedBy)
+		task, err := taskRepo.CreateTask(carolCtx, domain.Task{ListID: list.ID, Text: "Задача Карины"})
+		require.NoError(t, err)
+		assert.Equal(t, carol.ID, task.CreatedBy)
+
+		// После удаления пользователя автор не указан
+		require.NoError(t, userRepo.Delete(ctx, carol.ID))
+		list, err = repo.GetByID(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Empty(t, list.CreatedBy)
+		task, err = taskRepo.GetByIDTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, task.CreatedBy)
+	})
+
+	t.Run("Cursor Pagination", func(t *testing.T) {
+		workspace, err := NewWorkspaceRepo(pool).Create(ctx, "Курсоры")
+		require.NoError(t, err)
+		wsCtx := requestctx.WithWorkspace(ctx, workspace.ID)
+
+		var ids []string
+		for i := 0; i < 5; i++ {
+			list, err := repo.Create(wsCtx, fmt.Sprintf("Страница %d", i), "")
+			require.NoError(t, err)
+			ids = append([]string{list.ID}, ids...)
+		}
+
+		// Вперед от первой страницы: от новых к старым
+		first, more, err := repo.ListByCursor(wsCtx, domain.ListFilter{}, nil, 2)
+		require.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, ids[:2], listIDs(first))
+
+		after := &domain.Cursor{CreatedAt: first[1].CreatedAt, ID: first[1].ID}
+		second, more, err := repo.ListByCursor(wsCtx, domain.ListFilter{}, after, 2)
+		require.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, ids[2:4], listIDs(second))
+
+		after = &domain.Cursor{CreatedAt: second[1].CreatedAt, ID: second[1].ID}
+		last, more, err := repo.ListByCursor(wsCtx, domain.ListFilter{}, after, 2)
+		require.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, ids[4:], listIDs(last))
+
+		// Назад от последней страницы возвращается предыдущая в том же порядке
+		before := &domain.Cursor{CreatedAt: last[0].CreatedAt, ID: last[0].ID, Before: true}
+		prev, more, err := repo.ListByCursor(wsCtx, domain.ListFilter{}, before, 2)
+		require.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, ids[2:4], listIDs(prev))
+	})
+
+	t.Run("Update Missing List", func(t *testing.T) {
+		_, err := repo.Update(ctx, domain.List{ID: "00000000-0000-0000-0000-000000000000", Title: "Нет"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func listIDs(lists []domain.List) []string {
+	ids := make([]string, 0, len(lists))
+	for _, list := range lists {
+		ids = append(ids, list.ID)
+	}
+	return ids
+}
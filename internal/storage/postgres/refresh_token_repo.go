@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// refreshTokenColumns — колонки токена обновления в порядке, ожидаемом scanRefreshToken
+const refreshTokenColumns = "id, user_id, COALESCE(workspace_id::text, ''), created_at, expires_at, revoked_at"
+
+type RefreshTokenRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRefreshTokenRepo(pool *pgxpool.Pool) *RefreshTokenRepo {
+	return &RefreshTokenRepo{
+		pool: pool,
+	}
+}
+
+func scanRefreshToken(row pgx.Row, token *domain.RefreshToken) error {
+	return row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.WorkspaceID,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+	)
+}
+
+// Create сохраняет токен по его хэшу. Несуществующий пользователь или рабочее пространство — ErrNotFound
+func (r *RefreshTokenRepo) Create(ctx context.Context, token domain.RefreshToken, tokenHash string) (domain.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, workspace_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid)
+		RETURNING ` + refreshTokenColumns
+
+	var created domain.RefreshToken
+	if err := scanRefreshToken(r.pool.QueryRow(ctx, query, uuid.New(), token.UserID, tokenHash, token.ExpiresAt, token.WorkspaceID), &created); err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return domain.RefreshToken{}, ErrNotFound
+		}
+		return domain.RefreshToken{}, fmt.Errorf("create refresh token: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByHash находит токен по хэшу, в том числе отозванный
+func (r *RefreshTokenRepo) GetByHash(tokenHash string) (domain.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var token domain.RefreshToken
+	if err := scanRefreshToken(r.pool.QueryRow(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, tokenHash), &token); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RefreshToken{}, ErrNotFound
+		}
+		return domain.RefreshToken{}, fmt.Errorf("get refresh token by hash: %w", err)
+	}
+
+	return token, nil
+}
+
+// Revoke отзывает токен. Условие на revoked_at не дает двум
+// одновременным обновлениям использовать один токен дважды
+func (r *RefreshTokenRepo) Revoke(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("revoke refresh token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllForUser отзывает все действующие токены пользователя
+func (r *RefreshTokenRepo) RevokeAllForUser(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		return fmt.Errorf("revoke user refresh tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// searchItemsQuery выбирает списки и задачи рабочего пространства $1, подходящие
+// под запрос $2. Архивные списки и их задачи, как и в выдаче списков, не ищутся.
+// document — текст, из которого строится фрагмент с совпадениями.
+const searchItemsQuery = `
+	SELECT 'list' AS type, l.id, NULL::uuid AS list_id, l.title,
+		l.title || ' ' || COALESCE(l.description, '') AS document,
+		ts_rank_cd(l.search_vector, websearch_to_tsquery('russian', $2))::float8 AS rank,
+		NULL::boolean AS completed
+	FROM lists l
+	WHERE l.search_vector @@ websearch_to_tsquery('russian', $2)
+		AND l.workspace_id = $1 AND l.deleted_at IS NULL AND l.archived_at IS NULL
+	UNION ALL
+	SELECT 'task', t.id, t.list_id, t.text, t.text,
+		ts_rank_cd(t.search_vector, websearch_to_tsquery('russian', $2))::float8,
+		t.completed
+	FROM tasks t
+	JOIN lists l ON l.id = t.list_id
+	WHERE t.search_vector @@ websearch_to_tsquery('russian', $2)
+		AND t.workspace_id = $1 AND t.deleted_at IS NULL
+		AND l.deleted_at IS NULL AND l.archived_at IS NULL
+`
+
+// Границы совпадений во фрагменте: символы из области частного использования
+// Unicode не встречаются в обычном тексте и переживают экранирование HTML
+const (
+	snippetStartSel = "\ue000"
+	snippetStopSel  = "\ue001"
+)
+
+// snippetOptions — параметры ts_headline: до двух фрагментов по 15–35 слов
+const snippetOptions = "StartSel=" + snippetStartSel + ", StopSel=" + snippetStopSel + ", MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=\" … \""
+
+type SearchRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSearchRepo(pool *pgxpool.Pool) *SearchRepo {
+	return &SearchRepo{
+		pool: pool,
+	}
+}
+
+// Search ищет списки и задачи рабочего пространства запроса, начиная с наиболее релевантных.
+// Фрагменты строятся только для страницы результатов.
+func (r *SearchRepo) Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.SearchResult, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	where := "TRUE"
+	args := []any{requestctx.Workspace(ctx), filter.Query}
+	if filter.Type != "" {
+		args = append(args, string(filter.Type))
+		where += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+	if filter.ListID != "" {
+		args = append(args, filter.ListID)
+		where += fmt.Sprintf(" AND COALESCE(list_id, id) = $%d", len(args))
+	}
+	if filter.Completed != nil {
+		args = append(args, *filter.Completed)
+		where += fmt.Sprintf(" AND completed = $%d", len(args))
+	}
+	if filter.MemberID != "" {
+		args = append(args, filter.MemberID)
+		where += " AND " + memberListsCondition("COALESCE(list_id, id)", len(args))
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) results WHERE %s`, searchItemsQuery, where)
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count search results: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT type, id, list_id, title,
+			ts_headline('russian', document, websearch_to_tsquery('russian', $2), $%d),
+			rank, completed
+		FROM (
+			SELECT *
+			FROM (%s) results
+			WHERE %s
+			ORDER BY rank DESC, id
+			LIMIT $%d OFFSET $%d
+		) page
+		ORDER BY rank DESC, id
+	`, len(args)+1, searchItemsQuery, where, len(args)+2, len(args)+3)
+	rows, err := r.pool.Query(ctx, query, append(args, snippetOptions, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]domain.SearchResult, 0)
+	for rows.Next() {
+		var result domain.SearchResult
+		if err := rows.Scan(&result.Type, &result.ID, &result.ListID, &result.Title, &result.Snippet, &result.Rank, &result.Completed); err != nil {
+			return nil, 0, fmt.Errorf("scan search result: %w", err)
+		}
+		result.Snippet = highlightSnippet(result.Snippet)
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return results, total, nil
+}
+
+// highlightSnippet экранирует фрагмент для HTML и заменяет границы совпадений тегом <mark>
+func highlightSnippet(snippet string) string {
+	return strings.NewReplacer(snippetStartSel, "<mark>", snippetStopSel, "</mark>").Replace(html.EscapeString(snippet))
+}
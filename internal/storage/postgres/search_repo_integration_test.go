@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	repo := NewSearchRepo(pool)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	workspaceRepo := NewWorkspaceRepo(pool)
+	ctx := context.Background()
+
+	groceries, err := listRepo.Create(ctx, "Покупки", "Молоко и хлеб на неделю")
+	require.NoError(t, err)
+	milk, err := taskRepo.CreateTask(ctx, domain.Task{ListID: groceries.ID, Text: "Купить молоко <без лактозы>"})
+	require.NoError(t, err)
+	bread, err := taskRepo.CreateTask(ctx, domain.Task{ListID: groceries.ID, Text: "Купить хлеб"})
+	require.NoError(t, err)
+	bread.SetStatus(domain.StatusDone)
+	_, err = taskRepo.UpdateTask(ctx, bread, nil)
+	require.NoError(t, err)
+
+	work, err := listRepo.Create(ctx, "Работа", "")
+	require.NoError(t, err)
+	_, err = taskRepo.CreateTask(ctx, domain.Task{ListID: work.ID, Text: "Заказать молоко в офис"})
+	require.NoError(t, err)
+
+	t.Run("Ranked Results", func(t *testing.T) {
+		// Русские слова находятся в любой форме
+		results, total, err := repo.Search(ctx, domain.SearchFilter{Query: "молока"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, results, 3)
+		for i := 1; i < len(results); i++ {
+			assert.GreaterOrEqual(t, results[i-1].Rank, results[i].Rank)
+		}
+
+		byID := make(map[string]domain.SearchResult)
+		for _, result := range results {
+			byID[result.ID] = result
+		}
+		assert.Equal(t, domain.SearchItemList, byID[groceries.ID].Type)
+		assert.Contains(t, byID[groceries.ID].Snippet, "<mark>Молоко</mark>")
+
+		// Совпадения выделены, остальной текст экранирован
+		require.Contains(t, byID, milk.ID)
+		assert.Contains(t, byID[milk.ID].Snippet, "<mark>молоко</mark>")
+		assert.Contains(t, byID[milk.ID].Snippet, "&lt;без лактозы&gt;")
+		assert.Equal(t, groceries.ID, *byID[milk.ID].ListID)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		results, total, err := repo.Search(ctx, domain.SearchFilter{Query: "молоко", ListID: groceries.ID}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, results, 2)
+
+		results, total, err = repo.Search(ctx, domain.SearchFilter{Query: "молоко", Type: domain.SearchItemTask}, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, results, 1)
+
+		done := true
+		results, _, err = repo.Search(ctx, domain.SearchFilter{Query: "купить", Completed: &done}, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, bread.ID, results[0].ID)
+
+		results, _, err = repo.Search(ctx, domain.SearchFilter{Query: "молоко -офис", Type: domain.SearchItemTask}, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, milk.ID, results[0].ID)
+	})
+
+	t.Run("Deleted And Other Workspaces", func(t *testing.T) {
+		require.NoError(t, taskRepo.DeleteTask(ctx, milk.ID))
+		_, total, err := repo.Search(ctx, domain.SearchFilter{Query: "лактозы"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+
+		workspace, err := workspaceRepo.Create(ctx, "Поиск")
+		require.NoError(t, err)
+		_, total, err = repo.Search(requestctx.WithWorkspace(ctx, workspace.ID), domain.SearchFilter{Query: "молоко"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Коды ошибок PostgreSQL
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+type TagRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTagRepo(pool *pgxpool.Pool) *TagRepo {
+	return &TagRepo{
+		pool: pool,
+	}
+}
+
+// isPgError сообщает, является ли err ошибкой PostgreSQL с указанным кодом
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// Create создает новую метку в рабочем пространстве запроса
+func (r *TagRepo) Create(ctx context.Context, name string) (domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        INSERT INTO tags (id, name, workspace_id)
+        VALUES ($1, $2, $3)
+        RETURNING id, name, created_at
+    `
+	var tag domain.Tag
+	err := r.pool.QueryRow(ctx, query, uuid.New(), name, requestctx.Workspace(ctx)).Scan(&tag.ID, &tag.Name, &tag.CreatedAt)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return domain.Tag{}, ErrAlreadyExists
+		}
+		return domain.Tag{}, fmt.Errorf("create tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// GetByID получает метку рабочего пространства запроса по ID
+func (r *TagRepo) GetByID(ctx context.Context, id string) (domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var tag domain.Tag
+	err := r.pool.QueryRow(ctx, "SELECT id, name, created_at FROM tags WHERE id = $1 AND workspace_id = $2", id, requestctx.Workspace(ctx)).
+		Scan(&tag.ID, &tag.Name, &tag.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Tag{}, ErrNotFound
+		}
+		return domain.Tag{}, fmt.Errorf("get tag by id: %w", err)
+	}
+
+	return tag, nil
+}
+
+// List получает метки рабочего пространства запроса с пагинацией, упорядоченные по имени
+func (r *TagRepo) List(ctx context.Context, limit, offset int) ([]domain.Tag, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workspaceID := requestctx.Workspace(ctx)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM tags WHERE workspace_id = $1`, workspaceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count tags: %w", err)
+	}
+
+	query := `
+        SELECT id, name, created_at
+        FROM tags
+        WHERE workspace_id = $1
+        ORDER BY lower(name)
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := r.pool.Query(ctx, query, workspaceID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags, err := scanTags(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tags, total, nil
+}
+
+// Update переименовывает метку рабочего пространства запроса
+func (r *TagRepo) Update(ctx context.Context, id, name string) (domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        UPDATE tags
+        SET name = $2
+        WHERE id = $1 AND workspace_id = $3
+        RETURNING id, name, created_at
+    `
+	var tag domain.Tag
+	err := r.pool.QueryRow(ctx, query, id, name, requestctx.Workspace(ctx)).Scan(&tag.ID, &tag.Name, &tag.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Tag{}, ErrNotFound
+		}
+		if isPgError(err, pgUniqueViolation) {
+			return domain.Tag{}, ErrAlreadyExists
+		}
+		return domain.Tag{}, fmt.Errorf("update tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// Delete удаляет метку рабочего пространства запроса вместе со всеми ее назначениями
+func (r *TagRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM tags WHERE id = $1 AND workspace_id = $2`, id, requestctx.Workspace(ctx))
+	if err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AttachToTask назначает метку задаче. Метка и задача должны быть из рабочего
+// пространства запроса. Повторное назначение не считается ошибкой.
+func (r *TagRepo) AttachToTask(ctx context.Context, taskID, tagID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        WITH found AS (
+            SELECT t.id AS task_id, g.id AS tag_id
+            FROM tasks t
+            JOIN tags g ON g.workspace_id = t.workspace_id
+            WHERE t.id = $1 AND g.id = $2 AND t.workspace_id = $3
+        ), inserted AS (
+            INSERT INTO task_tags (task_id, tag_id)
+            SELECT task_id, tag_id FROM found
+            ON CONFLICT DO NOTHING
+        )
+        SELECT EXISTS(SELECT 1 FROM found)
+    `
+	var found bool
+	if err := r.pool.QueryRow(ctx, query, taskID, tagID, requestctx.Workspace(ctx)).Scan(&found); err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("attach tag: %w", err)
+	}
+
+	if !found {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DetachFromTask снимает метку рабочего пространства запроса с задачи
+func (r *TagRepo) DetachFromTask(ctx context.Context, taskID, tagID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        DELETE FROM task_tags
+        WHERE task_id = $1 AND tag_id = $2
+          AND tag_id IN (SELECT id FROM tags WHERE workspace_id = $3)
+    `
+	result, err := r.pool.Exec(ctx, query, taskID, tagID, requestctx.Workspace(ctx))
+	if err != nil {
+		return fmt.Errorf("detach tag: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListByTask получает метки задачи из рабочего пространства запроса
+func (r *TagRepo) ListByTask(ctx context.Context, taskID string) ([]domain.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+        SELECT t.id, t.name, t.created_at
+        FROM tags t
+        JOIN task_tags tt ON tt.tag_id = t.id
+        WHERE tt.task_id = $1 AND t.workspace_id = $2
+        ORDER BY lower(t.name)
+    `
+	rows, err := r.pool.Query(ctx, query, taskID, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list task tags: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTags(rows)
+}
+
+func scanTags(rows pgx.Rows) ([]domain.Tag, error) {
+	tags := make([]domain.Tag, 0)
+	for rows.Next() {
+		var tag domain.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tags, nil
+}
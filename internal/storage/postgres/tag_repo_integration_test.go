@@ -0,0 +1,104 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	tagRepo := NewTagRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	var listID string
+	err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Tagged").Scan(&listID)
+	require.NoError(t, err)
+
+	t.Run("Unique Names", func(t *testing.T) {
+		_, err := tagRepo.Create(ctx, "Urgent")
+		require.NoError(t, err)
+
+		_, err = tagRepo.Create(ctx, "urgent")
+		assert.ErrorIs(t, err, ErrAlreadyExists)
+	})
+
+	t.Run("Filter Tasks by Tags", func(t *testing.T) {
+		home, err := tagRepo.Create(ctx, "home")
+		require.NoError(t, err)
+		work, err := tagRepo.Create(ctx, "work")
+		require.NoError(t, err)
+
+		homeTask, err := taskRepo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Home only"})
+		require.NoError(t, err)
+		bothTask, err := taskRepo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Home and work"})
+		require.NoError(t, err)
+		_, err = taskRepo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Untagged"})
+		require.NoError(t, err)
+
+		require.NoError(t, tagRepo.AttachToTask(ctx, homeTask.ID, home.ID))
+		require.NoError(t, tagRepo.AttachToTask(ctx, bothTask.ID, home.ID))
+		require.NoError(t, tagRepo.AttachToTask(ctx, bothTask.ID, work.ID))
+		// Повторное назначение не является ошибкой
+		require.NoError(t, tagRepo.AttachToTask(ctx, bothTask.ID, work.ID))
+
+		tags, err := tagRepo.ListByTask(ctx, bothTask.ID)
+		require.NoError(t, err)
+		assert.Len(t, tags, 2)
+
+		filter := domain.TaskFilter{TagIDs: []string{home.ID, work.ID}, TagMode: domain.TagMatchAny}
+		_, total, err := taskRepo.ListTasks(ctx, listID, filter, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		filter.TagMode = domain.TagMatchAll
+		tasks, total, err := taskRepo.ListTasks(ctx, listID, filter, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, bothTask.ID, tasks[0].ID)
+
+		require.NoError(t, tagRepo.DetachFromTask(ctx, bothTask.ID, work.ID))
+		assert.ErrorIs(t, tagRepo.DetachFromTask(ctx, bothTask.ID, work.ID), ErrNotFound)
+	})
+
+	t.Run("Workspace Isolation", func(t *testing.T) {
+		workspace, err := NewWorkspaceRepo(pool).Create(ctx, "Метки")
+		require.NoError(t, err)
+		wsCtx := requestctx.WithWorkspace(ctx, workspace.ID)
+
+		// Имя занято в рабочем пространстве по умолчанию, но свободно в другом
+		own, err := tagRepo.Create(wsCtx, "urgent")
+		require.NoError(t, err)
+
+		_, err = tagRepo.GetByID(ctx, own.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = tagRepo.Update(ctx, own.ID, "renamed")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, tagRepo.Delete(ctx, own.ID), ErrNotFound)
+
+		tags, total, err := tagRepo.List(wsCtx, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, tags, 1)
+		assert.Equal(t, own.ID, tags[0].ID)
+
+		// Метку нельзя назначить задаче другого рабочего пространства
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Чужая метка"})
+		require.NoError(t, err)
+		assert.ErrorIs(t, tagRepo.AttachToTask(ctx, task.ID, own.ID), ErrNotFound)
+		assert.ErrorIs(t, tagRepo.AttachToTask(wsCtx, task.ID, own.ID), ErrNotFound)
+	})
+}
@@ -2,9 +2,14 @@ package postgres
 
 import (
 	"RestApi/internal/domain"
+	"RestApi/internal/rank"
+	"RestApi/internal/requestctx"
+	"RestApi/internal/storage"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +17,54 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// taskColumns — колонки задачи в порядке, ожидаемом scanTask
+const taskColumns = "id, list_id, parent_task_id, text, status, completed, completed_at, priority, due_at, position, recurrence_rule, assignee_id, created_by, created_at, updated_at"
+
+// priorityRank переводит приоритет в число для сортировки
+const priorityRank = `CASE priority
+	WHEN 'urgent' THEN 4
+	WHEN 'high' THEN 3
+	WHEN 'medium' THEN 2
+	WHEN 'low' THEN 1
+	ELSE 0
+END`
+
+// updateTaskQuery обновляет все изменяемые поля задачи
+const updateTaskQuery = `
+	UPDATE tasks
+	SET text = $2, status = $3, priority = $4, due_at = $5, parent_task_id = $6, recurrence_rule = $7, assignee_id = $8, updated_at = NOW()
+	WHERE id = $1
+	RETURNING ` + taskColumns
+
+// cascadeStatusQuery проставляет статус всему поддереву задачи
+// и возвращает прежний статус каждой измененной подзадачи
+const cascadeStatusQuery = `
+	WITH RECURSIVE subtree AS (
+		SELECT id FROM tasks WHERE parent_task_id = $1 AND deleted_at IS NULL
+		UNION ALL
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_task_id = s.id WHERE t.deleted_at IS NULL
+	)
+	UPDATE tasks t
+	SET status = $2, updated_at = NOW()
+	FROM tasks old
+	WHERE t.id = old.id AND t.id IN (SELECT id FROM subtree) AND t.status <> $2
+	RETURNING t.id, old.status
+`
+
+// openBlockersCondition — у задачи есть невыполненные блокирующие задачи вне корзины
+const openBlockersCondition = `EXISTS (
+	SELECT 1
+	FROM task_dependencies d
+	JOIN tasks b ON b.id = d.blocker_id
+	WHERE d.task_id = tasks.id AND b.completed = FALSE AND b.deleted_at IS NULL
+)`
+
+// copyTaskTagsQuery назначает задаче $1 метки задачи $2
+const copyTaskTagsQuery = `
+	INSERT INTO task_tags (task_id, tag_id)
+	SELECT $1, tag_id FROM task_tags WHERE task_id = $2
+`
+
 type TaskRepo struct {
 	pool *pgxpool.Pool
 }
@@ -22,15 +75,50 @@ func NewTaskRepo(pool *pgxpool.Pool) *TaskRepo {
 	}
 }
 
-// Create создает новую задачу
-func (r *TaskRepo) CreateTask(task domain.Task) (domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// scanTask читает задачу; отсутствующий автор читается как пустая строка
+func scanTask(row pgx.Row, task *domain.Task) error {
+	var createdBy *string
+	err := row.Scan(
+		&task.ID,
+		&task.ListID,
+		&task.ParentTaskID,
+		&task.Text,
+		&task.Status,
+		&task.Completed,
+		&task.CompletedAt,
+		&task.Priority,
+		&task.DueAt,
+		&task.Position,
+		&task.RecurrenceRule,
+		&task.AssigneeID,
+		&createdBy,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if createdBy != nil {
+		task.CreatedBy = *createdBy
+	}
+	return nil
+}
+
+// Create создает новую задачу в рабочем пространстве запроса
+func (r *TaskRepo) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Генерируем ID если не передан
 	if task.ID == "" {
 		task.ID = uuid.New().String()
 	}
+	if task.Priority == "" {
+		task.Priority = domain.PriorityNone
+	}
+	if task.Status == "" {
+		task.Status = domain.StatusTodo
+	}
 	// Устанавливаем временные метки если не установлены
 	if task.CreatedAt.IsZero() {
 		task.CreatedAt = time.Now()
@@ -39,55 +127,77 @@ func (r *TaskRepo) CreateTask(task domain.Task) (domain.Task, error) {
 		task.UpdatedAt = time.Now()
 	}
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокировка списка не дает параллельным созданиям задач получить одну и ту же позицию
+	if _, err := lockList(ctx, tx, task.ListID); err != nil {
+		return domain.Task{}, err
+	}
+	// Новая задача по умолчанию встает в конец ручного порядка списка
+	if task.Position == "" {
+		last, err := lastPosition(ctx, tx, task.ListID)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if task.Position, err = rank.After(last); err != nil {
+			return domain.Task{}, fmt.Errorf("compute position: %w", err)
+		}
+	}
+
 	query := `
-        INSERT INTO tasks (id, list_id, text, completed, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
-        RETURNING id, list_id, text, completed, created_at, updated_at
-    `
+        INSERT INTO tasks (id, list_id, parent_task_id, text, status, priority, due_at, position, recurrence_rule, assignee_id, created_by, created_at, updated_at, workspace_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, '')::uuid, $12, $13, $14)
+        RETURNING ` + taskColumns
 	var createdTask domain.Task
-	err := r.pool.QueryRow(ctx, query,
+	err = scanTask(tx.QueryRow(ctx, query,
 		task.ID,
 		task.ListID,
+		task.ParentTaskID,
 		task.Text,
-		task.Completed,
+		task.Status,
+		task.Priority,
+		task.DueAt,
+		task.Position,
+		task.RecurrenceRule,
+		task.AssigneeID,
+		requestctx.UserID(ctx),
 		task.CreatedAt,
 		task.UpdatedAt,
-	).Scan(
-		&createdTask.ID,
-		&createdTask.ListID,
-		&createdTask.Text,
-		&createdTask.Completed,
-		&createdTask.CreatedAt,
-		&createdTask.UpdatedAt,
-	)
+		requestctx.Workspace(ctx),
+	), &createdTask)
 	if err != nil {
 		return domain.Task{}, fmt.Errorf("create task: %w", err)
 	}
 
+	if err := recordHistory(ctx, tx, domain.HistoryEntityTask, createdTask.ID, domain.HistoryCreated, domain.TaskChanges(nil, createdTask)); err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return createdTask, nil
 }
 
 // GetByID получает список по ID
-func (r *TaskRepo) GetByIDTask(id string) (domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *TaskRepo) GetByIDTask(ctx context.Context, id string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
-		SELECT id, list_id, text, completed, created_at, updated_at 
+		SELECT ` + taskColumns + `
 		FROM tasks 
-		WHERE id = $1
+		WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
 	`
 
 	var task domain.Task
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&task.ID,
-		&task.ListID,
-		&task.Text,
-		&task.Completed,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-	)
+	err := scanTask(r.pool.QueryRow(ctx, query, id, requestctx.Workspace(ctx)), &task)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -99,96 +209,897 @@ func (r *TaskRepo) GetByIDTask(id string) (domain.Task, error) {
 	return task, nil
 }
 
-func (r *TaskRepo) ListTasks(listID string, limit int, offset int) ([]domain.Task, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *TaskRepo) ListTasks(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	where, args := taskFilterConditions(requestctx.Workspace(ctx), "list_id", listID, filter)
+	return r.queryTasks(ctx, where, taskOrderBy(filter.Sort), args, limit, offset)
+}
+
+// ListTasksByCursor получает страницу задач списка после или перед курсором.
+// Поддерживается только порядок по created_at: ключ курсора — (created_at, id)
+func (r *TaskRepo) ListTasksByCursor(ctx context.Context, listID string, filter domain.TaskFilter, cursor *domain.Cursor, limit int) ([]domain.Task, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Получаем общее количество задач в списке
+	where, args := taskFilterConditions(requestctx.Workspace(ctx), "list_id", listID, filter)
+	condition, orderBy, args := keysetConditions(cursor, len(filter.Sort) == 0 || filter.Sort[0].Desc, args)
+	if condition != "" {
+		where += " AND " + condition
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d
+	`, taskColumns, where, orderBy, len(args)+1)
+	rows, err := r.pool.Query(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		return nil, false, fmt.Errorf("list tasks by cursor: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := collectTasks(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	more := len(tasks) > limit
+	if more {
+		tasks = tasks[:limit]
+	}
+	if cursor != nil && cursor.Before {
+		slices.Reverse(tasks)
+	}
+
+	return tasks, more, nil
+}
+
+// ListAllTasks получает все подходящие под фильтр задачи списка без пагинации
+func (r *TaskRepo) ListAllTasks(ctx context.Context, listID string, filter domain.TaskFilter) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	where, args := taskFilterConditions(requestctx.Workspace(ctx), "list_id", listID, filter)
+	query := fmt.Sprintf(`SELECT %s FROM tasks WHERE %s ORDER BY %s`, taskColumns, where, taskOrderBy(filter.Sort))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list all tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTasks(rows)
+}
+
+// taskFilterConditions строит условие WHERE и его аргументы по фильтру задач рабочего
+// пространства workspaceID, у которых колонка column (list_id или assignee_id) равна value
+func taskFilterConditions(workspaceID, column, value string, filter domain.TaskFilter) (string, []any) {
+	conditions := []string{column + " = $1", "workspace_id = $2", "deleted_at IS NULL"}
+	args := []any{value, workspaceID}
+
+	if filter.DueBefore != nil {
+		args = append(args, *filter.DueBefore)
+		conditions = append(conditions, fmt.Sprintf("due_at < $%d", len(args)))
+	}
+	if filter.DueAfter != nil {
+		args = append(args, *filter.DueAfter)
+		conditions = append(conditions, fmt.Sprintf("due_at > $%d", len(args)))
+	}
+	if filter.CreatedBefore != nil {
+		args = append(args, *filter.CreatedBefore)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.CreatedAfter != nil {
+		args = append(args, *filter.CreatedAfter)
+		conditions = append(conditions, fmt.Sprintf("created_at > $%d", len(args)))
+	}
+	if filter.UpdatedBefore != nil {
+		args = append(args, *filter.UpdatedBefore)
+		conditions = append(conditions, fmt.Sprintf("updated_at < $%d", len(args)))
+	}
+	if filter.UpdatedAfter != nil {
+		args = append(args, *filter.UpdatedAfter)
+		conditions = append(conditions, fmt.Sprintf("updated_at > $%d", len(args)))
+	}
+	if filter.Overdue {
+		conditions = append(conditions, "due_at < NOW()", "completed = FALSE")
+	}
+	if filter.Completed != nil {
+		args = append(args, *filter.Completed)
+		conditions = append(conditions, fmt.Sprintf("completed = $%d", len(args)))
+	}
+	if filter.TextContains != "" {
+		// strpos вместо LIKE: символы % и _ в запросе не работают как шаблон
+		args = append(args, filter.TextContains)
+		conditions = append(conditions, fmt.Sprintf("strpos(lower(text), lower($%d)) > 0", len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		args = append(args, filter.Statuses)
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			conditions = append(conditions, openBlockersCondition)
+		} else {
+			conditions = append(conditions, "NOT "+openBlockersCondition)
+		}
+	}
+	if len(filter.TagIDs) > 0 {
+		args = append(args, filter.TagIDs)
+		tagCondition := fmt.Sprintf("id IN (SELECT task_id FROM task_tags WHERE tag_id = ANY($%d)", len(args))
+		if filter.TagMode == domain.TagMatchAll {
+			args = append(args, len(filter.TagIDs))
+			tagCondition += fmt.Sprintf(" GROUP BY task_id HAVING COUNT(*) = $%d", len(args))
+		}
+		conditions = append(conditions, tagCondition+")")
+	}
+	if filter.MemberID != "" {
+		args = append(args, filter.MemberID)
+		conditions = append(conditions, memberListsCondition("list_id", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// taskOrderBy строит ORDER BY из белого списка полей сортировки: в запрос попадают
+// только выражения отсюда, значения из запроса — никогда. Если created_at нет среди
+// ключей, задачи с равными ключами идут от новых к старым; id в конце делает порядок
+// стабильным между страницами.
+func taskOrderBy(sorts []domain.TaskSort) string {
+	terms := make([]string, 0, len(sorts)+2)
+	byCreatedAt := false
+	for _, sort := range sorts {
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+
+		switch sort.Field {
+		case domain.TaskSortPriority:
+			terms = append(terms, priorityRank+" "+direction)
+		case domain.TaskSortUpdatedAt:
+			terms = append(terms, "updated_at "+direction)
+		case domain.TaskSortDue:
+			terms = append(terms, "due_at "+direction+" NULLS LAST")
+		case domain.TaskSortPosition:
+			terms = append(terms, "position "+direction)
+		case domain.TaskSortText:
+			terms = append(terms, "lower(text) "+direction)
+		default:
+			terms = append(terms, "created_at "+direction)
+			byCreatedAt = true
+		}
+	}
+	if !byCreatedAt {
+		terms = append(terms, "created_at DESC")
+	}
+
+	return strings.Join(append(terms, "id"), ", ")
+}
+
+// ListAssignedTasks получает задачи исполнителя из всех списков с фильтрами, сортировкой и пагинацией
+func (r *TaskRepo) ListAssignedTasks(ctx context.Context, userID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error) {
+	where, args := taskFilterConditions(requestctx.Workspace(ctx), "assignee_id", userID, filter)
+	return r.queryTasks(ctx, where, taskOrderBy(filter.Sort), args, limit, offset)
+}
+
+// ListOverdueTasks получает незавершенные задачи с истекшим сроком из всех списков.
+// Непустой memberID оставляет только задачи списков, в которых состоит пользователь.
+func (r *TaskRepo) ListOverdueTasks(ctx context.Context, memberID string, limit int, offset int) ([]domain.Task, int, error) {
+	where := "workspace_id = $1 AND due_at < NOW() AND completed = FALSE AND deleted_at IS NULL"
+	args := []any{requestctx.Workspace(ctx)}
+	if memberID != "" {
+		args = append(args, memberID)
+		where += " AND " + memberListsCondition("list_id", len(args))
+	}
+	return r.queryTasks(ctx, where, "due_at ASC", args, limit, offset)
+}
+
+// queryTasks выбирает страницу задач по условию и считает их общее количество
+func (r *TaskRepo) queryTasks(ctx context.Context, where string, orderBy string, args []any, limit int, offset int) ([]domain.Task, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Получаем общее количество подходящих задач
 	var total int
-	countQuery := `SELECT COUNT(*) FROM tasks WHERE list_id = $1`
-	err := r.pool.QueryRow(ctx, countQuery, listID).Scan(&total)
+	countQuery := `SELECT COUNT(*) FROM tasks WHERE ` + where
+	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("count tasks: %w", err)
 	}
 
-	query := `
-		SELECT id, list_id, text, completed, created_at, updated_at
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM tasks
-		WHERE list_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
-	rows, err := r.pool.Query(ctx, query, listID, limit, offset)
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, taskColumns, where, orderBy, len(args)+1, len(args)+2)
+	rows, err := r.pool.Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list tasks: %w", err)
 	}
 	defer rows.Close()
 
-	tasks := make([]domain.Task, 0)
-	for rows.Next() {
-		var task domain.Task
-		err := rows.Scan(
-			&task.ID,
-			&task.ListID,
-			&task.Text,
-			&task.Completed,
-			&task.CreatedAt,
-			&task.UpdatedAt,
+	tasks, err := collectTasks(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, total, nil
+}
+
+// ListSubtasks получает непосредственные подзадачи задачи
+func (r *TaskRepo) ListSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE parent_task_id = $1 AND workspace_id = $2 AND deleted_at IS NULL ORDER BY created_at, id`
+	rows, err := r.pool.Query(ctx, query, parentID, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTasks(rows)
+}
+
+// ListDescendants получает все подзадачи задачи на любом уровне вложенности.
+// Подзадачи всегда в рабочем пространстве родителя, поэтому оно проверяется только на первом уровне
+func (r *TaskRepo) ListDescendants(ctx context.Context, id string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return listDescendants(ctx, r.pool, id, false)
+}
+
+// listDescendants получает поддерево задачи; lock блокирует подзадачи до конца транзакции
+func listDescendants(ctx context.Context, q querier, id string, lock bool) ([]domain.Task, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT ` + taskColumns + ` FROM tasks WHERE parent_task_id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT ` + prefixColumns("t", taskColumns) + `
+			FROM tasks t
+			JOIN subtree s ON t.parent_task_id = s.id
+			WHERE t.deleted_at IS NULL
 		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("scan task: %w", err)
+	`
+	if lock {
+		query += `SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) FOR UPDATE`
+	} else {
+		query += `SELECT ` + taskColumns + ` FROM subtree`
+	}
+	rows, err := q.Query(ctx, query, id, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list descendants: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTasks(rows)
+}
+
+// Update обновляет изменяемые поля задачи
+func (r *TaskRepo) UpdateTask(ctx context.Context, task domain.Task, check storage.TaskCheck) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, updated, err := updateTask(ctx, tx, task, check)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// UpdateTaskWithSubtasks обновляет задачу и в той же транзакции
+// переносит ее статус на все подзадачи
+func (r *TaskRepo) UpdateTaskWithSubtasks(ctx context.Context, task domain.Task, check storage.TaskCheck) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, updated, err := updateTask(ctx, tx, task, check)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := cascadeStatus(ctx, tx, task.ID, task.Status); err != nil {
+		return domain.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+// lockTask получает задачу рабочего пространства запроса, не находящуюся в корзине,
+// и блокирует ее до конца транзакции
+func lockTask(ctx context.Context, tx pgx.Tx, id string) (domain.Task, error) {
+	var task domain.Task
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL FOR UPDATE`
+	if err := scanTask(tx.QueryRow(ctx, query, id, requestctx.Workspace(ctx)), &task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Task{}, ErrNotFound
 		}
-		tasks = append(tasks, task)
+		return domain.Task{}, fmt.Errorf("get task by id: %w", err)
 	}
+	return task, nil
+}
 
+// updateTask блокирует задачу, проверяет изменение через check (если задан),
+// обновляет задачу в транзакции и записывает изменения в историю.
+// Возвращает задачу до и после изменения
+func updateTask(ctx context.Context, tx pgx.Tx, task domain.Task, check storage.TaskCheck) (domain.Task, domain.Task, error) {
+	before, err := lockTask(ctx, tx, task.ID)
+	if err != nil {
+		return domain.Task{}, domain.Task{}, err
+	}
+
+	if check != nil {
+		if err := check(ctx, before, txTaskReader{tx: tx}); err != nil {
+			return domain.Task{}, domain.Task{}, err
+		}
+	}
+
+	var updated domain.Task
+	err = scanTask(tx.QueryRow(ctx, updateTaskQuery, task.ID, task.Text, task.Status, task.Priority, task.DueAt, task.ParentTaskID, task.RecurrenceRule, task.AssigneeID), &updated)
+	if err != nil {
+		return domain.Task{}, domain.Task{}, fmt.Errorf("update task: %w", err)
+	}
+
+	if changes := domain.TaskChanges(&before, updated); len(changes) > 0 {
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, updated.ID, domain.HistoryUpdated, changes); err != nil {
+			return domain.Task{}, domain.Task{}, err
+		}
+	}
+
+	return before, updated, nil
+}
+
+// txTaskReader читает связанные задачи в транзакции изменения и блокирует их:
+// подзадачи — для изменения, блокирующие задачи — от изменения до конца транзакции
+type txTaskReader struct {
+	tx pgx.Tx
+}
+
+func (r txTaskReader) ListDescendants(ctx context.Context, id string) ([]domain.Task, error) {
+	return listDescendants(ctx, r.tx, id, true)
+}
+
+func (r txTaskReader) ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error) {
+	return listBlockers(ctx, r.tx, taskID, true)
+}
+
+// cascadeStatus переносит статус на поддерево задачи
+// и записывает изменение в историю каждой затронутой подзадачи.
+// Допустимость переходов по рабочему процессу проверяет сервис задач
+func cascadeStatus(ctx context.Context, tx pgx.Tx, id string, status domain.TaskStatus) error {
+	rows, err := tx.Query(ctx, cascadeStatusQuery, id, status)
+	if err != nil {
+		return fmt.Errorf("update subtasks: %w", err)
+	}
+
+	// Прежние статусы подзадач нужны для истории
+	var ids []string
+	previous := make(map[string]domain.TaskStatus)
+	for rows.Next() {
+		var subtaskID string
+		var before domain.TaskStatus
+		if err := rows.Scan(&subtaskID, &before); err != nil {
+			rows.Close()
+			return fmt.Errorf("update subtasks: %w", err)
+		}
+		ids = append(ids, subtaskID)
+		previous[subtaskID] = before
+	}
+	rows.Close()
 	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("rows error: %w", err)
+		return fmt.Errorf("update subtasks: %w", err)
 	}
 
-	return tasks, total, nil
+	var before, after domain.Task
+	after.SetStatus(status)
+	for _, subtaskID := range ids {
+		before.SetStatus(previous[subtaskID])
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, subtaskID, domain.HistoryUpdated, domain.TaskChanges(&before, after)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NextPosition возвращает ближайшую позицию после position в списке,
+// не учитывая задачу excludeID. Пустая строка — позиции дальше нет.
+func (r *TaskRepo) NextPosition(ctx context.Context, listID, position, excludeID string) (string, error) {
+	return r.adjacentPosition(ctx, `SELECT MIN(position) FROM tasks WHERE list_id = $1 AND position > $2 AND id <> $3 AND workspace_id = $4 AND deleted_at IS NULL`, listID, position, excludeID)
+}
+
+// PrevPosition возвращает ближайшую позицию перед position в списке,
+// не учитывая задачу excludeID. Пустая строка — позиции раньше нет.
+func (r *TaskRepo) PrevPosition(ctx context.Context, listID, position, excludeID string) (string, error) {
+	return r.adjacentPosition(ctx, `SELECT MAX(position) FROM tasks WHERE list_id = $1 AND position < $2 AND id <> $3 AND workspace_id = $4 AND deleted_at IS NULL`, listID, position, excludeID)
+}
+
+func (r *TaskRepo) adjacentPosition(ctx context.Context, query, listID, position, excludeID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var adjacent *string
+	if err := r.pool.QueryRow(ctx, query, listID, position, excludeID, requestctx.Workspace(ctx)).Scan(&adjacent); err != nil {
+		return "", fmt.Errorf("get adjacent position: %w", err)
+	}
+	if adjacent == nil {
+		return "", nil
+	}
+	return *adjacent, nil
+}
+
+// CompleteRecurringTask в одной транзакции сохраняет завершенную повторяющуюся
+// задачу и создает ее следующее повторение с метками исходной задачи.
+// При cascade завершаются также все подзадачи. Если задачу уже завершили
+// параллельно, изменения сохраняются, а повторение не создается: next пустая
+func (r *TaskRepo) CompleteRecurringTask(ctx context.Context, task domain.Task, next domain.Task, cascade bool, check storage.TaskCheck) (domain.Task, domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	before, updated, err := updateTask(ctx, tx, task, check)
+	if err != nil {
+		return domain.Task{}, domain.Task{}, err
+	}
+
+	if cascade {
+		if err := cascadeStatus(ctx, tx, task.ID, task.Status); err != nil {
+			return domain.Task{}, domain.Task{}, err
+		}
+	}
+
+	// Следующее повторение создается один раз: у завершенной задачи
+	// или задачи без правила оно уже есть
+	if before.Completed || before.RecurrenceRule == nil {
+		if err := tx.Commit(ctx); err != nil {
+			return domain.Task{}, domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+		}
+		return updated, domain.Task{}, nil
+	}
+
+	if next.ID == "" {
+		next.ID = uuid.New().String()
+	}
+	if next.Status == "" {
+		next.Status = domain.StatusTodo
+	}
+	insertQuery := `
+		INSERT INTO tasks (id, list_id, parent_task_id, text, status, priority, due_at, position, recurrence_rule, assignee_id, created_by, workspace_id, recurrence_parent_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, '')::uuid, $12, $13)
+		RETURNING ` + taskColumns
+	var created domain.Task
+	err = scanTask(tx.QueryRow(ctx, insertQuery,
+		next.ID,
+		next.ListID,
+		next.ParentTaskID,
+		next.Text,
+		next.Status,
+		next.Priority,
+		next.DueAt,
+		next.Position,
+		next.RecurrenceRule,
+		next.AssigneeID,
+		requestctx.UserID(ctx),
+		requestctx.Workspace(ctx),
+		task.ID,
+	), &created)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return domain.Task{}, domain.Task{}, ErrAlreadyExists
+		}
+		return domain.Task{}, domain.Task{}, fmt.Errorf("create next occurrence: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, copyTaskTagsQuery, created.ID, task.ID); err != nil {
+		return domain.Task{}, domain.Task{}, fmt.Errorf("copy task tags: %w", err)
+	}
+
+	if err := recordHistory(ctx, tx, domain.HistoryEntityTask, created.ID, domain.HistoryCreated, domain.TaskChanges(nil, created)); err != nil {
+		return domain.Task{}, domain.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return updated, created, nil
+}
+
+// LastPosition возвращает последнюю позицию в ручном порядке списка.
+// Пустая строка — в списке нет задач.
+func (r *TaskRepo) LastPosition(ctx context.Context, listID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return lastPosition(ctx, r.pool, listID)
+}
+
+// querier выполняет запросы в пуле соединений или в транзакции
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// lastPosition возвращает последнюю позицию среди задач списка, не находящихся в корзине
+func lastPosition(ctx context.Context, q querier, listID string) (string, error) {
+	var last *string
+	query := `SELECT MAX(position) FROM tasks WHERE list_id = $1 AND workspace_id = $2 AND deleted_at IS NULL`
+	err := q.QueryRow(ctx, query, listID, requestctx.Workspace(ctx)).Scan(&last)
+	if err != nil {
+		return "", fmt.Errorf("get last position: %w", err)
+	}
+	if last == nil {
+		return "", nil
+	}
+	return *last, nil
 }
 
-// Update обновляет название задачи
-func (r *TaskRepo) UpdateTask(id string, text string, completed bool) (domain.Task, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// SetPosition меняет позицию задачи в ручном порядке
+func (r *TaskRepo) SetPosition(ctx context.Context, id, position string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockTask(ctx, tx, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
 	query := `
-        UPDATE tasks
-		SET text = $2, completed = $3, updated_at = NOW()
+		UPDATE tasks
+		SET position = $2, updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, list_id, text, completed, created_at, updated_at
-    `
+		RETURNING ` + taskColumns
 
 	var task domain.Task
-	err := r.pool.QueryRow(ctx, query, id, text, completed).Scan(
-		&task.ID,
-		&task.ListID,
-		&task.Text,
-		&task.Completed,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-	)
+	if err := scanTask(tx.QueryRow(ctx, query, id, position), &task); err != nil {
+		return domain.Task{}, fmt.Errorf("set task position: %w", err)
+	}
+
+	if changes := domain.TaskChanges(&before, task); len(changes) > 0 {
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, id, domain.HistoryMoved, changes); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// MoveTasks переносит задачи в другие списки в одной транзакции
+func (r *TaskRepo) MoveTasks(ctx context.Context, moves []domain.TaskMove) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE tasks
+		SET list_id = $2,
+			parent_task_id = $3,
+			position = $4,
+			status = CASE WHEN $5 AND status = 'done' THEN $6 ELSE status END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + taskColumns
+
+	moved := make([]domain.Task, 0, len(moves))
+	for _, move := range moves {
+		before, err := lockTask(ctx, tx, move.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resetStatus := move.ResetStatus
+		if resetStatus == "" {
+			resetStatus = domain.StatusTodo
+		}
+
+		var task domain.Task
+		err = scanTask(tx.QueryRow(ctx, query, move.ID, move.ListID, move.ParentTaskID, move.Position, move.ResetCompleted, resetStatus), &task)
+		if err != nil {
+			return nil, fmt.Errorf("move task: %w", err)
+		}
+
+		if changes := domain.TaskChanges(&before, task); len(changes) > 0 {
+			if err := recordHistory(ctx, tx, domain.HistoryEntityTask, task.ID, domain.HistoryMoved, changes); err != nil {
+				return nil, err
+			}
+		}
+		moved = append(moved, task)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return moved, nil
+}
+
+// CopyTasks создает копии задач вместе с их метками в одной транзакции
+func (r *TaskRepo) CopyTasks(ctx context.Context, copies []domain.TaskCopy) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO tasks (id, list_id, parent_task_id, text, status, priority, due_at, position, recurrence_rule, assignee_id, created_by, workspace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, '')::uuid, $12)
+		RETURNING ` + taskColumns
+
+	created := make([]domain.Task, 0, len(copies))
+	for _, c := range copies {
+		task := c.Task
+		if task.Status == "" {
+			task.Status = domain.StatusTodo
+		}
+		var copied domain.Task
+		err := scanTask(tx.QueryRow(ctx, insertQuery,
+			uuid.New().String(),
+			task.ListID,
+			task.ParentTaskID,
+			task.Text,
+			task.Status,
+			task.Priority,
+			task.DueAt,
+			task.Position,
+			task.RecurrenceRule,
+			task.AssigneeID,
+			requestctx.UserID(ctx),
+			requestctx.Workspace(ctx),
+		), &copied)
+		if err != nil {
+			return nil, fmt.Errorf("copy task: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, copyTaskTagsQuery, copied.ID, c.SourceID); err != nil {
+			return nil, fmt.Errorf("copy task tags: %w", err)
+		}
+
+		changes := domain.TaskChanges(nil, copied)
+		changes["copied_from"] = domain.FieldChange{From: nil, To: c.SourceID}
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, copied.ID, domain.HistoryCopied, changes); err != nil {
+			return nil, err
+		}
+		created = append(created, copied)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteTask перемещает задачу вместе с подзадачами в корзину.
+// Все задачи поддерева получают одинаковое время удаления.
+func (r *TaskRepo) DeleteTask(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_task_id = s.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks
+		SET deleted_at = NOW()
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING id
+	`
+
+	rows, err := tx.Query(ctx, query, id, requestctx.Workspace(ctx))
+	if err != nil {
+		return fmt.Errorf("delete task: %w", err)
+	}
+	ids, err := collectIDs(rows)
+	if err != nil {
+		return fmt.Errorf("delete task: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return ErrNotFound
+	}
+
+	if err := recordTaskHistory(ctx, tx, ids, domain.HistoryDeleted, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeletedTask получает задачу из корзины
+func (r *TaskRepo) GetDeletedTask(ctx context.Context, id string) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`
+
+	var task domain.Task
+	if err := scanTask(r.pool.QueryRow(ctx, query, id, requestctx.Workspace(ctx)), &task); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Task{}, ErrNotFound
 		}
-		return domain.Task{}, fmt.Errorf("update task: %w", err)
+		return domain.Task{}, fmt.Errorf("get deleted task: %w", err)
 	}
 
 	return task, nil
 }
 
-// Delete удаляет задачу
-func (r *TaskRepo) DeleteTask(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// RestoreTask возвращает задачу из корзины вместе с подзадачами, удаленными
+// одновременно с ней. При detachParent задача становится задачей верхнего уровня.
+func (r *TaskRepo) RestoreTask(ctx context.Context, id string, detachParent bool) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `DELETE FROM tasks WHERE id = $1`
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	result, err := r.pool.Exec(ctx, query, id)
+	restoreQuery := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at FROM tasks WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+			UNION ALL
+			SELECT t.id, t.deleted_at FROM tasks t
+			JOIN subtree s ON t.parent_task_id = s.id
+			WHERE t.deleted_at = s.deleted_at
+		)
+		UPDATE tasks
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING id
+	`
+	rows, err := tx.Query(ctx, restoreQuery, id, requestctx.Workspace(ctx))
 	if err != nil {
-		return fmt.Errorf("delete task: %w", err)
+		return domain.Task{}, fmt.Errorf("restore task: %w", err)
+	}
+	ids, err := collectIDs(rows)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("restore task: %w", err)
+	}
+	if len(ids) == 0 {
+		return domain.Task{}, ErrNotFound
+	}
+
+	var task domain.Task
+	if err := scanTask(tx.QueryRow(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = $1`, id), &task); err != nil {
+		return domain.Task{}, fmt.Errorf("get restored task: %w", err)
+	}
+
+	// Отвязка от родителя записывается в историю вместе с восстановлением задачи
+	changes := domain.Changes{}
+	if detachParent && task.ParentTaskID != nil {
+		if _, err := tx.Exec(ctx, `UPDATE tasks SET parent_task_id = NULL WHERE id = $1`, id); err != nil {
+			return domain.Task{}, fmt.Errorf("detach restored task: %w", err)
+		}
+		changes["parent_task_id"] = domain.FieldChange{From: *task.ParentTaskID, To: nil}
+		task.ParentTaskID = nil
+	}
+
+	for _, restoredID := range ids {
+		var restoredChanges domain.Changes
+		if restoredID == id {
+			restoredChanges = changes
+		}
+		if err := recordHistory(ctx, tx, domain.HistoryEntityTask, restoredID, domain.HistoryRestored, restoredChanges); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Task{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// AddDependency делает задачу blockerID блокирующей для задачи taskID.
+// Обе задачи должны быть в рабочем пространстве запроса. Повторное добавление не считается ошибкой.
+func (r *TaskRepo) AddDependency(ctx context.Context, taskID, blockerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		WITH pair AS (
+			SELECT t.id AS task_id, b.id AS blocker_id
+			FROM tasks t
+			JOIN tasks b ON b.workspace_id = t.workspace_id
+			WHERE t.id = $1 AND b.id = $2 AND t.workspace_id = $3
+		), inserted AS (
+			INSERT INTO task_dependencies (task_id, blocker_id)
+			SELECT task_id, blocker_id FROM pair
+			ON CONFLICT DO NOTHING
+		)
+		SELECT COUNT(*) FROM pair
+	`
+	var found int
+	if err := r.pool.QueryRow(ctx, query, taskID, blockerID, requestctx.Workspace(ctx)).Scan(&found); err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("add dependency: %w", err)
+	}
+
+	if found == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveDependency убирает зависимость задачи taskID от задачи blockerID
+func (r *TaskRepo) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		DELETE FROM task_dependencies
+		WHERE task_id = $1 AND blocker_id = $2
+			AND task_id IN (SELECT id FROM tasks WHERE workspace_id = $3)
+	`
+	result, err := r.pool.Exec(ctx, query, taskID, blockerID, requestctx.Workspace(ctx))
+	if err != nil {
+		return fmt.Errorf("remove dependency: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -197,3 +1108,77 @@ func (r *TaskRepo) DeleteTask(id string) error {
 
 	return nil
 }
+
+// ListBlockers получает блокирующие задачи вне корзины в порядке добавления зависимостей
+func (r *TaskRepo) ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return listBlockers(ctx, r.pool, taskID, false)
+}
+
+// listBlockers получает блокирующие задачи; lock не дает изменить их до конца транзакции
+func listBlockers(ctx context.Context, q querier, taskID string, lock bool) ([]domain.Task, error) {
+	query := `
+		SELECT ` + prefixColumns("t", taskColumns) + `
+		FROM tasks t
+		JOIN task_dependencies d ON d.blocker_id = t.id
+		WHERE d.task_id = $1 AND t.workspace_id = $2 AND t.deleted_at IS NULL
+		ORDER BY d.created_at, t.id
+	`
+	if lock {
+		query += ` FOR SHARE OF t`
+	}
+	rows, err := q.Query(ctx, query, taskID, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list blockers: %w", err)
+	}
+	defer rows.Close()
+
+	return collectTasks(rows)
+}
+
+// ListBlockerIDs получает ID всех блокирующих задач, включая задачи в корзине
+func (r *TaskRepo) ListBlockerIDs(ctx context.Context, taskID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT d.blocker_id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE d.task_id = $1 AND t.workspace_id = $2
+	`
+	rows, err := r.pool.Query(ctx, query, taskID, requestctx.Workspace(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list blocker ids: %w", err)
+	}
+
+	return collectIDs(rows)
+}
+
+func collectTasks(rows pgx.Rows) ([]domain.Task, error) {
+	tasks := make([]domain.Task, 0)
+	for rows.Next() {
+		var task domain.Task
+		if err := scanTask(rows, &task); err != nil {
+			return nil, fmt.Errorf("scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// prefixColumns добавляет псевдоним таблицы к каждой колонке списка
+func prefixColumns(alias string, columns string) string {
+	parts := strings.Split(columns, ", ")
+	for i, column := range parts {
+		parts[i] = alias + "." + column
+	}
+	return strings.Join(parts, ", ")
+}
@@ -5,8 +5,15 @@ package postgres
 
 import (
 	"RestApi/internal/domain"
+	"RestApi/internal/rank"
+	"RestApi/internal/storage"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,27 +46,7 @@ func setupTestDatabase(t *testing.T) *pgxpool.Pool {
 	pool, err := pgxpool.New(ctx, connStr)
 	require.NoError(t, err)
 
-	// Create tables
-	_, err = pool.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS lists (
-			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-			title TEXT NOT NULL CHECK (length(title) BETWEEN 1 AND 100),
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		)
-	`)
-	require.NoError(t, err)
-
-	_, err = pool.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS tasks (
-			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-			list_id UUID NOT NULL REFERENCES lists(id) ON DELETE CASCADE,
-			text TEXT NOT NULL CHECK (length(text) BETWEEN 1 AND 500),
-			completed BOOLEAN NOT NULL DEFAULT FALSE,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		)
-	`)
-	require.NoError(t, err)
+	applyMigrations(t, pool)
 
 	t.Cleanup(func() {
 		pool.Close()
@@ -69,6 +56,23 @@ func setupTestDatabase(t *testing.T) *pgxpool.Pool {
 	return pool
 }
 
+// applyMigrations применяет up-миграции из каталога migrations по порядку,
+// чтобы схема тестовой БД совпадала с боевой
+func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
+	files, err := filepath.Glob("../../../migrations/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	sort.Strings(files)
+
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(context.Background(), string(migration))
+		require.NoError(t, err, "apply migration %s", filepath.Base(file))
+	}
+}
+
 func TestTaskRepo_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -80,17 +84,17 @@ func TestTaskRepo_Integration(t *testing.T) {
 
 	// Create a test list first
 	var listID string
-	err := pool.QueryRow(ctx, "INSERT INTO lists (title) VALUES ($1) RETURNING id", "Test List").Scan(&listID)
+	err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Test List").Scan(&listID)
 	require.NoError(t, err)
 
 	t.Run("Create and Get Task", func(t *testing.T) {
 		task := domain.Task{
-			ListID:    listID,
-			Text:      "Integration test task",
-			Completed: false,
+			ListID: listID,
+			Text:   "Integration test task",
+			Status: domain.StatusTodo,
 		}
 
-		created, err := repo.CreateTask(task)
+		created, err := repo.CreateTask(ctx, task)
 		require.NoError(t, err)
 		assert.NotEmpty(t, created.ID)
 		assert.Equal(t, task.Text, created.Text)
@@ -98,7 +102,7 @@ func TestTaskRepo_Integration(t *testing.T) {
 		assert.False(t, created.Completed)
 
 		// Test GetByID
-		fetched, err := repo.GetByIDTask(created.ID)
+		fetched, err := repo.GetByIDTask(ctx, created.ID)
 		require.NoError(t, err)
 		assert.Equal(t, created, fetched)
 	})
@@ -106,41 +110,507 @@ func TestTaskRepo_Integration(t *testing.T) {
 	t.Run("List Tasks with Pagination", func(t *testing.T) {
 		// Create multiple tasks
 		for i := 0; i < 5; i++ {
-			_, err := repo.CreateTask(domain.Task{
+			_, err := repo.CreateTask(ctx, domain.Task{
 				ListID: listID,
 				Text:   fmt.Sprintf("Test task %d", i),
 			})
 			require.NoError(t, err)
 		}
 
-		tasks, total, err := repo.ListTasks(listID, 3, 0)
+		tasks, total, err := repo.ListTasks(ctx, listID, domain.TaskFilter{}, 3, 0)
 		require.NoError(t, err)
 		assert.Len(t, tasks, 3)
 		assert.GreaterOrEqual(t, total, 5)
 	})
 
+	t.Run("Cursor Pagination", func(t *testing.T) {
+		var cursorListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Cursor List").Scan(&cursorListID)
+		require.NoError(t, err)
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			task, err := repo.CreateTask(ctx, domain.Task{ListID: cursorListID, Text: fmt.Sprintf("Cursor task %d", i)})
+			require.NoError(t, err)
+			ids = append(ids, task.ID)
+		}
+
+		// sort=created_at по возрастанию: от старых к новым
+		filter := domain.TaskFilter{Sort: []domain.TaskSort{{Field: domain.TaskSortCreatedAt}}}
+		first, more, err := repo.ListTasksByCursor(ctx, cursorListID, filter, nil, 2)
+		require.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, ids[:2], taskIDs(first))
+
+		after := &domain.Cursor{CreatedAt: first[1].CreatedAt, ID: first[1].ID}
+		rest, more, err := repo.ListTasksByCursor(ctx, cursorListID, filter, after, 2)
+		require.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, ids[2:], taskIDs(rest))
+
+		before := &domain.Cursor{CreatedAt: rest[0].CreatedAt, ID: rest[0].ID, Before: true}
+		prev, more, err := repo.ListTasksByCursor(ctx, cursorListID, filter, before, 2)
+		require.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, ids[:2], taskIDs(prev))
+
+		// По умолчанию — от новых к старым
+		newest, _, err := repo.ListTasksByCursor(ctx, cursorListID, domain.TaskFilter{}, nil, 1)
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:], taskIDs(newest))
+	})
+
 	t.Run("Update Task", func(t *testing.T) {
-		task, _ := repo.CreateTask(domain.Task{
+		task, _ := repo.CreateTask(ctx, domain.Task{
 			ListID: listID,
 			Text:   "To update",
 		})
 
-		updated, err := repo.UpdateTask(task.ID, "Updated text", true)
+		task.Text = "Updated text"
+		task.SetStatus(domain.StatusDone)
+		updated, err := repo.UpdateTask(ctx, task, nil)
 		require.NoError(t, err)
 		assert.Equal(t, "Updated text", updated.Text)
 		assert.True(t, updated.Completed)
 	})
 
+	t.Run("Due Dates and Overdue", func(t *testing.T) {
+		past := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+		future := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+		overdue, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Overdue", DueAt: &past})
+		require.NoError(t, err)
+		require.NotNil(t, overdue.DueAt)
+		assert.True(t, past.Equal(*overdue.DueAt))
+
+		_, err = repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Upcoming", DueAt: &future})
+		require.NoError(t, err)
+
+		_, err = repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Done late", DueAt: &past, Status: domain.StatusDone})
+		require.NoError(t, err)
+
+		tasks, total, err := repo.ListTasks(ctx, listID, domain.TaskFilter{Overdue: true}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, overdue.ID, tasks[0].ID)
+
+		now := time.Now()
+		tasks, _, err = repo.ListTasks(ctx, listID, domain.TaskFilter{DueAfter: &now}, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Upcoming", tasks[0].Text)
+
+		tasks, total, err = repo.ListOverdueTasks(ctx, "", 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, overdue.ID, tasks[0].ID)
+	})
+
+	t.Run("Sort by Priority", func(t *testing.T) {
+		var sortListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Priorities").Scan(&sortListID)
+		require.NoError(t, err)
+
+		for _, priority := range []domain.TaskPriority{domain.PriorityLow, domain.PriorityUrgent, domain.PriorityNone, domain.PriorityHigh} {
+			_, err := repo.CreateTask(ctx, domain.Task{ListID: sortListID, Text: string(priority), Priority: priority})
+			require.NoError(t, err)
+		}
+
+		sort := domain.TaskSort{Field: domain.TaskSortPriority, Desc: true}
+		tasks, _, err := repo.ListTasks(ctx, sortListID, domain.TaskFilter{Sort: []domain.TaskSort{sort}}, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 4)
+		assert.Equal(t, domain.PriorityUrgent, tasks[0].Priority)
+		assert.Equal(t, domain.PriorityHigh, tasks[1].Priority)
+		assert.Equal(t, domain.PriorityLow, tasks[2].Priority)
+		assert.Equal(t, domain.PriorityNone, tasks[3].Priority)
+
+		sort.Desc = false
+		tasks, _, err = repo.ListTasks(ctx, sortListID, domain.TaskFilter{Sort: []domain.TaskSort{sort}}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityNone, tasks[0].Priority)
+	})
+
+	t.Run("Filter and Multi-Key Sort", func(t *testing.T) {
+		var queryListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Query").Scan(&queryListID)
+		require.NoError(t, err)
+
+		march, err := repo.CreateTask(ctx, domain.Task{ListID: queryListID, Text: "Отчет за март", Priority: domain.PriorityHigh})
+		require.NoError(t, err)
+		april, err := repo.CreateTask(ctx, domain.Task{ListID: queryListID, Text: "отчет за апрель", Priority: domain.PriorityHigh})
+		require.NoError(t, err)
+		letter, err := repo.CreateTask(ctx, domain.Task{ListID: queryListID, Text: "Письмо 100%", Priority: domain.PriorityLow})
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE tasks SET completed = TRUE, status = 'done', updated_at = NOW() WHERE id = $1", march.ID)
+		require.NoError(t, err)
+
+		// Подстрока ищется без учета регистра, % не работает как шаблон
+		tasks, total, err := repo.ListTasks(ctx, queryListID, domain.TaskFilter{TextContains: "ОТЧЕТ"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		tasks, _, err = repo.ListTasks(ctx, queryListID, domain.TaskFilter{TextContains: "%"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{letter.ID}, taskIDs(tasks))
+
+		completed := false
+		tasks, _, err = repo.ListTasks(ctx, queryListID, domain.TaskFilter{Completed: &completed, TextContains: "отчет"}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{april.ID}, taskIDs(tasks))
+
+		tasks, _, err = repo.ListTasks(ctx, queryListID, domain.TaskFilter{CreatedAfter: &march.CreatedAt}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{letter.ID, april.ID}, taskIDs(tasks))
+		tasks, _, err = repo.ListTasks(ctx, queryListID, domain.TaskFilter{UpdatedBefore: &april.UpdatedAt}, 20, 0)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+
+		// Сначала по приоритету, при равном приоритете — по тексту
+		sort := []domain.TaskSort{
+			{Field: domain.TaskSortPriority, Desc: true},
+			{Field: domain.TaskSortText},
+		}
+		tasks, _, err = repo.ListTasks(ctx, queryListID, domain.TaskFilter{Sort: sort}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{april.ID, march.ID, letter.ID}, taskIDs(tasks))
+	})
+
+	t.Run("Subtasks and Cascade", func(t *testing.T) {
+		parent, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Parent"})
+		require.NoError(t, err)
+		child, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Child", ParentTaskID: &parent.ID})
+		require.NoError(t, err)
+		grandchild, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Grandchild", ParentTaskID: &child.ID})
+		require.NoError(t, err)
+
+		subtasks, err := repo.ListSubtasks(ctx, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, subtasks, 1)
+		assert.Equal(t, child.ID, subtasks[0].ID)
+
+		descendants, err := repo.ListDescendants(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Len(t, descendants, 2)
+
+		parent.SetStatus(domain.StatusDone)
+		_, err = repo.UpdateTaskWithSubtasks(ctx, parent, nil)
+		require.NoError(t, err)
+
+		fetched, err := repo.GetByIDTask(ctx, grandchild.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusDone, fetched.Status)
+		assert.True(t, fetched.Completed)
+
+		// Удаление родителя удаляет все поддерево
+		require.NoError(t, repo.DeleteTask(ctx, parent.ID))
+		_, err = repo.GetByIDTask(ctx, grandchild.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Status Filter", func(t *testing.T) {
+		var statusListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Kanban").Scan(&statusListID)
+		require.NoError(t, err)
+
+		_, err = repo.CreateTask(ctx, domain.Task{ListID: statusListID, Text: "New"})
+		require.NoError(t, err)
+		inProgress, err := repo.CreateTask(ctx, domain.Task{ListID: statusListID, Text: "Doing", Status: domain.StatusInProgress})
+		require.NoError(t, err)
+		blocked, err := repo.CreateTask(ctx, domain.Task{ListID: statusListID, Text: "Waiting", Status: domain.StatusBlocked})
+		require.NoError(t, err)
+		assert.False(t, blocked.Completed)
+
+		tasks, total, err := repo.ListTasks(ctx, statusListID, domain.TaskFilter{Statuses: []domain.TaskStatus{domain.StatusInProgress, domain.StatusBlocked}}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.ElementsMatch(t, []string{inProgress.ID, blocked.ID}, []string{tasks[0].ID, tasks[1].ID})
+
+		inProgress.SetStatus(domain.StatusDone)
+		updated, err := repo.UpdateTask(ctx, inProgress, nil)
+		require.NoError(t, err)
+		assert.True(t, updated.Completed)
+	})
+
+	t.Run("Dependencies", func(t *testing.T) {
+		var depListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Release").Scan(&depListID)
+		require.NoError(t, err)
+
+		build, err := repo.CreateTask(ctx, domain.Task{ListID: depListID, Text: "Build"})
+		require.NoError(t, err)
+		test, err := repo.CreateTask(ctx, domain.Task{ListID: depListID, Text: "Test"})
+		require.NoError(t, err)
+		release, err := repo.CreateTask(ctx, domain.Task{ListID: depListID, Text: "Release"})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.AddDependency(ctx, release.ID, build.ID))
+		require.NoError(t, repo.AddDependency(ctx, release.ID, test.ID))
+		// Повторное добавление не считается ошибкой
+		require.NoError(t, repo.AddDependency(ctx, release.ID, test.ID))
+		assert.ErrorIs(t, repo.AddDependency(ctx, release.ID, "00000000-0000-0000-0000-000000000000"), ErrNotFound)
+
+		blockers, err := repo.ListBlockers(ctx, release.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{build.ID, test.ID}, []string{blockers[0].ID, blockers[1].ID})
+
+		// Проверка выполняется в транзакции изменения и может его отменить
+		errBlocked := errors.New("blocked")
+		completing := release
+		completing.SetStatus(domain.StatusDone)
+		_, err = repo.UpdateTask(ctx, completing, func(ctx context.Context, before domain.Task, tasks storage.TaskReader) error {
+			assert.False(t, before.Completed)
+			blockers, err := tasks.ListBlockers(ctx, before.ID)
+			if err != nil {
+				return err
+			}
+			assert.Len(t, blockers, 2)
+			descendants, err := tasks.ListDescendants(ctx, before.ID)
+			if err != nil {
+				return err
+			}
+			assert.Empty(t, descendants)
+			return errBlocked
+		})
+		assert.ErrorIs(t, err, errBlocked)
+		unchanged, err := repo.GetByIDTask(ctx, release.ID)
+		require.NoError(t, err)
+		assert.False(t, unchanged.Completed)
+
+		blocked := true
+		tasks, total, err := repo.ListTasks(ctx, depListID, domain.TaskFilter{Blocked: &blocked}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, release.ID, tasks[0].ID)
+
+		// Выполненная блокирующая задача и задача в корзине больше не блокируют
+		build.SetStatus(domain.StatusDone)
+		_, err = repo.UpdateTask(ctx, build, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteTask(ctx, test.ID))
+
+		_, total, err = repo.ListTasks(ctx, depListID, domain.TaskFilter{Blocked: &blocked}, 10, 0)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+
+		notBlocked := false
+		_, total, err = repo.ListTasks(ctx, depListID, domain.TaskFilter{Blocked: &notBlocked}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		blockers, err = repo.ListBlockers(ctx, release.ID)
+		require.NoError(t, err)
+		assert.Len(t, blockers, 1)
+		blockerIDs, err := repo.ListBlockerIDs(ctx, release.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{build.ID, test.ID}, blockerIDs)
+
+		require.NoError(t, repo.RemoveDependency(ctx, release.ID, build.ID))
+		assert.ErrorIs(t, repo.RemoveDependency(ctx, release.ID, build.ID), ErrNotFound)
+	})
+
+	t.Run("Manual Order", func(t *testing.T) {
+		var orderListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Ordered").Scan(&orderListID)
+		require.NoError(t, err)
+
+		first, err := repo.CreateTask(ctx, domain.Task{ListID: orderListID, Text: "First"})
+		require.NoError(t, err)
+		second, err := repo.CreateTask(ctx, domain.Task{ListID: orderListID, Text: "Second"})
+		require.NoError(t, err)
+		third, err := repo.CreateTask(ctx, domain.Task{ListID: orderListID, Text: "Third"})
+		require.NoError(t, err)
+		assert.Less(t, first.Position, second.Position)
+		assert.Less(t, second.Position, third.Position)
+
+		next, err := repo.NextPosition(ctx, orderListID, first.Position, third.ID)
+		require.NoError(t, err)
+		assert.Equal(t, second.Position, next)
+
+		// Ставим третью задачу между первой и второй
+		position, err := rank.Between(first.Position, second.Position)
+		require.NoError(t, err)
+		_, err = repo.SetPosition(ctx, third.ID, position)
+		require.NoError(t, err)
+
+		sort := domain.TaskSort{Field: domain.TaskSortPosition}
+		tasks, _, err := repo.ListTasks(ctx, orderListID, domain.TaskFilter{Sort: []domain.TaskSort{sort}}, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 3)
+		assert.Equal(t, []string{first.ID, third.ID, second.ID}, []string{tasks[0].ID, tasks[1].ID, tasks[2].ID})
+	})
+
+	t.Run("Concurrent Create Positions", func(t *testing.T) {
+		var positionListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Positions").Scan(&positionListID)
+		require.NoError(t, err)
+
+		// Задача в корзине не влияет на позицию новых задач
+		trashed, err := repo.CreateTask(ctx, domain.Task{ListID: positionListID, Text: "Trashed", Position: "zzz"})
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteTask(ctx, trashed.ID))
+		last, err := repo.LastPosition(ctx, positionListID)
+		require.NoError(t, err)
+		assert.Empty(t, last)
+
+		const count = 10
+		var wg sync.WaitGroup
+		created := make([]domain.Task, count)
+		errs := make([]error, count)
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				created[i], errs[i] = repo.CreateTask(ctx, domain.Task{ListID: positionListID, Text: fmt.Sprintf("Task %d", i)})
+			}(i)
+		}
+		wg.Wait()
+
+		positions := make(map[string]bool, count)
+		for i := 0; i < count; i++ {
+			require.NoError(t, errs[i])
+			positions[created[i].Position] = true
+		}
+		assert.Len(t, positions, count)
+		first, err := rank.After("")
+		require.NoError(t, err)
+		assert.True(t, positions[first], "first task goes to the start of an empty list")
+
+		_, err = repo.CreateTask(ctx, domain.Task{ListID: "00000000-0000-0000-0000-000000000000", Text: "Orphan"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Move and Copy Between Lists", func(t *testing.T) {
+		var targetListID string
+		err := pool.QueryRow(ctx, "INSERT INTO lists (id, title) VALUES (gen_random_uuid(), $1) RETURNING id", "Target").Scan(&targetListID)
+		require.NoError(t, err)
+
+		source, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Source", Priority: domain.PriorityHigh})
+		require.NoError(t, err)
+		source.SetStatus(domain.StatusDone)
+		_, err = repo.UpdateTask(ctx, source, nil)
+		require.NoError(t, err)
+
+		var tagID string
+		err = pool.QueryRow(ctx, "INSERT INTO tags (id, name) VALUES (gen_random_uuid(), $1) RETURNING id", "copied").Scan(&tagID)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2)", source.ID, tagID)
+		require.NoError(t, err)
+
+		copies, err := repo.CopyTasks(ctx, []domain.TaskCopy{{
+			SourceID: source.ID,
+			Task:     domain.Task{ListID: targetListID, Text: source.Text, Priority: source.Priority, Position: "a"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, copies, 1)
+		assert.NotEqual(t, source.ID, copies[0].ID)
+		assert.Equal(t, targetListID, copies[0].ListID)
+		assert.False(t, copies[0].Completed)
+
+		var copiedTags int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM task_tags WHERE task_id = $1 AND tag_id = $2", copies[0].ID, tagID).Scan(&copiedTags)
+		require.NoError(t, err)
+		assert.Equal(t, 1, copiedTags)
+
+		last, err := repo.LastPosition(ctx, targetListID)
+		require.NoError(t, err)
+		assert.Equal(t, "a", last)
+
+		moved, err := repo.MoveTasks(ctx, []domain.TaskMove{{ID: source.ID, ListID: targetListID, Position: "b", ResetCompleted: true}})
+		require.NoError(t, err)
+		require.Len(t, moved, 1)
+		assert.Equal(t, targetListID, moved[0].ListID)
+		assert.Equal(t, "b", moved[0].Position)
+		assert.False(t, moved[0].Completed)
+
+		_, err = repo.MoveTasks(ctx, []domain.TaskMove{{ID: "00000000-0000-0000-0000-000000000000", ListID: targetListID, Position: "c"}})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Complete Recurring Task", func(t *testing.T) {
+		rule := "FREQ=DAILY"
+		due := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		task, err := repo.CreateTask(ctx, domain.Task{ListID: listID, Text: "Daily", DueAt: &due, RecurrenceRule: &rule})
+		require.NoError(t, err)
+		require.NotNil(t, task.RecurrenceRule)
+		assert.Equal(t, rule, *task.RecurrenceRule)
+
+		var tagID string
+		err = pool.QueryRow(ctx, "INSERT INTO tags (id, name) VALUES (gen_random_uuid(), $1) RETURNING id", "recurring").Scan(&tagID)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2)", task.ID, tagID)
+		require.NoError(t, err)
+
+		task.SetStatus(domain.StatusDone)
+		task.RecurrenceRule = nil
+		nextDue := due.AddDate(0, 0, 1)
+		updated, next, err := repo.CompleteRecurringTask(ctx, task, domain.Task{
+			ListID:         listID,
+			Text:           task.Text,
+			Priority:       domain.PriorityNone,
+			DueAt:          &nextDue,
+			Position:       "zz",
+			RecurrenceRule: &rule,
+		}, false, nil)
+		require.NoError(t, err)
+		assert.True(t, updated.Completed)
+		assert.Nil(t, updated.RecurrenceRule)
+		assert.False(t, next.Completed)
+		assert.True(t, nextDue.Equal(*next.DueAt))
+
+		var tags int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM task_tags WHERE task_id = $1", next.ID).Scan(&tags)
+		require.NoError(t, err)
+		assert.Equal(t, 1, tags)
+
+		// Повторное завершение (параллельный запрос с тем же состоянием задачи)
+		// не создает второе повторение
+		again, duplicate, err := repo.CompleteRecurringTask(ctx, task, domain.Task{
+			ListID:         listID,
+			Text:           task.Text,
+			DueAt:          &nextDue,
+			Position:       "zzz",
+			RecurrenceRule: &rule,
+		}, false, nil)
+		require.NoError(t, err)
+		assert.True(t, again.Completed)
+		assert.Empty(t, duplicate.ID)
+
+		var occurrences int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE list_id = $1 AND text = $2", listID, task.Text).Scan(&occurrences)
+		require.NoError(t, err)
+		assert.Equal(t, 2, occurrences)
+
+		// Даже с заново заданным правилом у задачи остается одно следующее повторение
+		task.SetStatus(domain.StatusTodo)
+		task.RecurrenceRule = &rule
+		_, err = repo.UpdateTask(ctx, task, nil)
+		require.NoError(t, err)
+		task.SetStatus(domain.StatusDone)
+		task.RecurrenceRule = nil
+		_, _, err = repo.CompleteRecurringTask(ctx, task, domain.Task{ListID: listID, Text: task.Text, DueAt: &nextDue, Position: "zzzz"}, false, nil)
+		assert.ErrorIs(t, err, ErrAlreadyExists)
+	})
+
 	t.Run("Delete Task", func(t *testing.T) {
-		task, _ := repo.CreateTask(domain.Task{
+		task, _ := repo.CreateTask(ctx, domain.Task{
 			ListID: listID,
 			Text:   "To delete",
 		})
 
-		err := repo.DeleteTask(task.ID)
+		err := repo.DeleteTask(ctx, task.ID)
 		require.NoError(t, err)
 
-		_, err = repo.GetByIDTask(task.ID)
+		_, err = repo.GetByIDTask(ctx, task.ID)
 		assert.Error(t, err)
 	})
 }
+
+func taskIDs(tasks []domain.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
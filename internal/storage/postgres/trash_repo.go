@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// trashItemsQuery выбирает объекты, удаленные самостоятельно. Задачи, удаленные
+// вместе со списком или родительской задачей, восстанавливаются вместе с ними
+// и в корзине отдельно не показываются. owner_list_id — список, участие в котором
+// дает доступ к объекту: сам список или список задачи.
+const trashItemsQuery = `
+	SELECT 'list' AS type, id, title, NULL::uuid AS list_id, deleted_at, workspace_id, id AS owner_list_id
+	FROM lists
+	WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'task', t.id, t.text, t.list_id, t.deleted_at, t.workspace_id, t.list_id
+	FROM tasks t
+	JOIN lists l ON l.id = t.list_id
+	LEFT JOIN tasks p ON p.id = t.parent_task_id
+	WHERE t.deleted_at IS NOT NULL
+		AND l.deleted_at IS DISTINCT FROM t.deleted_at
+		AND p.deleted_at IS DISTINCT FROM t.deleted_at
+`
+
+type TrashRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTrashRepo(pool *pgxpool.Pool) *TrashRepo {
+	return &TrashRepo{
+		pool: pool,
+	}
+}
+
+// List получает содержимое корзины рабочего пространства запроса, начиная с недавно удаленного.
+// Пустой filter.Type означает списки и задачи вместе.
+func (r *TrashRepo) List(ctx context.Context, filter domain.TrashFilter, limit, offset int) ([]domain.TrashItem, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	where := "workspace_id = $1"
+	args := []any{requestctx.Workspace(ctx)}
+	if filter.Type != "" {
+		args = append(args, string(filter.Type))
+		where += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+	if filter.MemberID != "" {
+		args = append(args, filter.MemberID)
+		where += " AND " + memberListsCondition("owner_list_id", len(args))
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) trash WHERE %s`, trashItemsQuery, where)
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count trash: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT type, id, title, list_id, deleted_at
+		FROM (%s) trash
+		WHERE %s
+		ORDER BY deleted_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, trashItemsQuery, where, len(args)+1, len(args)+2)
+	rows, err := r.pool.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]domain.TrashItem, 0)
+	for rows.Next() {
+		var item domain.TrashItem
+		if err := rows.Scan(&item.Type, &item.ID, &item.Title, &item.ListID, &item.DeletedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// Purge окончательно удаляет списки и задачи, попавшие в корзину раньше before.
+// Возвращает количество удаленных строк.
+func (r *TrashRepo) Purge(before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tasks, err := tx.Exec(ctx, `DELETE FROM tasks WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge tasks: %w", err)
+	}
+
+	lists, err := tx.Exec(ctx, `DELETE FROM lists WHERE deleted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge lists: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return int(tasks.RowsAffected() + lists.RowsAffected()), nil
+}
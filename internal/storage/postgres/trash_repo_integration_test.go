@@ -0,0 +1,150 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	trashRepo := NewTrashRepo(pool)
+	ctx := context.Background()
+
+	t.Run("Delete and Restore List", func(t *testing.T) {
+		list, err := listRepo.Create(ctx, "В корзину", "")
+		require.NoError(t, err)
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Задача списка"})
+		require.NoError(t, err)
+
+		require.NoError(t, listRepo.Delete(ctx, list.ID))
+
+		_, err = listRepo.GetByID(ctx, list.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = taskRepo.GetByIDTask(ctx, task.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, listRepo.Delete(ctx, list.ID), ErrNotFound)
+
+		// Задачи, удаленные вместе со списком, в корзине отдельно не показываются
+		items, _, err := trashRepo.List(ctx, domain.TrashFilter{}, 100, 0)
+		require.NoError(t, err)
+		i := indexOfTrashItem(items, list.ID)
+		require.NotEqual(t, -1, i)
+		assert.Equal(t, domain.TrashItemList, items[i].Type)
+		assert.Equal(t, list.Title, items[i].Title)
+		assert.Equal(t, -1, indexOfTrashItem(items, task.ID))
+
+		restored, err := listRepo.Restore(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, list.ID, restored.ID)
+		_, err = taskRepo.GetByIDTask(ctx, task.ID)
+		assert.NoError(t, err)
+
+		_, err = listRepo.Restore(ctx, list.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Delete and Restore Task Subtree", func(t *testing.T) {
+		list, err := listRepo.Create(ctx, "Поддеревья", "")
+		require.NoError(t, err)
+		parent, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Родитель"})
+		require.NoError(t, err)
+		child, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, ParentTaskID: &parent.ID, Text: "Подзадача"})
+		require.NoError(t, err)
+
+		require.NoError(t, taskRepo.DeleteTask(ctx, parent.ID))
+
+		tasks, total, err := taskRepo.ListTasks(ctx, list.ID, domain.TaskFilter{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, tasks)
+
+		items, _, err := trashRepo.List(ctx, domain.TrashFilter{Type: domain.TrashItemTask}, 100, 0)
+		require.NoError(t, err)
+		assert.NotEqual(t, -1, indexOfTrashItem(items, parent.ID))
+		assert.Equal(t, -1, indexOfTrashItem(items, child.ID))
+
+		deleted, err := taskRepo.GetDeletedTask(ctx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, *deleted.ParentTaskID)
+
+		// Подзадача восстанавливается отдельно от удаленного родителя на верхнем уровне
+		restored, err := taskRepo.RestoreTask(ctx, child.ID, true)
+		require.NoError(t, err)
+		assert.Nil(t, restored.ParentTaskID)
+
+		_, err = taskRepo.RestoreTask(ctx, parent.ID, false)
+		require.NoError(t, err)
+		_, err = taskRepo.GetByIDTask(ctx, parent.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Member Filter", func(t *testing.T) {
+		alice, err := NewUserRepo(pool).Create(ctx, domain.User{Name: "Алиса", Email: "alice.trash@example.com"})
+		require.NoError(t, err)
+		aliceCtx := requestctx.WithPrincipal(ctx, domain.Principal{Type: domain.PrincipalUser, ID: alice.ID})
+
+		own, err := listRepo.Create(aliceCtx, "Корзина Алисы", "")
+		require.NoError(t, err)
+		ownTask, err := taskRepo.CreateTask(ctx, domain.Task{ListID: own.ID, Text: "Задача Алисы"})
+		require.NoError(t, err)
+		foreign, err := listRepo.Create(ctx, "Чужая корзина", "")
+		require.NoError(t, err)
+		foreignTask, err := taskRepo.CreateTask(ctx, domain.Task{ListID: foreign.ID, Text: "Чужая задача"})
+		require.NoError(t, err)
+
+		require.NoError(t, taskRepo.DeleteTask(ctx, ownTask.ID))
+		require.NoError(t, taskRepo.DeleteTask(ctx, foreignTask.ID))
+		require.NoError(t, listRepo.Delete(ctx, foreign.ID))
+
+		// Пользователь видит в корзине только объекты своих списков
+		items, total, err := trashRepo.List(ctx, domain.TrashFilter{MemberID: alice.ID}, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.NotEqual(t, -1, indexOfTrashItem(items, ownTask.ID))
+		assert.Equal(t, -1, indexOfTrashItem(items, foreign.ID))
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		list, err := listRepo.Create(ctx, "Очистка", "")
+		require.NoError(t, err)
+		task, err := taskRepo.CreateTask(ctx, domain.Task{ListID: list.ID, Text: "Старая задача"})
+		require.NoError(t, err)
+		require.NoError(t, taskRepo.DeleteTask(ctx, task.ID))
+
+		// До срока хранения задача остается в корзине
+		_, err = trashRepo.Purge(time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		_, err = taskRepo.GetDeletedTask(ctx, task.ID)
+		require.NoError(t, err)
+
+		purged, err := trashRepo.Purge(time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, purged, 1)
+		_, err = taskRepo.GetDeletedTask(ctx, task.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func indexOfTrashItem(items []domain.TrashItem, id string) int {
+	for i, item := range items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// userColumns — колонки пользователя в порядке, ожидаемом scanUser
+const userColumns = "id, name, email, created_at, updated_at, COALESCE(password_hash, '')"
+
+// userWorkspaceCondition оставляет пользователей, состоящих в рабочем пространстве $1
+const userWorkspaceCondition = "EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.user_id = users.id AND wm.workspace_id = $1)"
+
+type UserRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
+	return &UserRepo{
+		pool: pool,
+	}
+}
+
+func scanUser(row pgx.Row, user *domain.User) error {
+	return row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.PasswordHash,
+	)
+}
+
+// Create создает пользователя и добавляет его в рабочее пространство запроса.
+// Занятый email возвращает ErrAlreadyExists
+func (r *UserRepo) Create(ctx context.Context, user domain.User) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO users (id, name, email, password_hash)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING ` + userColumns
+
+	var created domain.User
+	if err := scanUser(tx.QueryRow(ctx, query, uuid.New(), user.Name, user.Email, user.PasswordHash), &created); err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return domain.User{}, ErrAlreadyExists
+		}
+		return domain.User{}, fmt.Errorf("create user: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO workspace_members (workspace_id, user_id) VALUES ($1, $2)`,
+		requestctx.Workspace(ctx), created.ID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("add workspace member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.User{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetByID получает пользователя рабочего пространства запроса по ID
+func (r *UserRepo) GetByID(ctx context.Context, id string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $2 AND ` + userWorkspaceCondition
+
+	var user domain.User
+	if err := scanUser(r.pool.QueryRow(ctx, query, requestctx.Workspace(ctx), id), &user); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.User{}, ErrNotFound
+		}
+		return domain.User{}, fmt.Errorf("get user by id: %w", err)
+	}
+
+	return user, nil
+}
+
+// List получает пользователей рабочего пространства запроса с пагинацией, упорядоченных по имени
+func (r *UserRepo) List(ctx context.Context, limit, offset int) ([]domain.User, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	workspaceID := requestctx.Workspace(ctx)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE `+userWorkspaceCondition, workspaceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count users: %w", err)
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE ` + userWorkspaceCondition + `
+		ORDER BY lower(name), id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, workspaceID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		var user domain.User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, 0, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetByEmail получает пользователя по email без учета регистра
+func (r *UserRepo) GetByEmail(email string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user domain.User
+	if err := scanUser(r.pool.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE lower(email) = lower($1)`, email), &user); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.User{}, ErrNotFound
+		}
+		return domain.User{}, fmt.Errorf("get user by email: %w", err)
+	}
+
+	return user, nil
+}
+
+// Update обновляет имя, email и пароль пользователя рабочего пространства запроса
+func (r *UserRepo) Update(ctx context.Context, user domain.User) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE users
+		SET name = $3, email = $4, password_hash = NULLIF($5, ''), updated_at = NOW()
+		WHERE id = $2 AND ` + userWorkspaceCondition + `
+		RETURNING ` + userColumns
+
+	var updated domain.User
+	if err := scanUser(r.pool.QueryRow(ctx, query, requestctx.Workspace(ctx), user.ID, user.Name, user.Email, user.PasswordHash), &updated); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.User{}, ErrNotFound
+		}
+		if isPgError(err, pgUniqueViolation) {
+			return domain.User{}, ErrAlreadyExists
+		}
+		return domain.User{}, fmt.Errorf("update user: %w", err)
+	}
+
+	return updated, nil
+}
+
+// Delete удаляет пользователя рабочего пространства запроса. Назначенные ему задачи остаются без исполнителя
+func (r *UserRepo) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM users WHERE id = $2 AND `+userWorkspaceCondition, requestctx.Workspace(ctx), id)
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	repo := NewUserRepo(pool)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	ctx := context.Background()
+
+	t.Run("CRUD", func(t *testing.T) {
+		user, err := repo.Create(ctx, domain.User{Name: "Алиса", Email: "alice@example.com"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, user.ID)
+
+		// Email уникален без учета регистра
+		_, err = repo.Create(ctx, domain.User{Name: "Другая Алиса", Email: "Alice@Example.com"})
+		assert.ErrorIs(t, err, ErrAlreadyExists)
+
+		user.Name = "Алиса Петрова"
+		updated, err := repo.Update(ctx, user)
+		require.NoError(t, err)
+		assert.Equal(t, "Алиса Петрова", updated.Name)
+
+		fetched, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, updated, fetched)
+
+		users, total, err := repo.List(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, user.ID, users[0].ID)
+
+		require.NoError(t, repo.Delete(ctx, user.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID), ErrNotFound)
+		_, err = repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Assigned Tasks", func(t *testing.T) {
+		bob, err := repo.Create(ctx, domain.User{Name: "Боб", Email: "bob@example.com"})
+		require.NoError(t, err)
+
+		home, err := listRepo.Create(ctx, "Дом", "")
+		require.NoError(t, err)
+		work, err := listRepo.Create(ctx, "Работа", "")
+		require.NoError(t, err)
+
+		dishes, err := taskRepo.CreateTask(ctx, domain.Task{ListID: home.ID, Text: "Помыть посуду", AssigneeID: &bob.ID})
+		require.NoError(t, err)
+		assert.Equal(t, &bob.ID, dishes.AssigneeID)
+		report, err := taskRepo.CreateTask(ctx, domain.Task{ListID: work.ID, Text: "Отчет"})
+		require.NoError(t, err)
+
+		report.AssigneeID = &bob.ID
+		report.SetStatus(domain.StatusDone)
+		_, err = taskRepo.UpdateTask(ctx, report, nil)
+		require.NoError(t, err)
+
+		tasks, total, err := taskRepo.ListAssignedTasks(ctx, bob.ID, domain.TaskFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.ElementsMatch(t, []string{dishes.ID, report.ID}, []string{tasks[0].ID, tasks[1].ID})
+
+		tasks, total, err = taskRepo.ListAssignedTasks(ctx, bob.ID, domain.TaskFilter{Statuses: []domain.TaskStatus{domain.StatusDone}}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, report.ID, tasks[0].ID)
+
+		// Задачи удаленного пользователя остаются без исполнителя
+		require.NoError(t, repo.Delete(ctx, bob.ID))
+		fetched, err := taskRepo.GetByIDTask(ctx, dishes.ID)
+		require.NoError(t, err)
+		assert.Nil(t, fetched.AssigneeID)
+	})
+
+	t.Run("Password And Refresh Tokens", func(t *testing.T) {
+		tokenRepo := NewRefreshTokenRepo(pool)
+
+		carol, err := repo.Create(ctx, domain.User{Name: "Кэрол", Email: "carol@example.com", PasswordHash: "$2a$10$hash"})
+		require.NoError(t, err)
+
+		// Поиск по email без учета регистра возвращает хэш пароля
+		fetched, err := repo.GetByEmail("CAROL@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, carol.ID, fetched.ID)
+		assert.Equal(t, "$2a$10$hash", fetched.PasswordHash)
+		_, err = repo.GetByEmail("nobody@example.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		first, err := tokenRepo.Create(ctx, domain.RefreshToken{UserID: carol.ID, ExpiresAt: time.Now().Add(time.Hour)}, strings.Repeat("a", 64))
+		require.NoError(t, err)
+		_, err = tokenRepo.Create(ctx, domain.RefreshToken{UserID: carol.ID, ExpiresAt: time.Now().Add(time.Hour)}, strings.Repeat("b", 64))
+		require.NoError(t, err)
+
+		token, err := tokenRepo.GetByHash(strings.Repeat("a", 64))
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, token.ID)
+		assert.Nil(t, token.RevokedAt)
+
+		// Отозвать токен можно только один раз
+		require.NoError(t, tokenRepo.Revoke(ctx, first.ID))
+		assert.ErrorIs(t, tokenRepo.Revoke(ctx, first.ID), ErrNotFound)
+
+		require.NoError(t, tokenRepo.RevokeAllForUser(ctx, carol.ID))
+		token, err = tokenRepo.GetByHash(strings.Repeat("b", 64))
+		require.NoError(t, err)
+		assert.NotNil(t, token.RevokedAt)
+
+		// Токены удаляются вместе с пользователем
+		require.NoError(t, repo.Delete(ctx, carol.ID))
+		_, err = tokenRepo.GetByHash(strings.Repeat("b", 64))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Workspace", func(t *testing.T) {
+		workspaceRepo := NewWorkspaceRepo(pool)
+		team, err := workspaceRepo.Create(ctx, "Команда пользователей")
+		require.NoError(t, err)
+		teamCtx := requestctx.WithWorkspace(ctx, team.ID)
+
+		dave, err := repo.Create(ctx, domain.User{Name: "Дейв", Email: "dave@example.com"})
+		require.NoError(t, err)
+		erin, err := repo.Create(teamCtx, domain.User{Name: "Эрин", Email: "erin@example.com"})
+		require.NoError(t, err)
+
+		// Пользователи чужого рабочего пространства не видны и не меняются
+		_, err = repo.GetByID(teamCtx, dave.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		users, total, err := repo.List(teamCtx, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, erin.ID, users[0].ID)
+		_, err = repo.Update(teamCtx, dave)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(teamCtx, dave.ID), ErrNotFound)
+
+		// Участником списка может стать только пользователь рабочего пространства
+		list, err := listRepo.Create(teamCtx, "Список команды", "")
+		require.NoError(t, err)
+		_, err = listRepo.SetMember(teamCtx, domain.ListMember{ListID: list.ID, UserID: dave.ID, Role: domain.RoleViewer})
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = listRepo.SetMember(teamCtx, domain.ListMember{ListID: list.ID, UserID: erin.ID, Role: domain.RoleViewer})
+		require.NoError(t, err)
+
+		// После добавления в рабочее пространство пользователь становится виден
+		require.NoError(t, workspaceRepo.AddMember(ctx, team.ID, dave.ID))
+		fetched, err := repo.GetByID(teamCtx, dave.ID)
+		require.NoError(t, err)
+		assert.Equal(t, dave.ID, fetched.ID)
+	})
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkspaceRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewWorkspaceRepo(pool *pgxpool.Pool) *WorkspaceRepo {
+	return &WorkspaceRepo{
+		pool: pool,
+	}
+}
+
+// Create создает рабочее пространство
+func (r *WorkspaceRepo) Create(ctx context.Context, name string) (domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT INTO workspaces (id, name) VALUES ($1, $2) RETURNING id, name, created_at`
+
+	var workspace domain.Workspace
+	if err := r.pool.QueryRow(ctx, query, uuid.New(), name).Scan(&workspace.ID, &workspace.Name, &workspace.CreatedAt); err != nil {
+		return domain.Workspace{}, fmt.Errorf("create workspace: %w", err)
+	}
+
+	return workspace, nil
+}
+
+// GetByID получает рабочее пространство по ID
+func (r *WorkspaceRepo) GetByID(id string) (domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var workspace domain.Workspace
+	err := r.pool.QueryRow(ctx, `SELECT id, name, created_at FROM workspaces WHERE id = $1`, id).
+		Scan(&workspace.ID, &workspace.Name, &workspace.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Workspace{}, ErrNotFound
+		}
+		return domain.Workspace{}, fmt.Errorf("get workspace by id: %w", err)
+	}
+
+	return workspace, nil
+}
+
+// List получает рабочие пространства с пагинацией в порядке создания
+func (r *WorkspaceRepo) List(limit, offset int) ([]domain.Workspace, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM workspaces`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count workspaces: %w", err)
+	}
+
+	query := `
+		SELECT id, name, created_at
+		FROM workspaces
+		ORDER BY created_at, id
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.pool.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := make([]domain.Workspace, 0)
+	for rows.Next() {
+		var workspace domain.Workspace
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &workspace.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan workspace: %w", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return workspaces, total, nil
+}
+
+// AddMember добавляет пользователя в рабочее пространство. Повторное добавление не ошибка.
+// Несуществующее рабочее пространство или пользователь — ErrNotFound
+func (r *WorkspaceRepo) AddMember(ctx context.Context, workspaceID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO workspace_members (workspace_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (workspace_id, user_id) DO NOTHING`
+	if _, err := r.pool.Exec(ctx, query, workspaceID, userID); err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("add workspace member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMember исключает пользователя из рабочего пространства
+func (r *WorkspaceRepo) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, workspaceID, userID)
+	if err != nil {
+		return fmt.Errorf("remove workspace member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// HasMember проверяет, состоит ли пользователь в рабочем пространстве
+func (r *WorkspaceRepo) HasMember(ctx context.Context, workspaceID, userID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = $1 AND user_id = $2)`
+	if err := r.pool.QueryRow(ctx, query, workspaceID, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check workspace member: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,118 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"RestApi/internal/domain"
+	"RestApi/internal/requestctx"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceRepo_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	pool := setupTestDatabase(t)
+	repo := NewWorkspaceRepo(pool)
+	listRepo := NewListRepo(pool)
+	taskRepo := NewTaskRepo(pool)
+	trashRepo := NewTrashRepo(pool)
+	userRepo := NewUserRepo(pool)
+	ctx := context.Background()
+
+	t.Run("CRUD", func(t *testing.T) {
+		workspace, err := repo.Create(ctx, "Команда А")
+		require.NoError(t, err)
+		assert.NotEmpty(t, workspace.ID)
+
+		fetched, err := repo.GetByID(workspace.ID)
+		require.NoError(t, err)
+		assert.Equal(t, workspace.Name, fetched.Name)
+
+		// Рабочее пространство по умолчанию создается миграцией
+		workspaces, total, err := repo.List(10, 0)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, total, 2)
+		assert.Equal(t, domain.DefaultWorkspaceID, workspaces[0].ID)
+
+		_, err = repo.GetByID("11111111-2222-4333-8444-555555555555")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		workspace, err := repo.Create(ctx, "Участники")
+		require.NoError(t, err)
+		user, err := userRepo.Create(ctx, domain.User{Name: "Участник", Email: "workspace-member@example.com"})
+		require.NoError(t, err)
+
+		// Новый пользователь состоит в рабочем пространстве запроса
+		member, err := repo.HasMember(ctx, domain.DefaultWorkspaceID, user.ID)
+		require.NoError(t, err)
+		assert.True(t, member)
+		member, err = repo.HasMember(ctx, workspace.ID, user.ID)
+		require.NoError(t, err)
+		assert.False(t, member)
+
+		require.NoError(t, repo.AddMember(ctx, workspace.ID, user.ID))
+		require.NoError(t, repo.AddMember(ctx, workspace.ID, user.ID))
+		member, err = repo.HasMember(ctx, workspace.ID, user.ID)
+		require.NoError(t, err)
+		assert.True(t, member)
+
+		require.NoError(t, repo.RemoveMember(ctx, workspace.ID, user.ID))
+		assert.ErrorIs(t, repo.RemoveMember(ctx, workspace.ID, user.ID), ErrNotFound)
+		assert.ErrorIs(t, repo.AddMember(ctx, "11111111-2222-4333-8444-555555555555", user.ID), ErrNotFound)
+	})
+
+	t.Run("Isolation", func(t *testing.T) {
+		teamA, err := repo.Create(ctx, "Изоляция А")
+		require.NoError(t, err)
+		teamB, err := repo.Create(ctx, "Изоляция Б")
+		require.NoError(t, err)
+		ctxA := requestctx.WithWorkspace(ctx, teamA.ID)
+		ctxB := requestctx.WithWorkspace(ctx, teamB.ID)
+
+		list, err := listRepo.Create(ctxA, "Список команды А", "")
+		require.NoError(t, err)
+		task, err := taskRepo.CreateTask(ctxA, domain.Task{ListID: list.ID, Text: "Задача команды А"})
+		require.NoError(t, err)
+
+		// Чужое рабочее пространство не видит список и задачу
+		_, err = listRepo.GetByID(ctxB, list.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = taskRepo.GetByIDTask(ctxB, task.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		lists, total, err := listRepo.List(ctxB, domain.ListFilter{}, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.Empty(t, lists)
+
+		// Задачу нельзя создать в списке чужого рабочего пространства
+		_, err = taskRepo.CreateTask(ctxB, domain.Task{ListID: list.ID, Text: "Чужая задача"})
+		assert.Error(t, err)
+
+		// Изменения из чужого рабочего пространства не применяются
+		assert.ErrorIs(t, listRepo.Delete(ctxB, list.ID), ErrNotFound)
+		assert.ErrorIs(t, taskRepo.DeleteTask(ctxB, task.ID), ErrNotFound)
+
+		// Корзина тоже разделена по рабочим пространствам
+		require.NoError(t, taskRepo.DeleteTask(ctxA, task.ID))
+		items, _, err := trashRepo.List(ctxB, domain.TrashFilter{}, 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+		items, _, err = trashRepo.List(ctxA, domain.TrashFilter{}, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, task.ID, items[0].ID)
+
+		fetched, err := listRepo.GetByID(ctxA, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, list.Title, fetched.Title)
+	})
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// RefreshTokenRepository — интерфейс для работы с токенами обновления.
+// Токены хранятся в виде хэша, как и API-ключи.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token domain.RefreshToken, tokenHash string) (domain.RefreshToken, error)
+	GetByHash(tokenHash string) (domain.RefreshToken, error)
+	// Revoke отзывает действующий токен; уже отозванный токен дает ErrNotFound
+	Revoke(ctx context.Context, id string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// SearchRepository — интерфейс полнотекстового поиска по спискам и задачам
+type SearchRepository interface {
+	// Search ищет в рабочем пространстве из контекста запроса, начиная с наиболее релевантного
+	Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.SearchResult, int, error)
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// TagRepository — интерфейс для работы с метками задач.
+// Все методы работают в рабочем пространстве из контекста запроса
+type TagRepository interface {
+	Create(ctx context.Context, name string) (domain.Tag, error)
+	GetByID(ctx context.Context, id string) (domain.Tag, error)
+	List(ctx context.Context, limit, offset int) ([]domain.Tag, int, error)
+	Update(ctx context.Context, id, name string) (domain.Tag, error)
+	Delete(ctx context.Context, id string) error
+	AttachToTask(ctx context.Context, taskID, tagID string) error
+	DetachFromTask(ctx context.Context, taskID, tagID string) error
+	ListByTask(ctx context.Context, taskID string) ([]domain.Tag, error)
+}
@@ -1,12 +1,52 @@
 package storage
 
-import "RestApi/internal/domain"
+import (
+	"context"
 
-// TaskRepository — интерфейс для работы со списками
+	"RestApi/internal/domain"
+)
+
+// TaskRepository — интерфейс для работы со списками.
+// Все методы работают в рабочем пространстве из контекста запроса
 type TaskRepository interface {
-	CreateTask(task domain.Task) (domain.Task, error)
-	GetByIDTask(id string) (domain.Task, error)
-	ListTasks(listID string, limit int, offset int) ([]domain.Task, int, error)
-	UpdateTask(id string, text string, completed bool) (domain.Task, error)
-	DeleteTask(id string) error
+	CreateTask(ctx context.Context, task domain.Task) (domain.Task, error)
+	GetByIDTask(ctx context.Context, id string) (domain.Task, error)
+	ListTasks(ctx context.Context, listID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error)
+	// ListTasksByCursor получает страницу задач списка после или перед курсором (nil — первая
+	// страница) без подсчета общего количества; more — за страницей есть еще задачи
+	ListTasksByCursor(ctx context.Context, listID string, filter domain.TaskFilter, cursor *domain.Cursor, limit int) ([]domain.Task, bool, error)
+	ListAllTasks(ctx context.Context, listID string, filter domain.TaskFilter) ([]domain.Task, error)
+	ListOverdueTasks(ctx context.Context, memberID string, limit int, offset int) ([]domain.Task, int, error)
+	ListAssignedTasks(ctx context.Context, userID string, filter domain.TaskFilter, limit int, offset int) ([]domain.Task, int, error)
+	ListSubtasks(ctx context.Context, parentID string) ([]domain.Task, error)
+	ListDescendants(ctx context.Context, id string) ([]domain.Task, error)
+	// UpdateTask, UpdateTaskWithSubtasks и CompleteRecurringTask вызывают check (если задан)
+	// в транзакции изменения после блокировки задачи; ошибка check отменяет изменение
+	UpdateTask(ctx context.Context, task domain.Task, check TaskCheck) (domain.Task, error)
+	UpdateTaskWithSubtasks(ctx context.Context, task domain.Task, check TaskCheck) (domain.Task, error)
+	CompleteRecurringTask(ctx context.Context, task domain.Task, next domain.Task, cascade bool, check TaskCheck) (domain.Task, domain.Task, error)
+	NextPosition(ctx context.Context, listID, position, excludeID string) (string, error)
+	PrevPosition(ctx context.Context, listID, position, excludeID string) (string, error)
+	LastPosition(ctx context.Context, listID string) (string, error)
+	SetPosition(ctx context.Context, id, position string) (domain.Task, error)
+	MoveTasks(ctx context.Context, moves []domain.TaskMove) ([]domain.Task, error)
+	CopyTasks(ctx context.Context, copies []domain.TaskCopy) ([]domain.Task, error)
+	DeleteTask(ctx context.Context, id string) error
+	GetDeletedTask(ctx context.Context, id string) (domain.Task, error)
+	RestoreTask(ctx context.Context, id string, detachParent bool) (domain.Task, error)
+	AddDependency(ctx context.Context, taskID, blockerID string) error
+	RemoveDependency(ctx context.Context, taskID, blockerID string) error
+	ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error)
+	ListBlockerIDs(ctx context.Context, taskID string) ([]string, error)
+}
+
+// TaskReader читает подзадачи и блокирующие задачи в транзакции изменения задачи.
+// Прочитанные задачи заблокированы до конца транзакции
+type TaskReader interface {
+	ListDescendants(ctx context.Context, id string) ([]domain.Task, error)
+	ListBlockers(ctx context.Context, taskID string) ([]domain.Task, error)
 }
+
+// TaskCheck проверяет изменение задачи по ее состоянию в транзакции:
+// before — задача до изменения, tasks читает связанные задачи в той же транзакции
+type TaskCheck func(ctx context.Context, before domain.Task, tasks TaskReader) error
@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"RestApi/internal/domain"
+)
+
+// TrashRepository — интерфейс для работы с корзиной удаленных списков и задач
+type TrashRepository interface {
+	// List получает содержимое корзины рабочего пространства из контекста запроса
+	List(ctx context.Context, filter domain.TrashFilter, limit, offset int) ([]domain.TrashItem, int, error)
+	Purge(before time.Time) (int, error)
+}
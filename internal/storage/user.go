@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// UserRepository — интерфейс для работы с пользователями
+type UserRepository interface {
+	Create(ctx context.Context, user domain.User) (domain.User, error)
+	GetByID(ctx context.Context, id string) (domain.User, error)
+	GetByEmail(email string) (domain.User, error)
+	List(ctx context.Context, limit, offset int) ([]domain.User, int, error)
+	Update(ctx context.Context, user domain.User) (domain.User, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"RestApi/internal/domain"
+)
+
+// WorkspaceRepository — интерфейс для работы с рабочими пространствами
+type WorkspaceRepository interface {
+	Create(ctx context.Context, name string) (domain.Workspace, error)
+	GetByID(id string) (domain.Workspace, error)
+	List(limit, offset int) ([]domain.Workspace, int, error)
+	AddMember(ctx context.Context, workspaceID, userID string) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+	HasMember(ctx context.Context, workspaceID, userID string) (bool, error)
+}
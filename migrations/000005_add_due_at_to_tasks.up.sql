@@ -0,0 +1,7 @@
+-- Срок выполнения задачи
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP WITH TIME ZONE;
+
+-- Индекс для выборки просроченных задач
+CREATE INDEX idx_tasks_due_at ON tasks(due_at) WHERE completed = FALSE;
+
+COMMENT ON COLUMN tasks.due_at IS 'Срок выполнения задачи';
\ No newline at end of file
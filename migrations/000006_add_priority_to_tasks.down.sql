@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_list_id_priority;
+ALTER TABLE tasks DROP COLUMN priority;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Приоритет задачи
+ALTER TABLE tasks ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'none'
+    CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent'));
+
+CREATE INDEX idx_tasks_list_id_priority ON tasks(list_id, priority);
+
+COMMENT ON COLUMN tasks.priority IS 'Приоритет задачи (none, low, medium, high, urgent)';
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- Метки задач
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    name VARCHAR(50) NOT NULL CHECK (length(name) >= 1 AND length(name) <= 50),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Имена меток уникальны без учета регистра
+CREATE UNIQUE INDEX idx_tags_name_lower ON tags(lower(name));
+
+-- Связь задач и меток (многие ко многим)
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- Индекс для фильтрации задач по метке
+CREATE INDEX idx_task_tags_tag_id ON task_tags(tag_id);
+
+COMMENT ON TABLE tags IS 'Метки для категоризации задач';
+COMMENT ON COLUMN tags.name IS 'Название метки (1-50 символов, уникально без учета регистра)';
+COMMENT ON TABLE task_tags IS 'Метки, назначенные задачам';
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Родительская задача (для подзадач)
+ALTER TABLE tasks ADD COLUMN parent_task_id UUID
+    REFERENCES tasks(id) ON DELETE CASCADE
+    CHECK (parent_task_id <> id);
+
+CREATE INDEX idx_tasks_parent_task_id ON tasks(parent_task_id);
+
+COMMENT ON COLUMN tasks.parent_task_id IS 'Родительская задача в том же списке (NULL для задач верхнего уровня)';
\ No newline at end of file
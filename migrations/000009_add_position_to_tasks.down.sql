@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_list_id_position;
+ALTER TABLE tasks DROP COLUMN position;
\ No newline at end of file
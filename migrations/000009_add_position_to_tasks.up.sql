@@ -0,0 +1,18 @@
+-- Позиция задачи для ручной сортировки внутри списка.
+-- Ключи сравниваются побайтово (COLLATE "C"), как в пакете internal/rank
+ALTER TABLE tasks ADD COLUMN position TEXT COLLATE "C";
+
+-- Существующие задачи получают позиции в порядке создания
+UPDATE tasks t
+SET position = lpad(r.rn::text, 10, '0') || 'i'
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY list_id ORDER BY created_at, id) AS rn
+    FROM tasks
+) r
+WHERE t.id = r.id;
+
+ALTER TABLE tasks ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX idx_tasks_list_id_position ON tasks(list_id, position);
+
+COMMENT ON COLUMN tasks.position IS 'Лексикографический ключ ручной сортировки задач в списке';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP COLUMN recurrence_rule;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Правило повторения задачи (подмножество RRULE из RFC 5545, см. internal/rrule).
+-- При завершении повторяющейся задачи создается ее следующее повторение
+ALTER TABLE tasks ADD COLUMN recurrence_rule TEXT;
+
+COMMENT ON COLUMN tasks.recurrence_rule IS 'Правило повторения задачи в формате RRULE'
\ No newline at end of file
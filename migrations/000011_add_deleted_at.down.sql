@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+DROP INDEX IF EXISTS idx_lists_deleted_at;
+ALTER TABLE tasks DROP COLUMN deleted_at;
+ALTER TABLE lists DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Мягкое удаление: удаленные списки и задачи попадают в корзину
+-- и окончательно удаляются фоновой очисткой после срока хранения
+ALTER TABLE lists ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Индексы для выборки корзины и очистки
+CREATE INDEX idx_lists_deleted_at ON lists(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN lists.deleted_at IS 'Время перемещения списка в корзину';
+COMMENT ON COLUMN tasks.deleted_at IS 'Время перемещения задачи в корзину'
\ No newline at end of file
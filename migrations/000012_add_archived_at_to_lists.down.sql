@@ -0,0 +1 @@
+ALTER TABLE lists DROP COLUMN archived_at;
\ No newline at end of file
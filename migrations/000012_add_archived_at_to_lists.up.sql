@@ -0,0 +1,4 @@
+-- Архивные списки скрыты из выдачи по умолчанию и не принимают новые задачи
+ALTER TABLE lists ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN lists.archived_at IS 'Время архивации списка'
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_history_append_only ON history;
+DROP FUNCTION IF EXISTS history_append_only();
+DROP TABLE IF EXISTS history;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- История изменений списков и задач (только добавление записей).
+-- Записи не ссылаются на объекты и сохраняются после их окончательного удаления.
+CREATE TABLE IF NOT EXISTS history (
+    id UUID PRIMARY KEY,
+    entity_type VARCHAR(10) NOT NULL CHECK (entity_type IN ('list', 'task')),
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    actor VARCHAR(100),
+    request_id VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Индекс для выборки истории объекта от новых записей к старым
+CREATE INDEX idx_history_entity ON history(entity_type, entity_id, created_at DESC);
+
+-- Запрещаем изменение и удаление записей истории
+CREATE OR REPLACE FUNCTION history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_history_append_only
+BEFORE UPDATE OR DELETE ON history
+FOR EACH ROW EXECUTE FUNCTION history_append_only();
+
+COMMENT ON TABLE history IS 'История изменений списков и задач';
+COMMENT ON COLUMN history.changes IS 'Изменения полей: {"поле": {"from": ..., "to": ...}}';
+COMMENT ON COLUMN history.actor IS 'Автор изменения (заголовок X-Actor)';
+COMMENT ON COLUMN history.request_id IS 'Идентификатор запроса (X-Request-Id)'
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- Комментарии к задачам
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author VARCHAR(100),
+    text VARCHAR(2000) NOT NULL CHECK (length(text) >= 1),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Индекс для выборки комментариев задачи в порядке добавления
+CREATE INDEX idx_comments_task_id ON comments(task_id, created_at);
+
+COMMENT ON TABLE comments IS 'Комментарии к задачам; удаляются вместе с задачей при очистке корзины';
+COMMENT ON COLUMN comments.author IS 'Автор комментария (заголовок X-Actor)';
+COMMENT ON COLUMN comments.text IS 'Текст комментария (1-2000 символов)'
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- Метаданные файлов, прикрепленных к задачам. Содержимое хранится в blob-хранилище
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    uploaded_by VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments(task_id, created_at);
+
+-- Ключи содержимого удаленных вложений (в том числе при очистке корзины).
+-- Фоновая задача удаляет содержимое из хранилища и затем запись.
+CREATE TABLE IF NOT EXISTS attachment_blob_deletions (
+    storage_key VARCHAR(500) PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION attachments_queue_blob_deletion() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key) VALUES (OLD.storage_key)
+    ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_attachments_queue_blob_deletion
+AFTER DELETE ON attachments
+FOR EACH ROW EXECUTE FUNCTION attachments_queue_blob_deletion();
+
+COMMENT ON TABLE attachments IS 'Файлы, прикрепленные к задачам';
+COMMENT ON COLUMN attachments.storage_key IS 'Ключ содержимого в blob-хранилище';
+COMMENT ON COLUMN attachments.uploaded_by IS 'Автор загрузки (заголовок X-Actor)';
+COMMENT ON TABLE attachment_blob_deletions IS 'Очередь удаления содержимого вложений из blob-хранилища'
\ No newline at end of file
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_tasks_list_status;
+
+ALTER TABLE tasks DROP COLUMN completed;
+ALTER TABLE tasks ADD COLUMN completed BOOLEAN DEFAULT FALSE;
+
+UPDATE tasks SET completed = (status = 'done');
+
+ALTER TABLE tasks DROP COLUMN status;
+
+CREATE INDEX idx_tasks_completed ON tasks(completed);
+CREATE INDEX idx_tasks_due_at ON tasks(due_at) WHERE completed = FALSE;
+
+COMMENT ON COLUMN tasks.completed IS 'Статус выполнения задачи'
\ No newline at end of file
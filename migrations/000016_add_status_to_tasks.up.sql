@@ -0,0 +1,16 @@
+-- Статус задачи в рабочем процессе (набор статусов настраивается в приложении).
+-- completed становится производным от статуса и сохраняется для совместимости
+ALTER TABLE tasks ADD COLUMN status VARCHAR(30) NOT NULL DEFAULT 'todo' CHECK (status <> '');
+
+UPDATE tasks SET status = 'done' WHERE completed;
+
+-- Удаление колонки удаляет и индексы idx_tasks_completed, idx_tasks_due_at
+ALTER TABLE tasks DROP COLUMN completed;
+ALTER TABLE tasks ADD COLUMN completed BOOLEAN GENERATED ALWAYS AS (status = 'done') STORED;
+
+CREATE INDEX idx_tasks_completed ON tasks(completed);
+CREATE INDEX idx_tasks_due_at ON tasks(due_at) WHERE completed = FALSE;
+CREATE INDEX idx_tasks_list_status ON tasks(list_id, status) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN tasks.status IS 'Статус задачи в рабочем процессе';
+COMMENT ON COLUMN tasks.completed IS 'Задача выполнена (status = done)'
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_tasks_set_completed_at ON tasks;
+DROP FUNCTION IF EXISTS tasks_set_completed_at();
+
+ALTER TABLE tasks DROP COLUMN completed_at;
\ No newline at end of file
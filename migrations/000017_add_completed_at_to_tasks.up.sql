@@ -0,0 +1,23 @@
+-- Время выполнения задачи. Заполняется триггером при переходе в статус done
+-- и сбрасывается при выходе из него, поэтому его не нужно передавать из приложения
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+
+-- Для уже выполненных задач точное время неизвестно: берем время последнего изменения
+UPDATE tasks SET completed_at = updated_at WHERE status = 'done';
+
+CREATE OR REPLACE FUNCTION tasks_set_completed_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status <> 'done' THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status <> 'done' THEN
+        NEW.completed_at := COALESCE(NEW.completed_at, NOW());
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_tasks_set_completed_at
+BEFORE INSERT OR UPDATE OF status ON tasks
+FOR EACH ROW EXECUTE FUNCTION tasks_set_completed_at();
+
+COMMENT ON COLUMN tasks.completed_at IS 'Время перевода задачи в статус done'
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Зависимости задач: задача task_id не может быть завершена, пока не выполнена blocker_id
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocker_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id)
+);
+
+-- Индекс для поиска задач, которые блокирует задача
+CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+COMMENT ON TABLE task_dependencies IS 'Зависимости задач: task_id заблокирована задачей blocker_id';
\ No newline at end of file
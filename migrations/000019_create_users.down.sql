@@ -0,0 +1,7 @@
+ALTER TABLE tasks DROP COLUMN created_by;
+ALTER TABLE lists DROP COLUMN created_by;
+
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+ALTER TABLE tasks DROP COLUMN assignee_id;
+
+DROP TABLE IF EXISTS users;
\ No newline at end of file
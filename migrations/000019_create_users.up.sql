@@ -0,0 +1,27 @@
+-- Пользователи
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CHECK (length(name) >= 1 AND length(name) <= 100),
+    email VARCHAR(254) NOT NULL CHECK (length(email) >= 3),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Email уникален без учета регистра
+CREATE UNIQUE INDEX idx_users_email_lower ON users(lower(email));
+
+-- Исполнитель задачи: при удалении пользователя задача остается без исполнителя
+ALTER TABLE tasks ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Индекс для выборки задач исполнителя во всех списках
+CREATE INDEX idx_tasks_assignee_id ON tasks(assignee_id) WHERE assignee_id IS NOT NULL;
+
+-- Автор списка и задачи
+ALTER TABLE lists ADD COLUMN created_by VARCHAR(100);
+ALTER TABLE tasks ADD COLUMN created_by VARCHAR(100);
+
+COMMENT ON TABLE users IS 'Пользователи, которым назначаются задачи';
+COMMENT ON COLUMN users.email IS 'Email пользователя (уникален без учета регистра)';
+COMMENT ON COLUMN tasks.assignee_id IS 'Исполнитель задачи';
+COMMENT ON COLUMN lists.created_by IS 'Автор списка (заголовок X-Actor)';
+COMMENT ON COLUMN tasks.created_by IS 'Автор задачи (заголовок X-Actor)';
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- API-ключи. Хранится только SHA-256 ключа, сам ключ показывается один раз при выпуске
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CHECK (length(name) >= 1 AND length(name) <= 100),
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL CHECK (cardinality(scopes) >= 1 AND scopes <@ ARRAY['read', 'write', 'admin']),
+    created_by VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE api_keys IS 'API-ключи для доступа к API';
+COMMENT ON COLUMN api_keys.prefix IS 'Начало ключа для опознания в списке ключей';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 ключа в hex';
+COMMENT ON COLUMN api_keys.scopes IS 'Права ключа: read, write, admin';
+COMMENT ON COLUMN api_keys.revoked_at IS 'Время отзыва ключа; отозванный ключ не принимается';
\ No newline at end of file
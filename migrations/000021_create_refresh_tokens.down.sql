@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS refresh_tokens;
+
+ALTER TABLE users DROP COLUMN password_hash;
\ No newline at end of file
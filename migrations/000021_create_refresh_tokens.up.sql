@@ -0,0 +1,20 @@
+-- Хэш пароля пользователя (bcrypt); без пароля пользователь не может войти
+ALTER TABLE users ADD COLUMN password_hash TEXT;
+
+-- Токены обновления. Хранится только SHA-256 токена
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Индекс для отзыва всех токенов пользователя
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+
+COMMENT ON COLUMN users.password_hash IS 'Хэш пароля (bcrypt)';
+COMMENT ON TABLE refresh_tokens IS 'Токены обновления JWT, выданные пользователям';
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'SHA-256 токена в hex';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'Время отзыва: при выходе, обновлении или повторном использовании токена';
\ No newline at end of file
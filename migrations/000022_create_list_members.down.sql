@@ -0,0 +1 @@
+DROP TABLE IF EXISTS list_members;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Участники списков и их роли. Пользователь видит только списки, в которых состоит
+CREATE TABLE IF NOT EXISTS list_members (
+    list_id UUID NOT NULL REFERENCES lists(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+
+-- Индекс для выборки списков пользователя
+CREATE INDEX idx_list_members_user_id ON list_members(user_id);
+
+COMMENT ON TABLE list_members IS 'Участники списков';
+COMMENT ON COLUMN list_members.role IS 'Роль участника: owner — управляет списком и участниками, editor — меняет задачи, viewer — только читает';
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE refresh_tokens DROP COLUMN workspace_id;
+ALTER TABLE api_keys DROP COLUMN workspace_id;
+
+ALTER TABLE tasks DROP CONSTRAINT fk_tasks_parent_workspace;
+ALTER TABLE tasks DROP CONSTRAINT fk_tasks_list_workspace;
+ALTER TABLE tasks DROP CONSTRAINT uq_tasks_id_workspace_id;
+ALTER TABLE tasks DROP COLUMN workspace_id;
+
+ALTER TABLE lists DROP CONSTRAINT uq_lists_id_workspace_id;
+ALTER TABLE lists DROP COLUMN workspace_id;
+
+DROP TABLE IF EXISTS workspaces;
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- Рабочие пространства: списки и задачи разных рабочих пространств изолированы друг от друга
+CREATE TABLE IF NOT EXISTS workspaces (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CHECK (length(name) >= 1 AND length(name) <= 100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Рабочее пространство по умолчанию: в нем остаются существующие данные
+-- и выполняются запросы без заголовка X-Workspace-Id
+INSERT INTO workspaces (id, name) VALUES ('00000000-0000-0000-0000-000000000000', 'Default');
+
+-- Строки без явного рабочего пространства попадают в рабочее пространство по умолчанию
+ALTER TABLE lists ADD COLUMN workspace_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES workspaces(id);
+ALTER TABLE lists ADD CONSTRAINT uq_lists_id_workspace_id UNIQUE (id, workspace_id);
+
+ALTER TABLE tasks ADD COLUMN workspace_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE tasks ADD CONSTRAINT uq_tasks_id_workspace_id UNIQUE (id, workspace_id);
+
+-- Задача всегда в том же рабочем пространстве, что и ее список и родительская задача
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_list_workspace
+    FOREIGN KEY (list_id, workspace_id) REFERENCES lists(id, workspace_id) ON DELETE CASCADE;
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_parent_workspace
+    FOREIGN KEY (parent_task_id, workspace_id) REFERENCES tasks(id, workspace_id) ON DELETE CASCADE;
+
+CREATE INDEX idx_lists_workspace_id ON lists(workspace_id);
+CREATE INDEX idx_tasks_workspace_id ON tasks(workspace_id);
+
+-- Ключи и токены, привязанные к рабочему пространству; NULL — без привязки
+ALTER TABLE api_keys ADD COLUMN workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE refresh_tokens ADD COLUMN workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+
+COMMENT ON TABLE workspaces IS 'Рабочие пространства';
+COMMENT ON COLUMN lists.workspace_id IS 'Рабочее пространство списка';
+COMMENT ON COLUMN tasks.workspace_id IS 'Рабочее пространство задачи, совпадает с рабочим пространством списка';
+COMMENT ON COLUMN api_keys.workspace_id IS 'Рабочее пространство, к которому ограничен ключ';
+COMMENT ON COLUMN refresh_tokens.workspace_id IS 'Рабочее пространство, к которому ограничены токены сессии';
\ No newline at end of file
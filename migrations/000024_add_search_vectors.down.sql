@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+DROP INDEX IF EXISTS idx_lists_search_vector;
+
+ALTER TABLE tasks DROP COLUMN search_vector;
+ALTER TABLE lists DROP COLUMN search_vector;
\ No newline at end of file
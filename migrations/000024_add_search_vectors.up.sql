@@ -0,0 +1,17 @@
+-- Полнотекстовый поиск по названиям и описаниям списков и тексту задач.
+-- Векторы вычисляются самой БД при каждом изменении строки; конфигурация russian
+-- приводит русские слова к основе, остальные слова индексируются как есть
+ALTER TABLE lists ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B')
+) STORED;
+
+ALTER TABLE tasks ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('russian', text)
+) STORED;
+
+CREATE INDEX idx_lists_search_vector ON lists USING GIN (search_vector);
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+COMMENT ON COLUMN lists.search_vector IS 'Поисковый вектор: название (вес A) и описание (вес B)';
+COMMENT ON COLUMN tasks.search_vector IS 'Поисковый вектор текста задачи';
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_lists_title_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
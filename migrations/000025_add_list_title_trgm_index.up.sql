@@ -0,0 +1,5 @@
+-- Нечеткий поиск списков по названию: триграммный индекс используется
+-- оператором сходства % и поиском подстроки через ILIKE
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_lists_title_trgm ON lists USING GIN (title gin_trgm_ops);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_list_created_at_id;
+DROP INDEX IF EXISTS idx_lists_workspace_created_at_id;
\ No newline at end of file
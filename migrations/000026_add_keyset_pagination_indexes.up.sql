@@ -0,0 +1,5 @@
+-- Индексы для постраничной выдачи по курсору: выборка продолжается с позиции
+-- (created_at, id) без OFFSET. Порядок колонок совпадает с порядком выдачи:
+-- от новых к старым, при равном времени создания — по возрастанию id
+CREATE INDEX idx_lists_workspace_created_at_id ON lists(workspace_id, created_at DESC, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_tasks_list_created_at_id ON tasks(list_id, created_at DESC, id) WHERE deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Метки с одинаковым именем из разных рабочих пространств сливаются в самую раннюю
+UPDATE task_tags tt SET tag_id = k.keep_id
+FROM (
+    SELECT id, first_value(id) OVER (PARTITION BY lower(name) ORDER BY created_at, id) AS keep_id
+    FROM tags
+) k
+WHERE tt.tag_id = k.id AND k.id <> k.keep_id;
+
+DELETE FROM tags g
+USING tags k
+WHERE lower(k.name) = lower(g.name) AND (k.created_at, k.id) < (g.created_at, g.id);
+
+DROP INDEX IF EXISTS idx_tags_workspace_name_lower;
+ALTER TABLE tags DROP CONSTRAINT fk_tags_workspace;
+ALTER TABLE tags DROP COLUMN workspace_id;
+
+CREATE UNIQUE INDEX idx_tags_name_lower ON tags(lower(name));
+
+COMMENT ON COLUMN tags.name IS 'Название метки (1-50 символов, уникально без учета регистра)';
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- Метки принадлежат рабочему пространству: имена уникальны в пределах рабочего пространства
+ALTER TABLE tags ADD COLUMN workspace_id UUID;
+
+-- Метка получает рабочее пространство задач, которым она назначена;
+-- неиспользуемые метки остаются в рабочем пространстве по умолчанию
+UPDATE tags g SET workspace_id = COALESCE(
+    (SELECT t.workspace_id
+     FROM task_tags tt
+     JOIN tasks t ON t.id = tt.task_id
+     WHERE tt.tag_id = g.id
+     ORDER BY t.workspace_id
+     LIMIT 1),
+    '00000000-0000-0000-0000-000000000000'
+);
+
+DROP INDEX IF EXISTS idx_tags_name_lower;
+
+-- Метка, назначенная задачам нескольких рабочих пространств, копируется
+-- в каждое из остальных, и назначения переводятся на копии
+CREATE TEMP TABLE tag_workspace_copies AS
+SELECT s.tag_id, s.workspace_id, gen_random_uuid() AS new_id
+FROM (
+    SELECT DISTINCT tt.tag_id, t.workspace_id
+    FROM task_tags tt
+    JOIN tasks t ON t.id = tt.task_id
+    JOIN tags g ON g.id = tt.tag_id
+    WHERE t.workspace_id <> g.workspace_id
+) s;
+
+INSERT INTO tags (id, name, created_at, workspace_id)
+SELECT c.new_id, g.name, g.created_at, c.workspace_id
+FROM tag_workspace_copies c
+JOIN tags g ON g.id = c.tag_id;
+
+UPDATE task_tags tt SET tag_id = c.new_id
+FROM tasks t, tag_workspace_copies c
+WHERE t.id = tt.task_id AND c.tag_id = tt.tag_id AND c.workspace_id = t.workspace_id;
+
+DROP TABLE tag_workspace_copies;
+
+ALTER TABLE tags ALTER COLUMN workspace_id SET DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE tags ALTER COLUMN workspace_id SET NOT NULL;
+ALTER TABLE tags ADD CONSTRAINT fk_tags_workspace
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id);
+
+-- Имена меток уникальны без учета регистра в пределах рабочего пространства
+CREATE UNIQUE INDEX idx_tags_workspace_name_lower ON tags(workspace_id, lower(name));
+
+COMMENT ON COLUMN tags.workspace_id IS 'Рабочее пространство метки';
+COMMENT ON COLUMN tags.name IS 'Название метки (1-50 символов, уникально без учета регистра в пределах рабочего пространства)';
\ No newline at end of file
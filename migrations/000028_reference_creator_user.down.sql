@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_tasks_created_by;
+DROP INDEX IF EXISTS idx_lists_created_by;
+
+ALTER TABLE lists ADD COLUMN created_by_name VARCHAR(100);
+ALTER TABLE tasks ADD COLUMN created_by_name VARCHAR(100);
+
+-- Автором снова становится имя пользователя
+UPDATE lists l SET created_by_name = u.name FROM users u WHERE u.id = l.created_by;
+UPDATE tasks t SET created_by_name = u.name FROM users u WHERE u.id = t.created_by;
+
+ALTER TABLE lists DROP COLUMN created_by;
+ALTER TABLE tasks DROP COLUMN created_by;
+ALTER TABLE lists RENAME COLUMN created_by_name TO created_by;
+ALTER TABLE tasks RENAME COLUMN created_by_name TO created_by;
+
+COMMENT ON COLUMN lists.created_by IS 'Автор списка (заголовок X-Actor)';
+COMMENT ON COLUMN tasks.created_by IS 'Автор задачи (заголовок X-Actor)';
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- Автор списка и задачи — пользователь, а не произвольный текст из заголовка X-Actor
+ALTER TABLE lists ADD COLUMN created_by_user UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN created_by_user UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Прежний автор сохраняется, если это ID пользователя или имя ровно одного пользователя
+-- (при входе по токену автором записывалось имя пользователя)
+UPDATE lists l SET created_by_user = COALESCE(
+    (SELECT u.id FROM users u WHERE u.id::text = l.created_by),
+    (SELECT (array_agg(u.id))[1] FROM users u WHERE u.name = l.created_by HAVING COUNT(*) = 1)
+)
+WHERE l.created_by IS NOT NULL;
+
+UPDATE tasks t SET created_by_user = COALESCE(
+    (SELECT u.id FROM users u WHERE u.id::text = t.created_by),
+    (SELECT (array_agg(u.id))[1] FROM users u WHERE u.name = t.created_by HAVING COUNT(*) = 1)
+)
+WHERE t.created_by IS NOT NULL;
+
+ALTER TABLE lists DROP COLUMN created_by;
+ALTER TABLE tasks DROP COLUMN created_by;
+ALTER TABLE lists RENAME COLUMN created_by_user TO created_by;
+ALTER TABLE tasks RENAME COLUMN created_by_user TO created_by;
+
+-- Индексы для обнуления автора при удалении пользователя
+CREATE INDEX idx_lists_created_by ON lists(created_by) WHERE created_by IS NOT NULL;
+CREATE INDEX idx_tasks_created_by ON tasks(created_by) WHERE created_by IS NOT NULL;
+
+COMMENT ON COLUMN lists.created_by IS 'Пользователь, создавший список';
+COMMENT ON COLUMN tasks.created_by IS 'Пользователь, создавший задачу';
\ No newline at end of file
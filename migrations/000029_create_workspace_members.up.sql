@@ -0,0 +1,24 @@
+-- Участники рабочих пространств: пользователь входит и видит данные только
+-- в рабочих пространствах, в которых состоит
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Индекс для выборки рабочих пространств пользователя
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- Существующие пользователи состоят в рабочем пространстве по умолчанию
+-- и в рабочих пространствах, где они участвуют в списках, исполняют задачи или уже входили
+INSERT INTO workspace_members (workspace_id, user_id)
+SELECT '00000000-0000-0000-0000-000000000000', id FROM users
+UNION
+SELECT l.workspace_id, m.user_id FROM list_members m JOIN lists l ON l.id = m.list_id
+UNION
+SELECT t.workspace_id, t.assignee_id FROM tasks t WHERE t.assignee_id IS NOT NULL
+UNION
+SELECT r.workspace_id, r.user_id FROM refresh_tokens r WHERE r.workspace_id IS NOT NULL;
+
+COMMENT ON TABLE workspace_members IS 'Пользователи, состоящие в рабочих пространствах';
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Ключ без права admin действует от имени пользователя: его роли в списках
+-- ограничивают запросы с ключом. Ключи удаляются вместе с пользователем
+ALTER TABLE api_keys ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id) WHERE user_id IS NOT NULL;
+
+COMMENT ON COLUMN api_keys.user_id IS 'Пользователь, от имени которого действует ключ; NULL — ключ admin без пользователя';
+
+-- Списки, созданные без пользователя, получают владельцем автора, если он известен
+INSERT INTO list_members (list_id, user_id, role)
+SELECT l.id, l.created_by, 'owner'
+FROM lists l
+WHERE l.created_by IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM list_members m WHERE m.list_id = l.id);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence_parent_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Задача, из которой создано повторение. У задачи может быть только одно
+-- следующее повторение: повторное завершение не создает дубликат
+ALTER TABLE tasks ADD COLUMN recurrence_parent_id UUID UNIQUE REFERENCES tasks(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN tasks.recurrence_parent_id IS 'Повторяющаяся задача, завершение которой создало эту задачу';
\ No newline at end of file
@@ -0,0 +1,49 @@
+-- Необязательная защита изоляции рабочих пространств на уровне БД (row-level security).
+-- Не применяется migrate: выполняется вручную после миграций 000023 и 000027 (make db-rls)
+-- и работает вместе с DB_ROW_LEVEL_SECURITY=true, при котором сервер передает
+-- рабочее пространство запроса в параметр сессии app.workspace_id.
+-- Пустой или не заданный параметр — фоновые задачи и администрирование: видны все строки.
+-- Отключение: ALTER TABLE lists NO FORCE ROW LEVEL SECURITY; ALTER TABLE lists DISABLE ROW LEVEL SECURITY;
+-- (то же для tasks и tags).
+
+ALTER TABLE lists ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tags ENABLE ROW LEVEL SECURITY;
+
+-- Политики действуют и на владельца таблиц, под которым обычно работает сервер
+ALTER TABLE lists FORCE ROW LEVEL SECURITY;
+ALTER TABLE tasks FORCE ROW LEVEL SECURITY;
+ALTER TABLE tags FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS workspace_isolation ON lists;
+CREATE POLICY workspace_isolation ON lists
+    USING (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    )
+    WITH CHECK (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    );
+
+DROP POLICY IF EXISTS workspace_isolation ON tasks;
+CREATE POLICY workspace_isolation ON tasks
+    USING (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    )
+    WITH CHECK (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    );
+
+DROP POLICY IF EXISTS workspace_isolation ON tags;
+CREATE POLICY workspace_isolation ON tags
+    USING (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    )
+    WITH CHECK (
+        COALESCE(current_setting('app.workspace_id', true), '') = ''
+        OR workspace_id = current_setting('app.workspace_id', true)::uuid
+    );
\ No newline at end of file